@@ -37,6 +37,62 @@ var messagesEn = map[string]util.Message{
 		RC:          500,
 		Action:      "Review the error that is returned. If the file share creation service is currently unavailable, try to manually create the file share with the 'ibmcloud is share-create' command.",
 	},
+	"VolumeNameConflict": {
+		Code:        "VolumeNameConflict",
+		Description: "A file share named '%s' already exists with ID '%s', but it does not match the request: %s",
+		Type:        util.ProvisioningFailed,
+		RC:          409,
+		Action:      "Run 'ibmcloud is share <SHARE-ID>' to review the existing file share. Either delete it, or request the volume under another name.",
+	},
+	"VolumeNameConflictState": {
+		Code:        "VolumeNameConflictState",
+		Description: "A file share named '%s' already exists with ID '%s', but it cannot be used in the '%s' lifecycle state",
+		Type:        util.ProvisioningFailed,
+		RC:          409,
+		Action:      "Run 'ibmcloud is share <SHARE-ID>' to review the existing file share and its lifecycle reasons. Either delete it, or request the volume under another name.",
+	},
+	"VolumeCapacityNotAllowed": {
+		Code:        "VolumeCapacityNotAllowed",
+		Description: "The capacity %d GB is not allowed by the share profile '%s', which allows %s GB.",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Request a capacity allowed by the share profile, or another share profile. Run 'ibmcloud is share-profiles' to list the share profiles.",
+	},
+	"VolumeIopsNotAllowed": {
+		Code:        "VolumeIopsNotAllowed",
+		Description: "The IOPS %d is not allowed by the share profile '%s', which allows %s IOPS.",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Request IOPS allowed by the share profile, or another share profile. Run 'ibmcloud is share-profile <PROFILE-NAME>' to review the share profile.",
+	},
+	"VolumeBandwidthNotAllowed": {
+		Code:        "VolumeBandwidthNotAllowed",
+		Description: "The bandwidth %d Mbps is not allowed by the share profile '%s', which allows %s Mbps.",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Request a bandwidth allowed by the share profile, or another share profile. Run 'ibmcloud is share-profile <PROFILE-NAME>' to review the share profile.",
+	},
+	"ListShareProfilesFailed": {
+		Code:        "ListShareProfilesFailed",
+		Description: "Unable to fetch the list of share profiles.",
+		Type:        util.RetrivalFailed,
+		RC:          500,
+		Action:      "Run 'ibmcloud is share-profiles' to list the share profiles. Please check backend error for more details.",
+	},
+	"ShareProfileLookupFailed": {
+		Code:        "ShareProfileLookupFailed",
+		Description: "Unable to fetch the share profile '%s'.",
+		Type:        util.RetrivalFailed,
+		RC:          404,
+		Action:      "Verify the name of the share profile. Run 'ibmcloud is share-profiles' to list the share profiles. Please check backend error for more details.",
+	},
+	"InvalidEncryptionKeyCRN": {
+		Code:        "InvalidEncryptionKeyCRN",
+		Description: "The specified encryption key CRN '%s' is not valid.",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Provide the CRN of a root key, of the form 'crn:v1:bluemix:public:kms:<REGION>:a/<ACCOUNT-ID>:<INSTANCE-ID>:key:<KEY-ID>'. Run 'ibmcloud kp keys' to list the keys of your Key Protect instance.",
+	},
 	"FailedToDeleteVolume": {
 		Code:        "FailedToDeleteVolume",
 		Description: "The file share ID '%d' could not be deleted from your VPC.",
@@ -44,6 +100,97 @@ var messagesEn = map[string]util.Message{
 		RC:          500,
 		Action:      "Verify that the file share ID exists. Run 'ibmcloud is shares' to list available file shares in your account. If the ID is correct, try to delete the file share with the 'ibmcloud is share-delete' command. ",
 	},
+	"VolumeCascadeDeleteFailed": {
+		Code:        "VolumeCascadeDeleteFailed",
+		Description: "The file share ID '%s' was not deleted from your VPC, as %d of its %d mount targets and snapshots could not be deleted.",
+		Type:        util.DeletionFailed,
+		RC:          500,
+		Action:      "Review the error that is returned for each mount target and snapshot, then delete the file share again. Run 'ibmcloud is share-mount-targets <SHARE_ID>' and 'ibmcloud is share-snapshots <SHARE_ID>' to list the remaining ones.",
+	},
+	"FailedToModifyVolume": {
+		Code:        "FailedToModifyVolume",
+		Description: "The file share ID '%s' could not be modified.",
+		Type:        util.UpdateFailed,
+		RC:          500,
+		Action:      "Review the error that is returned. Run 'ibmcloud is share <SHARE-ID>' to check that the file share is stable, then try the modification again.",
+	},
+	"InvalidModifyVolumeRequest": {
+		Code:        "InvalidModifyVolumeRequest",
+		Description: "The requested modification of the file share ID '%s' is not valid: %s",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Review the error that is returned, then correct the requested values and try again.",
+	},
+	"InvalidReplicaVolumeRequest": {
+		Code:        "InvalidReplicaVolumeRequest",
+		Description: "The requested replica '%s' of a file share is not valid: %s",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Review the error that is returned, then correct the request and try again.",
+	},
+	"FailedToCreateReplicaVolume": {
+		Code:        "FailedToCreateReplicaVolume",
+		Description: "The replica '%s' of the file share '%s' could not be created.",
+		Type:        util.ProvisioningFailed,
+		RC:          500,
+		Action:      "Review the error that is returned. Run 'ibmcloud is share <SHARE-ID>' to check that the source file share is stable and has no replica yet.",
+	},
+	"VolumeNotReplica": {
+		Code:        "VolumeNotReplica",
+		Description: "The file share ID '%s' is not a replica share, its replication role is '%s'.",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Run 'ibmcloud is share <SHARE-ID>' to find the replica share of the replication, then try again with its ID.",
+	},
+	"FailedToFailoverVolume": {
+		Code:        "FailedToFailoverVolume",
+		Description: "The replica file share ID '%s' could not take over from its source file share.",
+		Type:        util.UpdateFailed,
+		RC:          500,
+		Action:      "Review the error that is returned. Run 'ibmcloud is share <SHARE-ID>' to check the replication status of the file share, it must be active.",
+	},
+	"FailedToSplitReplicaVolume": {
+		Code:        "FailedToSplitReplicaVolume",
+		Description: "The replica file share ID '%s' could not be split from its source file share.",
+		Type:        util.UpdateFailed,
+		RC:          500,
+		Action:      "Review the error that is returned. Run 'ibmcloud is share <SHARE-ID>' to check the replication status of the file share, it must be active.",
+	},
+	"VolumeReplicationNotInValidState": {
+		Code:        "VolumeReplicationNotInValidState",
+		Description: "The replication of the file share ID '%s' did not become %s within the timeout period.",
+		Type:        util.UpdateFailed,
+		RC:          500,
+		Action:      "Run 'ibmcloud is share <SHARE-ID>' and check the replication status and its reasons. If the replication is degraded, contact support.",
+	},
+	"InvalidAccessorVolumeRequest": {
+		Code:        "InvalidAccessorVolumeRequest",
+		Description: "The requested accessor '%s' of a file share is not valid: %s",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Review the error that is returned, then correct the request and try again.",
+	},
+	"FailedToCreateAccessorVolume": {
+		Code:        "FailedToCreateAccessorVolume",
+		Description: "The accessor '%s' of the origin file share '%s' could not be created.",
+		Type:        util.ProvisioningFailed,
+		RC:          500,
+		Action:      "Review the error that is returned. Check that the origin file share is stable, that its access control mode is 'security_group', and that your account is authorized to access it.",
+	},
+	"ListAccessorBindingsFailed": {
+		Code:        "ListAccessorBindingsFailed",
+		Description: "Unable to fetch the list of accessor bindings of the file share ID '%s'.",
+		Type:        util.RetrivalFailed,
+		RC:          500,
+		Action:      "Run 'ibmcloud is share-accessor-bindings <SHARE-ID>' to list the accessor bindings. Please check backend error for more details.",
+	},
+	"FailedToDeleteAccessorBinding": {
+		Code:        "FailedToDeleteAccessorBinding",
+		Description: "The accessor binding ID '%s' of the file share ID '%s' could not be deleted.",
+		Type:        util.DeletionFailed,
+		RC:          500,
+		Action:      "Review the error that is returned. Run 'ibmcloud is share-accessor-bindings <SHARE-ID>' to check that the accessor binding exists and is stable, then try again.",
+	},
 	"FailedToExpandVolume": {
 		Code:        "FailedToExpandVolume",
 		Description: "The volume ID '%s' could not be expanded from your VPC.",
@@ -58,6 +205,20 @@ var messagesEn = map[string]util.Message{
 		RC:          500,
 		Action:      "Verify that the file share ID exists. Run 'ibmcloud is shares' to list available file share in your account.",
 	},
+	"FailedToReconcileVolumeTags": {
+		Code:        "FailedToReconcileVolumeTags",
+		Description: "The %s tags of the file share ID '%s' could not be updated.",
+		Type:        util.UpdateFailed,
+		RC:          500,
+		Action:      "Review the error that is returned. Run 'ibmcloud resource tags --attached-to <SHARE-CRN>' to check the tags of the file share, then try again.",
+	},
+	"TagServiceNotConfigured": {
+		Code:        "TagServiceNotConfigured",
+		Description: "The tags of the file share ID '%s' cannot be updated because no Global Tagging endpoint is configured.",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Set the IBMCLOUD_GT_API_ENDPOINT environment variable to the Global Tagging endpoint, e.g. 'https://tags.global-search-tagging.cloud.ibm.com', and try again.",
+	},
 	"StorageFindFailedWithVolumeId": {
 		Code:        "StorageFindFailedWithVolumeId",
 		Description: "A file share with the specified file share ID '%s' could not be found.",
@@ -225,6 +386,13 @@ var messagesEn = map[string]util.Message{
 		RC:          404,
 		Action:      "Run 'ibmcloud is share-snapshots --share <share-id>' to list available snapshots in your account.Please check backend error for more details.",
 	},
+	"ListVolumeAccessPointsFailed": {
+		Code:        "ListVolumeAccessPointsFailed",
+		Description: "Unable to fetch list of mount targets for file share ID '%s'",
+		Type:        util.RetrivalFailed,
+		RC:          404,
+		Action:      "Run 'ibmcloud is share-mount-targets <share-id>' to list available mount targets of the file share. Please check backend error for more details.",
+	},
 	"InvalidListSnapshotLimit": {
 		Code:        "InvalidListSnapshotLimit",
 		Description: "The value '%v' specified in the limit parameter of the list snapshot call is not valid.",
@@ -246,6 +414,41 @@ var messagesEn = map[string]util.Message{
 		RC:          500,
 		Action:      "Check whether the snapshot ID exists. You may need to verify by using 'ibmcloud is share-snapshot <share-id> <snapshot-id>' cli",
 	},
+	"FailedToUpdateSnapshot": {
+		Code:        "FailedToUpdateSnapshot",
+		Description: "The snapshot ID '%s' of share ID '%s' could not be updated.",
+		Type:        util.UpdateFailed,
+		RC:          500,
+		Action:      "Check whether the snapshot ID exists and is stable. You may need to verify by using 'ibmcloud is share-snapshot <share-id> <snapshot-id>' cli. Please check backend error for more details",
+	},
+	"SnapshotNotReady": {
+		Code:        "SnapshotNotReady",
+		Description: "The snapshot ID '%s' of share ID '%s' did not get ready (stable) within timeout period.",
+		Type:        util.ProvisioningFailed,
+		RC:          500,
+		Action:      "Run 'ibmcloud is share-snapshot <share-id> <snapshot-id>' and check the current status. Please check backend error for more details",
+	},
+	"SnapshotFailed": {
+		Code:        "SnapshotFailed",
+		Description: "The snapshot ID '%s' of share ID '%s' is in the failed state.",
+		Type:        util.ProvisioningFailed,
+		RC:          500,
+		Action:      "Delete the snapshot and create it again. Please check backend error for the lifecycle reasons of the snapshot",
+	},
+	"InvalidRetentionPolicy": {
+		Code:        "InvalidRetentionPolicy",
+		Description: "The snapshot retention policy '%s' is not valid: %s.",
+		Type:        util.InvalidRequest,
+		RC:          400,
+		Action:      "Set at least one of the keep rules of the policy, with values which are not negative.",
+	},
+	"SnapshotRetentionFailed": {
+		Code:        "SnapshotRetentionFailed",
+		Description: "Snapshot retention failed for %d of %d file shares.",
+		Type:        util.DeletionFailed,
+		RC:          500,
+		Action:      "Check the results of the file shares for the snapshots which could not be listed or deleted and run the retention again. Please check backend error for more details",
+	},
 	"FailedToFindSnapshot": {
 		Code:        "FailedToFindSnapshot",
 		Description: "A snapshot with the specified snapshot ID '%s' and share ID '%s' could not be found.",
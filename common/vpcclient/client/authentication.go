@@ -25,18 +25,54 @@ import (
 // token has been provided to the client
 var ErrAuthenticationRequired = errors.New("authentication token required")
 
+// refresher is implemented by handlers which can renew the credentials of a request
+// the API rejected, so that the request can be replayed
+type refresher interface {
+	Refresh(request *Request) error
+}
+
 type authenticationHandler struct {
 	authToken     string
+	tokenSource   TokenSource
 	resourceGroup string
 }
 
+var _ refresher = &authenticationHandler{}
+
 // Before is called before each request
 func (a *authenticationHandler) Before(request *Request) error {
 	request.resourceGroup = a.resourceGroup
 
-	if a.authToken == "" {
+	authToken := a.authToken
+	if a.tokenSource != nil {
+		var err error
+		if authToken, err = a.tokenSource.Token(); err != nil {
+			return err
+		}
+	}
+
+	if authToken == "" {
+		return ErrAuthenticationRequired
+	}
+	request.authToken = authToken
+	request.headers.Set("Authorization", "Bearer "+authToken)
+	return nil
+}
+
+// Refresh replaces the rejected token of request with a fresh one from the token source
+func (a *authenticationHandler) Refresh(request *Request) error {
+	if a.tokenSource == nil {
+		return ErrAuthenticationRequired
+	}
+
+	authToken, err := a.tokenSource.Refresh(request.authToken)
+	if err != nil {
+		return err
+	}
+	if authToken == "" {
 		return ErrAuthenticationRequired
 	}
-	request.headers.Set("Authorization", "Bearer "+a.authToken)
+	request.authToken = authToken
+	request.headers.Set("Authorization", "Bearer "+authToken)
 	return nil
 }
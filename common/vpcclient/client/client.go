@@ -37,8 +37,11 @@ type SessionClient interface {
 	NewRequest(operation *Operation) *Request
 	WithDebug(writer io.Writer) SessionClient
 	WithAuthToken(authToken string) SessionClient
+	WithTokenSource(source TokenSource) SessionClient
 	WithPathParameter(name, value string) SessionClient
 	WithQueryValue(name, value string) SessionClient
+	WithContext(ctx context.Context) SessionClient
+	WithRateLimiter(limiter RateLimiter) SessionClient
 }
 
 type client struct {
@@ -47,6 +50,7 @@ type client struct {
 	pathParams    Params
 	queryValues   url.Values
 	authenHandler handler
+	rateLimiter   RateLimiter
 	debugWriter   io.Writer
 	resourceGroup string
 	contextID     string
@@ -94,6 +98,7 @@ func (c *client) NewRequest(operation *Operation) *Request {
 		operation:     operation,
 		pathParams:    c.pathParams.Copy(),
 		authenHandler: c.authenHandler,
+		rateLimiter:   c.rateLimiter,
 		headers:       headers,
 		debugWriter:   c.debugWriter,
 		resourceGroup: c.resourceGroup,
@@ -115,6 +120,15 @@ func (c *client) WithAuthToken(authToken string) SessionClient {
 	return c
 }
 
+// WithTokenSource supplies the source of the authentication token for all requests made by this session.
+// Unlike WithAuthToken, the token is refreshed before it expires and when the API rejects it
+func (c *client) WithTokenSource(source TokenSource) SessionClient {
+	c.authenHandler = &authenticationHandler{
+		tokenSource: source,
+	}
+	return c
+}
+
 // WithPathParameter adds a path parameter to the request
 func (c *client) WithPathParameter(name, value string) SessionClient {
 	c.pathParams[name] = value
@@ -126,3 +140,35 @@ func (c *client) WithQueryValue(name, value string) SessionClient {
 	c.queryValues.Set(name, value)
 	return c
 }
+
+// WithRateLimiter paces all requests made by this session with the supplied limiter
+func (c *client) WithRateLimiter(limiter RateLimiter) SessionClient {
+	c.rateLimiter = limiter
+	return c
+}
+
+// WithContext returns a copy of this SessionClient whose requests are bound to the supplied context.
+// The receiver is left untouched, so a per-call context never leaks into other callers of the session
+func (c *client) WithContext(ctx context.Context) SessionClient {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	qv := url.Values{}
+	for k, v := range c.queryValues {
+		qv[k] = v
+	}
+
+	return &client{
+		baseURL:       c.baseURL,
+		httpClient:    c.httpClient,
+		pathParams:    c.pathParams.Copy(),
+		queryValues:   qv,
+		authenHandler: c.authenHandler,
+		rateLimiter:   c.rateLimiter,
+		debugWriter:   c.debugWriter,
+		resourceGroup: c.resourceGroup,
+		contextID:     c.contextID,
+		context:       ctx,
+	}
+}
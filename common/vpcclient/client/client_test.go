@@ -20,6 +20,7 @@ package client_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -360,3 +361,24 @@ func TestWithQueryValue(t *testing.T) {
 	assert.NotNil(t, riaas)
 	defer s.Close()
 }
+
+func TestWithContext(t *testing.T) {
+	mux, riaas, teardown := test.SetupServer(t)
+	defer teardown()
+
+	test.SetupMuxResponse(t, mux, "/resource", http.MethodGet, nil, http.StatusOK, "{}", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var result models.Share
+	_, err := riaas.WithContext(ctx).NewRequest(getOperation).JSONSuccess(&result).Invoke()
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+
+	// The original client must not be bound to the cancelled context
+	resp, err := riaas.NewRequest(getOperation).JSONSuccess(&result).Invoke()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
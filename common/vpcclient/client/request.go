@@ -47,6 +47,8 @@ type Request struct {
 	httpClient    *http.Client
 	baseURL       string
 	authenHandler handler
+	authToken     string
+	rateLimiter   RateLimiter
 
 	context context.Context
 
@@ -171,13 +173,37 @@ func (r *Request) JSONError(receiver error) *Request {
 	return r
 }
 
-// Invoke performs the request, and populates the response or error as appropriate
+// Invoke performs the request, and populates the response or error as appropriate.
+// A request rejected because of its token is replayed once with a fresh token
 func (r *Request) Invoke() (*http.Response, error) {
 	err := r.authenHandler.Before(r)
 	if err != nil {
 		return nil, err
 	}
 
+	resp, err := r.invoke()
+	if !tokenRejected(resp, err) || !r.replayable() {
+		return resp, err
+	}
+	tokenRefresher, ok := r.authenHandler.(refresher)
+	if !ok || tokenRefresher.Refresh(r) != nil {
+		return resp, err
+	}
+	return r.invoke()
+}
+
+// replayable tells if the body of the request can be sent again
+func (r *Request) replayable() bool {
+	if r.bodyProvider == nil {
+		return true
+	}
+	_, ok := r.bodyProvider.(*payload.JSONBodyProvider)
+	return ok
+}
+
+// invoke sends the request once
+func (r *Request) invoke() (*http.Response, error) {
+	var err error
 	var body io.Reader
 	if r.bodyProvider != nil {
 		body, err = r.bodyProvider.Body()
@@ -199,6 +225,12 @@ func (r *Request) Invoke() (*http.Response, error) {
 		httpRequest.Header[k] = v
 	}
 
+	if r.rateLimiter != nil {
+		if err = r.rateLimiter.Wait(r.context); err != nil {
+			return nil, err
+		}
+	}
+
 	r.debugRequest(httpRequest)
 
 	resp, err := r.httpClient.Do(httpRequest.WithContext(r.context))
@@ -229,6 +261,7 @@ func (r *Request) Invoke() (*http.Response, error) {
 				}
 			}
 		}
+		err = throttled(resp, err)
 	}
 
 	return resp, err
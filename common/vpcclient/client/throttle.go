@@ -0,0 +1,198 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package client ...
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// throttledErrorCodes are the backend error codes which mean the caller is going too fast,
+// whatever the HTTP status of the response
+var throttledErrorCodes = map[string]bool{
+	"share_snapshot_rate_too_high": true,
+}
+
+// ThrottledError is returned by Invoke when the API asks the caller to slow down, i.e. on
+// 429 Too Many Requests, on 503 Service Unavailable with a Retry-After header, or on a
+// rate limiting error code. It is always worth retrying, after RetryAfter if it is set
+type ThrottledError struct {
+	StatusCode int
+	// RetryAfter is the wait requested by the Retry-After header, 0 if there was none
+	RetryAfter time.Duration
+	// Err is the error decoded from the response body, if any
+	Err error
+}
+
+// Error returns the backend error message, so that callers which only log or wrap
+// the message see the same text as for any other backend error
+func (e *ThrottledError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("request throttled with status %d, retry after %s", e.StatusCode, e.RetryAfter)
+	}
+	return fmt.Sprintf("request throttled with status %d", e.StatusCode)
+}
+
+// Unwrap gives access to the backend error
+func (e *ThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// Temporary tells that the request can be retried
+func (e *ThrottledError) Temporary() bool {
+	return true
+}
+
+// IsThrottled tells if err, or any error it wraps, is a ThrottledError
+func IsThrottled(err error) bool {
+	var throttledErr *ThrottledError
+	return errors.As(err, &throttledErr)
+}
+
+// RetryAfter returns the wait requested by the API when err is a ThrottledError
+func RetryAfter(err error) (time.Duration, bool) {
+	var throttledErr *ThrottledError
+	if !errors.As(err, &throttledErr) {
+		return 0, false
+	}
+	return throttledErr.RetryAfter, true
+}
+
+// throttled wraps err in a ThrottledError when resp or err tell that the request was throttled
+func throttled(resp *http.Response, err error) error {
+	retryAfter, hasRetryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+
+	isThrottled := resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode == http.StatusServiceUnavailable && hasRetryAfter)
+	if apiErr, ok := err.(*models.Error); ok {
+		for _, errorItem := range apiErr.Errors {
+			if throttledErrorCodes[string(errorItem.Code)] {
+				isThrottled = true
+			}
+		}
+	}
+	if !isThrottled {
+		return err
+	}
+	return &ThrottledError{StatusCode: resp.StatusCode, RetryAfter: retryAfter, Err: err}
+}
+
+// parseRetryAfter decodes a Retry-After header, given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// RateLimiter paces the requests sent by a SessionClient
+type RateLimiter interface {
+	// Wait blocks until a request may be sent or ctx is done
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a RateLimiter allowing rate requests per second on average, with bursts of up
+// to burst requests. It is safe for concurrent use, so a single bucket can be shared by sessions
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+var _ RateLimiter = &TokenBucket{}
+
+// NewTokenBucket creates a full token bucket. A burst lower than 1 is raised to 1
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// Wait takes a token from the bucket, waiting for one to be refilled if the bucket is empty
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for {
+		wait := b.reserve()
+		if wait <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available, otherwise it tells how long to wait for the next one
+func (b *TokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rate <= 0 {
+		return 0
+	}
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
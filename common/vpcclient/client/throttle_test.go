@@ -0,0 +1,189 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		value         string
+		expectedWait  time.Duration
+		expectedFound bool
+	}{
+		{name: "missing", value: ""},
+		{name: "seconds", value: "7", expectedWait: 7 * time.Second, expectedFound: true},
+		{name: "negative seconds", value: "-1"},
+		{name: "http date", value: now.Add(90 * time.Second).Format(http.TimeFormat), expectedWait: 90 * time.Second, expectedFound: true},
+		{name: "http date in the past", value: now.Add(-time.Minute).Format(http.TimeFormat), expectedFound: true},
+		{name: "garbage", value: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, found := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.expectedWait, wait)
+			assert.Equal(t, tt.expectedFound, found)
+		})
+	}
+}
+
+func TestInvokeThrottled(t *testing.T) {
+	tests := []struct {
+		name              string
+		status            int
+		retryAfter        string
+		content           string
+		expectedThrottled bool
+		expectedWait      time.Duration
+		expectedErr       string
+	}{
+		{
+			name:              "429 with Retry-After",
+			status:            http.StatusTooManyRequests,
+			retryAfter:        "3",
+			content:           `{"errors":[{"code":"rate_limit_exceeded","message":"slow down"}],"trace":"t1"}`,
+			expectedThrottled: true,
+			expectedWait:      3 * time.Second,
+			expectedErr:       "Trace Code:t1, Code:rate_limit_exceeded, Description:slow down, RC:429 Too Many Requests",
+		}, {
+			name:              "429 without Retry-After",
+			status:            http.StatusTooManyRequests,
+			content:           `{"errors":[{"code":"rate_limit_exceeded","message":"slow down"}],"trace":"t2"}`,
+			expectedThrottled: true,
+			expectedErr:       "Trace Code:t2, Code:rate_limit_exceeded, Description:slow down, RC:429 Too Many Requests",
+		}, {
+			name:              "503 with Retry-After",
+			status:            http.StatusServiceUnavailable,
+			retryAfter:        "1",
+			content:           `{"errors":[{"code":"service_error","message":"busy"}],"trace":"t3"}`,
+			expectedThrottled: true,
+			expectedWait:      time.Second,
+			expectedErr:       "Trace Code:t3, Code:service_error, Description:busy, RC:503 Service Unavailable",
+		}, {
+			name:        "503 without Retry-After",
+			status:      http.StatusServiceUnavailable,
+			content:     `{"errors":[{"code":"service_error","message":"busy"}],"trace":"t4"}`,
+			expectedErr: "Trace Code:t4, Code:service_error, Description:busy, RC:503 Service Unavailable",
+		}, {
+			name:              "rate limiting error code",
+			status:            http.StatusBadRequest,
+			content:           `{"errors":[{"code":"share_snapshot_rate_too_high","message":"too many snapshots"}],"trace":"t5"}`,
+			expectedThrottled: true,
+			expectedErr:       "Trace Code:t5, Code:share_snapshot_rate_too_high, Description:too many snapshots, RC:400 Bad Request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.content)
+			}))
+			defer s.Close()
+
+			c := New(context.Background(), s.URL, url.Values{}, http.DefaultClient, "", "").WithAuthToken("auth-token")
+			var apiErr models.Error
+			_, err := c.NewRequest(&Operation{Name: "Get", Method: http.MethodGet, PathPattern: "/resource"}).JSONError(&apiErr).Invoke()
+
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.expectedErr, err.Error())
+			}
+			assert.Equal(t, tt.expectedThrottled, IsThrottled(err))
+			wait, ok := RetryAfter(err)
+			assert.Equal(t, tt.expectedThrottled, ok)
+			assert.Equal(t, tt.expectedWait, wait)
+
+			// The backend error stays reachable for callers which inspect it
+			var modelErr *models.Error
+			assert.True(t, errors.As(err, &modelErr))
+		})
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	bucket := NewTokenBucket(2, 3)
+	bucket.last = now
+	bucket.now = func() time.Time { return now }
+
+	// The burst is available straight away
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, time.Duration(0), bucket.reserve())
+	}
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve())
+
+	// Tokens refill at the configured rate, never beyond the burst
+	now = now.Add(time.Second)
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+	assert.Equal(t, time.Duration(0), bucket.reserve())
+	assert.Equal(t, 500*time.Millisecond, bucket.reserve())
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, time.Duration(0), bucket.reserve())
+	}
+}
+
+func TestTokenBucketWait(t *testing.T) {
+	bucket := NewTokenBucket(1000, 1)
+	assert.NoError(t, bucket.Wait(context.Background()))
+	start := time.Now()
+	assert.NoError(t, bucket.Wait(context.Background()))
+	assert.True(t, time.Since(start) >= 500*time.Microsecond)
+
+	slow := NewTokenBucket(0.001, 1)
+	assert.NoError(t, slow.Wait(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, slow.Wait(ctx))
+}
+
+func TestInvokeRateLimited(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Drain the bucket, so the request has to wait for a token which never comes in time
+	bucket := NewTokenBucket(0.001, 1)
+	assert.NoError(t, bucket.Wait(context.Background()))
+
+	c := New(ctx, s.URL, url.Values{}, http.DefaultClient, "", "").WithAuthToken("auth-token").WithRateLimiter(bucket)
+	_, err := c.NewRequest(&Operation{Name: "Get", Method: http.MethodGet, PathPattern: "/resource"}).Invoke()
+	assert.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,123 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package client ...
+package client
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// TokenSource supplies the authentication token of a SessionClient, so that a long-lived
+// session keeps working across token expiry
+type TokenSource interface {
+	// Token returns the token to send, refreshing it first if it is about to expire
+	Token() (string, error)
+	// Refresh returns a fresh token after the API rejected the rejected token. Concurrent
+	// callers rejected with the same token share a single refresh
+	Refresh(rejected string) (string, error)
+}
+
+// TokenFetcher fetches a new token along with its expiry, the zero time if unknown
+type TokenFetcher func() (token string, expiry time.Time, err error)
+
+// RefreshingTokenSource is a TokenSource which fetches a new token refreshBefore the current one
+// expires, and whenever the API rejects the current one. It is safe for concurrent use
+type RefreshingTokenSource struct {
+	mu            sync.Mutex
+	fetch         TokenFetcher
+	refreshBefore time.Duration
+	token         string
+	expiry        time.Time
+	now           func() time.Time
+}
+
+var _ TokenSource = &RefreshingTokenSource{}
+
+// NewRefreshingTokenSource creates a RefreshingTokenSource starting with token, which expires at expiry
+func NewRefreshingTokenSource(token string, expiry time.Time, refreshBefore time.Duration, fetch TokenFetcher) *RefreshingTokenSource {
+	return &RefreshingTokenSource{
+		fetch:         fetch,
+		refreshBefore: refreshBefore,
+		token:         token,
+		expiry:        expiry,
+		now:           time.Now,
+	}
+}
+
+// Token returns the current token, or a new one if the current one is about to expire
+func (s *RefreshingTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || s.now().Add(s.refreshBefore).Before(s.expiry)) {
+		return s.token, nil
+	}
+	err := s.refresh()
+	if err != nil && s.token != "" && s.now().Before(s.expiry) {
+		// The current token is still valid for a little while, the next request will try again
+		return s.token, nil
+	}
+	return s.token, err
+}
+
+// Refresh fetches a new token, unless another caller already replaced the rejected one
+func (s *RefreshingTokenSource) Refresh(rejected string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.token != rejected {
+		return s.token, nil
+	}
+	err := s.refresh()
+	return s.token, err
+}
+
+// refresh replaces the current token, the caller must hold the lock
+func (s *RefreshingTokenSource) refresh() error {
+	token, expiry, err := s.fetch()
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return ErrAuthenticationRequired
+	}
+	s.token = token
+	s.expiry = expiry
+	return nil
+}
+
+// tokenRejected tells if the API refused the request because of its authentication token
+func tokenRejected(resp *http.Response, err error) bool {
+	if resp != nil && resp.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+	var apiErr *models.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, errorItem := range apiErr.Errors {
+		if errorItem.Code == models.ErrorCodeTokenInvalid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshingTokenSource(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	var fetches int
+	var fetchErr error
+	source := NewRefreshingTokenSource("token-0", now.Add(time.Hour), 5*time.Minute, func() (string, time.Time, error) {
+		if fetchErr != nil {
+			return "", time.Time{}, fetchErr
+		}
+		fetches++
+		return fmt.Sprintf("token-%d", fetches), now.Add(time.Hour), nil
+	})
+	source.now = func() time.Time { return now }
+
+	// The initial token is used until it is about to expire
+	token, err := source.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-0", token)
+
+	now = now.Add(56 * time.Minute)
+	token, err = source.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// A rejected token is refreshed once, however many requests were rejected with it
+	token, err = source.Refresh("token-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	token, err = source.Refresh("token-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, 2, fetches)
+
+	// A failed proactive refresh keeps the token which has not expired yet
+	fetchErr = errors.New("iam unavailable")
+	now = now.Add(58 * time.Minute)
+	token, err = source.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+
+	now = now.Add(time.Hour)
+	_, err = source.Token()
+	assert.Equal(t, fetchErr, err)
+	_, err = source.Refresh("token-2")
+	assert.Equal(t, fetchErr, err)
+}
+
+func TestInvokeTokenRejected(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		content          string
+		tokenSource      bool
+		body             interface{}
+		expectedRequests int
+		expectedToken    string
+		expectedErr      bool
+	}{
+		{
+			name:             "401 replayed with a fresh token",
+			status:           http.StatusUnauthorized,
+			content:          `{"errors":[{"code":"not_authorized","message":"expired"}],"trace":"t1"}`,
+			tokenSource:      true,
+			expectedRequests: 2,
+			expectedToken:    "Bearer fresh-token",
+		}, {
+			name:             "token_invalid replayed with a fresh token",
+			status:           http.StatusBadRequest,
+			content:          `{"errors":[{"code":"token_invalid","message":"invalid"}],"trace":"t2"}`,
+			tokenSource:      true,
+			body:             map[string]string{"name": "share"},
+			expectedRequests: 2,
+			expectedToken:    "Bearer fresh-token",
+		}, {
+			name:             "static token not replayed",
+			status:           http.StatusUnauthorized,
+			content:          `{"errors":[{"code":"not_authorized","message":"expired"}],"trace":"t3"}`,
+			expectedRequests: 1,
+			expectedToken:    "Bearer stale-token",
+			expectedErr:      true,
+		}, {
+			name:             "other errors not replayed",
+			status:           http.StatusBadRequest,
+			content:          `{"errors":[{"code":"bad_field","message":"bad"}],"trace":"t4"}`,
+			tokenSource:      true,
+			expectedRequests: 1,
+			expectedToken:    "Bearer stale-token",
+			expectedErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			var lastToken string
+			var lastBody bytes.Buffer
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				lastToken = r.Header.Get("Authorization")
+				lastBody.Reset()
+				_, _ = lastBody.ReadFrom(r.Body)
+				w.Header().Set("Content-Type", "application/json")
+				if lastToken == "Bearer fresh-token" {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.content)
+			}))
+			defer s.Close()
+
+			c := New(context.Background(), s.URL, url.Values{}, http.DefaultClient, "", "").WithAuthToken("stale-token")
+			if tt.tokenSource {
+				c.WithTokenSource(NewRefreshingTokenSource("stale-token", time.Time{}, time.Minute, func() (string, time.Time, error) {
+					return "fresh-token", time.Time{}, nil
+				}))
+			}
+
+			var apiErr models.Error
+			request := c.NewRequest(&Operation{Name: "Post", Method: http.MethodPost, PathPattern: "/resource"}).JSONError(&apiErr)
+			if tt.body != nil {
+				request.JSONBody(tt.body)
+			}
+			_, err := request.Invoke()
+
+			assert.Equal(t, tt.expectedErr, err != nil)
+			assert.Equal(t, tt.expectedRequests, requests)
+			assert.Equal(t, tt.expectedToken, lastToken)
+			if tt.body != nil {
+				// The replayed request carries the whole body again
+				assert.JSONEq(t, `{"name":"share"}`, lastBody.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakevpc ...
+package fakevpc
+
+import (
+	"net/http"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// TransitEncryptionNone is the transit encryption of a mount target which does not set any
+const TransitEncryptionNone = "none"
+
+// binding is an accessor binding of an origin share
+type binding struct {
+	lifecycle
+	binding models.ShareAccessorBinding
+	// accessor is the ID of the accessor share
+	accessor string
+}
+
+// bindingView returns the accessor binding as the API shows it, the caller must hold the lock and settle the binding
+func (s *Server) bindingView(b *binding) *models.ShareAccessorBinding {
+	view := b.binding
+	view.LifecycleState = b.state
+	return &view
+}
+
+// settleBinding returns the accessor binding with its state as of now, or nil once it is gone. The caller must hold the lock
+func (s *Server) settleBinding(sh *share, id string) *binding {
+	b, ok := sh.bindings[id]
+	if !ok {
+		return nil
+	}
+	if b.current(s.now()) {
+		return b
+	}
+	delete(sh.bindings, id)
+	sh.bindingOrder = remove(sh.bindingOrder, id)
+	return nil
+}
+
+// bindingIDs returns the IDs of the accessor bindings of sh which still exist, in creation order. The caller must hold the lock
+func (s *Server) bindingIDs(sh *share) []string {
+	ids := []string{}
+	for _, id := range append([]string(nil), sh.bindingOrder...) {
+		if s.settleBinding(sh, id) != nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// accessorBindingRole returns the role of the share in accessor bindings, the caller must hold the lock
+func (s *Server) accessorBindingRole(sh *share) string {
+	switch {
+	case sh.share.OriginShare != nil:
+		return models.AccessorBindingRoleAccessor
+	case len(s.bindingIDs(sh)) > 0:
+		return models.AccessorBindingRoleOrigin
+	}
+	return models.AccessorBindingRoleNone
+}
+
+// createAccessorShare creates the accessor share of the origin share of template, along with its accessor binding.
+// The accessor share has the profile, size and zone of its origin share. The caller must hold the lock
+func (s *Server) createAccessorShare(w http.ResponseWriter, r *http.Request, id string, template models.Share) {
+	origin := s.shareByReference(template.OriginShare)
+	if origin == nil {
+		s.writeErrorLocked(w, http.StatusNotFound, ErrorCodeShareNotFound, "Origin share not found")
+		return
+	}
+	if origin.share.OriginShare != nil {
+		s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeBadField, "The origin share cannot be an accessor share")
+		return
+	}
+	if origin.share.AccessControlMode != "security_group" {
+		s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeBadField, "The access control mode of the origin share must be security_group")
+		return
+	}
+	if origin.state != StateStable {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeStatusPending, "The origin share is "+origin.state)
+		return
+	}
+	if template.ResourceGroup == nil || template.ResourceGroup.ID == "" {
+		template.ResourceGroup = &models.ResourceGroup{ID: "default"}
+	}
+
+	now := s.now()
+	sh := s.newShare(r)
+	sh.share = models.Share{
+		ID:                            id,
+		CRN:                           s.crn(origin.share.Zone.Name, "share", id),
+		Href:                          s.server.URL + "/v1/shares/" + id,
+		Name:                          template.Name,
+		Size:                          origin.share.Size,
+		Iops:                          origin.share.Iops,
+		Profile:                       origin.share.Profile,
+		Zone:                          origin.share.Zone,
+		ResourceGroup:                 template.ResourceGroup,
+		CreatedAt:                     &now,
+		AccessControlMode:             origin.share.AccessControlMode,
+		AllowedTransitEncryptionModes: origin.share.AllowedTransitEncryptionModes,
+		OriginShare:                   origin.reference(),
+		ReplicationRole:               models.ReplicationRoleNone,
+		ReplicationStatus:             models.ReplicationStatusNone,
+	}
+	s.shares[id] = sh
+	s.shareOrder = append(s.shareOrder, id)
+
+	bindingID := s.newID()
+	b := &binding{
+		lifecycle: s.transition(r, StatePending),
+		accessor:  id,
+		binding: models.ShareAccessorBinding{
+			ID:           bindingID,
+			Href:         origin.share.Href + "/accessor_bindings/" + bindingID,
+			CreatedAt:    &now,
+			ResourceType: "share_accessor_binding",
+			Accessor: &models.ShareAccessor{
+				CRN:          sh.share.CRN,
+				Href:         sh.share.Href,
+				ID:           id,
+				Name:         sh.share.Name,
+				ResourceType: "share",
+			},
+		},
+	}
+	origin.bindings[bindingID] = b
+	origin.bindingOrder = append(origin.bindingOrder, bindingID)
+	origin.version++
+
+	w.Header().Set("ETag", sh.etag())
+	s.writeJSON(w, http.StatusCreated, s.shareView(sh))
+}
+
+// unbindAccessor deletes the accessor binding of the accessor share sh along with it, if sh is one. The caller must hold the lock
+func (s *Server) unbindAccessor(r *http.Request, sh *share) {
+	if sh.share.OriginShare == nil {
+		return
+	}
+	origin := s.shareByReference(sh.share.OriginShare)
+	if origin == nil {
+		return
+	}
+	for _, id := range s.bindingIDs(origin) {
+		if b := origin.bindings[id]; b.accessor == sh.share.ID && b.state != StateDeleting {
+			b.lifecycle = s.transition(r, StateDeleting)
+			origin.version++
+		}
+	}
+}
+
+// lookupBinding returns the accessor binding named by the path of r, writing not found if there is none. The caller must hold the lock
+func (s *Server) lookupBinding(w http.ResponseWriter, r *http.Request) (*share, *binding, bool) {
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	b := s.settleBinding(sh, r.PathValue("binding"))
+	if b == nil {
+		s.writeErrorLocked(w, http.StatusNotFound, ErrorCodeBindingNotFound, "Accessor binding not found")
+		return nil, nil, false
+	}
+	return sh, b, true
+}
+
+// listBindings serves GET /v1/shares/{share}/accessor_bindings
+func (s *Server) listBindings(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return
+	}
+	ids := s.bindingIDs(sh)
+
+	pageIDs, limit, first, next, ok := s.page(w, r, ids)
+	if !ok {
+		return
+	}
+	list := models.ShareAccessorBindingList{First: first, Next: next, Limit: limit, TotalCount: len(ids), AccessorBindings: []*models.ShareAccessorBinding{}}
+	for _, id := range pageIDs {
+		list.AccessorBindings = append(list.AccessorBindings, s.bindingView(sh.bindings[id]))
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+// getBinding serves GET /v1/shares/{share}/accessor_bindings/{binding}
+func (s *Server) getBinding(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, b, ok := s.lookupBinding(w, r)
+	if !ok {
+		return
+	}
+	s.writeJSON(w, http.StatusOK, s.bindingView(b))
+}
+
+// deleteBinding serves DELETE /v1/shares/{share}/accessor_bindings/{binding}. Revoking the access deletes the
+// accessor share along with its mount targets
+func (s *Server) deleteBinding(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, b, ok := s.lookupBinding(w, r)
+	if !ok {
+		return
+	}
+	if !b.deletable() {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeStatusPending, "The accessor binding is "+b.state)
+		return
+	}
+	b.lifecycle = s.transition(r, StateDeleting)
+	sh.version++
+
+	if accessor := s.settleShare(b.accessor); accessor != nil && accessor.state != StateDeleting {
+		for _, id := range s.targetIDs(accessor) {
+			accessor.targets[id].lifecycle = s.transition(r, StateDeleting)
+		}
+		accessor.lifecycle = s.transition(r, StateDeleting)
+		accessor.version++
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,219 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakevpc ...
+package fakevpc
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// defaultProfiles is the share profile catalog the fake starts with
+func defaultProfiles() []models.ProfileDetails {
+	return []models.ProfileDetails{
+		{
+			Profile:      models.Profile{Name: "dp2"},
+			Family:       "defined_performance",
+			ResourceType: "share_profile",
+			Capacity:     models.CapIops{Type: "range", Min: 10, Max: 32000, Step: 1, Default: 10},
+			Iops:         models.CapIops{Type: "range", Min: 100, Max: 96000, Step: 1, Default: 100},
+		},
+		{
+			Profile:      models.Profile{Name: "rfs"},
+			Family:       "defined_performance",
+			ResourceType: "share_profile",
+			Capacity:     models.CapIops{Type: "range", Min: 1, Max: 32000, Step: 1, Default: 1},
+			Iops:         models.CapIops{Type: "range", Min: 35000, Max: 35000, Step: 1, Default: 35000},
+		},
+		{
+			Profile:      models.Profile{Name: "tier-10iops"},
+			Family:       "tiered",
+			ResourceType: "share_profile",
+			Capacity:     models.CapIops{Type: "range", Min: 10, Max: 9600, Step: 1, Default: 10},
+			Iops:         models.CapIops{Type: "fixed", Value: 10},
+		},
+	}
+}
+
+// AddProfile adds a share profile to the catalog, or replaces the one with the same name
+func (s *Server) AddProfile(profile models.ProfileDetails) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.profiles {
+		if s.profiles[i].Name == profile.Name {
+			s.profiles[i] = profile
+			return
+		}
+	}
+	s.profiles = append(s.profiles, profile)
+}
+
+// AddSubnet registers a subnet, which mount targets can then be created in
+func (s *Server) AddSubnet(subnet models.Subnet) models.Subnet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if subnet.ID == "" {
+		subnet.ID = s.newID()
+	}
+	if subnet.Href == "" {
+		subnet.Href = s.server.URL + "/v1/subnets/" + subnet.ID
+	}
+	s.subnets = append(s.subnets, subnet)
+	return subnet
+}
+
+// AddSecurityGroup registers a security group
+func (s *Server) AddSecurityGroup(securityGroup models.SecurityGroup) models.SecurityGroup {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if securityGroup.ID == "" {
+		securityGroup.ID = s.newID()
+	}
+	if securityGroup.Href == "" {
+		securityGroup.Href = s.server.URL + "/v1/security_groups/" + securityGroup.ID
+	}
+	s.securityGroups = append(s.securityGroups, securityGroup)
+	return securityGroup
+}
+
+// profile returns the share profile named name, the caller must hold the lock
+func (s *Server) profile(name string) *models.ProfileDetails {
+	for i := range s.profiles {
+		if s.profiles[i].Name == name {
+			profile := s.profiles[i]
+			profile.Href = s.server.URL + "/v1/share/profiles/" + name
+			return &profile
+		}
+	}
+	return nil
+}
+
+// subnet returns the subnet with the given ID, the caller must hold the lock
+func (s *Server) subnet(id string) *models.Subnet {
+	for i := range s.subnets {
+		if s.subnets[i].ID == id {
+			return &s.subnets[i]
+		}
+	}
+	return nil
+}
+
+// listProfiles serves GET /v1/share/profiles
+func (s *Server) listProfiles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byName := map[string]*models.ProfileDetails{}
+	names := []string{}
+	for _, profile := range s.profiles {
+		byName[profile.Name] = s.profile(profile.Name)
+		names = append(names, profile.Name)
+	}
+	sort.Strings(names)
+
+	pageNames, limit, first, next, ok := s.page(w, r, names)
+	if !ok {
+		return
+	}
+	list := models.ProfileList{First: first, Next: next, Limit: limit, TotalCount: len(names), Profiles: []models.ProfileDetails{}}
+	for _, name := range pageNames {
+		list.Profiles = append(list.Profiles, *byName[name])
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+// getProfile serves GET /v1/share/profiles/{profile}
+func (s *Server) getProfile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile := s.profile(r.PathValue("profile"))
+	if profile == nil {
+		s.writeErrorLocked(w, http.StatusNotFound, ErrorCodeProfileNotFound, "Share profile not found")
+		return
+	}
+	s.writeJSON(w, http.StatusOK, profile)
+}
+
+// listSubnets serves GET /v1/subnets
+func (s *Server) listSubnets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	byID := map[string]models.Subnet{}
+	ids := []string{}
+	for _, subnet := range s.subnets {
+		if !matches(query.Get("resource_group.id"), subnet.ResourceGroup != nil, func() string { return subnet.ResourceGroup.ID }) ||
+			!matches(query.Get("vpc.id"), subnet.VPC != nil, func() string { return subnet.VPC.ID }) ||
+			!matches(query.Get("zone.name"), subnet.Zone != nil, func() string { return subnet.Zone.Name }) {
+			continue
+		}
+		byID[subnet.ID] = subnet
+		ids = append(ids, subnet.ID)
+	}
+	sort.Strings(ids)
+
+	pageIDs, limit, first, next, ok := s.page(w, r, ids)
+	if !ok {
+		return
+	}
+	list := models.SubnetList{First: first, Next: next, Limit: limit, TotalCount: len(ids), Subnets: []models.Subnet{}}
+	for _, id := range pageIDs {
+		list.Subnets = append(list.Subnets, byID[id])
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+// listSecurityGroups serves GET /v1/security_groups
+func (s *Server) listSecurityGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	byID := map[string]models.SecurityGroup{}
+	ids := []string{}
+	for _, securityGroup := range s.securityGroups {
+		if !matches(query.Get("resource_group.id"), securityGroup.ResourceGroup != nil, func() string { return securityGroup.ResourceGroup.ID }) ||
+			!matches(query.Get("vpc.id"), securityGroup.VPC != nil, func() string { return securityGroup.VPC.ID }) {
+			continue
+		}
+		byID[securityGroup.ID] = securityGroup
+		ids = append(ids, securityGroup.ID)
+	}
+	sort.Strings(ids)
+
+	pageIDs, limit, first, next, ok := s.page(w, r, ids)
+	if !ok {
+		return
+	}
+	list := models.SecurityGroupList{First: first, Next: next, Limit: limit, TotalCount: len(ids), SecurityGroups: []models.SecurityGroup{}}
+	for _, id := range pageIDs {
+		list.SecurityGroups = append(list.SecurityGroups, byID[id])
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+// matches tells if a resource passes a list filter, present telling if the filtered field is set at all
+func matches(filter string, present bool, value func() string) bool {
+	return filter == "" || (present && value() == filter)
+}
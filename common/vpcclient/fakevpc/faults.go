@@ -0,0 +1,218 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakevpc ...
+package fakevpc
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+)
+
+// Fault is a misbehavior of the fake, injected with Server.InjectFault. By default it fires on every call of
+// its operation; OnCall, Probability and Times narrow that down. When it fires, the fault waits Latency, then
+// drops the connection if Drop is set, else fails the call if Status or Code is set, else lets the call
+// through, dropping its response if DropResponse is set. The resources created, updated or deleted by a
+// call let through stay in their transitional state if Stuck is set, or end up failed if Fail is set
+type Fault struct {
+	// Operation is the operation the fault applies to, e.g. "GetFileShare". Empty applies it to all operations
+	Operation string
+	// OnCall fires the fault on the Nth call of the operation only, counting from when the fault is injected
+	OnCall int
+	// Probability fires the fault on each call with the given probability, using the seed of the fake
+	Probability float64
+	// Times retires the fault after it fired so many times, 0 keeps it firing
+	Times int
+
+	// Latency delays the call
+	Latency time.Duration
+	// Status is the HTTP status to fail the call with. It defaults to 500 when only Code is set
+	Status int
+	// Code is the backend error code to fail the call with, e.g. ErrorCodeInternalError. It defaults to
+	// ErrorCodeTooManyRequests for 429 and to ErrorCodeInternalError otherwise when only Status is set
+	Code string
+	// RetryAfter is sent as the Retry-After header of the failed call, in seconds rounded up
+	RetryAfter time.Duration
+	// Drop closes the connection without handling the call
+	Drop bool
+	// DropResponse handles the call, then closes the connection instead of responding
+	DropResponse bool
+	// Stuck leaves the resources created, updated or deleted by the call in their transitional state for good
+	Stuck bool
+	// Fail moves the resources created, updated or deleted by the call to the failed state once their transition ends
+	Fail bool
+}
+
+// InjectedFault is a fault injected into the fake
+type InjectedFault struct {
+	fault  Fault
+	server *Server
+	calls  int
+	fired  int
+}
+
+// faultEffects are the effects of the faults fired on a call on the resources it changes
+type faultEffects struct {
+	stuck bool
+	fail  bool
+}
+
+// effectsKey is the context key of the faultEffects of a call
+type effectsKey struct{}
+
+// never ends transitional states of stuck resources
+var never = time.Unix(math.MaxInt32, 0)
+
+// WithSeed seeds the source of randomness of the faults fired with a probability
+func (s *Server) WithSeed(seed int64) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.random = rand.New(rand.NewSource(seed)) // #nosec G404: deterministic randomness is the point here
+	return s
+}
+
+// InjectFault makes the fake misbehave as told by fault until it is removed. Faults fire in the order
+// they were injected; the latencies of all the faults firing on a call add up
+func (s *Server) InjectFault(fault Fault) *InjectedFault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if fault.Code != "" && fault.Status == 0 {
+		fault.Status = http.StatusInternalServerError
+	}
+	if fault.Status != 0 && fault.Code == "" {
+		fault.Code = ErrorCodeInternalError
+		if fault.Status == http.StatusTooManyRequests {
+			fault.Code = ErrorCodeTooManyRequests
+		}
+	}
+
+	injected := &InjectedFault{fault: fault, server: s}
+	s.faults = append(s.faults, injected)
+	return injected
+}
+
+// ClearFaults removes all the injected faults. Resources already stuck stay stuck
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Fired returns how many times the fault fired
+func (f *InjectedFault) Fired() int {
+	f.server.mu.Lock()
+	defer f.server.mu.Unlock()
+	return f.fired
+}
+
+// Remove stops the fault from firing
+func (f *InjectedFault) Remove() {
+	f.server.mu.Lock()
+	defer f.server.mu.Unlock()
+	for i, other := range f.server.faults {
+		if other == f {
+			f.server.faults = append(f.server.faults[:i], f.server.faults[i+1:]...)
+			return
+		}
+	}
+}
+
+// fires tells if the fault fires on this call of operation, the caller must hold the lock
+func (f *InjectedFault) fires(operation string, random *rand.Rand) bool {
+	if f.fault.Operation != "" && f.fault.Operation != operation {
+		return false
+	}
+	f.calls++
+	if f.fault.Times > 0 && f.fired >= f.fault.Times {
+		return false
+	}
+	if f.fault.OnCall > 0 && f.calls != f.fault.OnCall {
+		return false
+	}
+	if f.fault.Probability > 0 && random.Float64() >= f.fault.Probability {
+		return false
+	}
+	f.fired++
+	return true
+}
+
+// firingFaults returns copies of the faults firing on this call of operation
+func (s *Server) firingFaults(operation string) []Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	firing := []Fault{}
+	for _, f := range s.faults {
+		if f.fires(operation, s.random) {
+			firing = append(firing, f.fault)
+		}
+	}
+	return firing
+}
+
+// serveWithFaults serves the call of operation through handler, misbehaving as told by the faults firing on it
+func (s *Server) serveWithFaults(w http.ResponseWriter, r *http.Request, operation string, handler http.HandlerFunc) {
+	faults := s.firingFaults(operation)
+
+	var latency time.Duration
+	for _, f := range faults {
+		latency += f.Latency
+	}
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for _, f := range faults {
+		if f.Drop {
+			panic(http.ErrAbortHandler)
+		}
+	}
+	for _, f := range faults {
+		if f.Status != 0 {
+			if f.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(f.RetryAfter.Seconds()))))
+			}
+			s.writeError(w, f.Status, f.Code, "Fault injected into "+operation)
+			return
+		}
+	}
+
+	dropResponse := false
+	effects := faultEffects{}
+	for _, f := range faults {
+		dropResponse = dropResponse || f.DropResponse
+		effects.stuck = effects.stuck || f.Stuck
+		effects.fail = effects.fail || f.Fail
+	}
+	r = r.WithContext(context.WithValue(r.Context(), effectsKey{}, effects))
+	if dropResponse {
+		handler(httptest.NewRecorder(), r)
+		panic(http.ErrAbortHandler)
+	}
+	handler(w, r)
+}
@@ -0,0 +1,204 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fakevpc
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFaultOnCall(t *testing.T) {
+	s, _, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	share, err := shares.CreateFileShare(shareTemplate("share1"), logger)
+	require.NoError(t, err)
+
+	fault := s.InjectFault(Fault{Operation: "GetFileShare", OnCall: 2, Code: ErrorCodeInternalError})
+	_, err = shares.GetFileShare(share.ID, logger)
+	assert.NoError(t, err)
+	_, err = shares.GetFileShare(share.ID, logger)
+	assert.Equal(t, ErrorCodeInternalError, errorCode(err))
+	_, err = shares.GetFileShare(share.ID, logger)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fault.Fired())
+
+	// Faults only fire on their own operation
+	_, err = shares.ListFileShares(0, "", nil, logger)
+	assert.NoError(t, err)
+}
+
+func TestFaultTimesAndRemove(t *testing.T) {
+	s, _, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	share, err := shares.CreateFileShare(shareTemplate("share1"), logger)
+	require.NoError(t, err)
+
+	fault := s.InjectFault(Fault{Operation: "GetFileShare", Times: 2, Status: http.StatusConflict, Code: ErrorCodeStatusPending})
+	for i := 0; i < 2; i++ {
+		_, err = shares.GetFileShare(share.ID, logger)
+		assert.Equal(t, ErrorCodeStatusPending, errorCode(err))
+	}
+	_, err = shares.GetFileShare(share.ID, logger)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fault.Fired())
+
+	fault = s.InjectFault(Fault{Status: http.StatusServiceUnavailable})
+	_, err = shares.GetFileShare(share.ID, logger)
+	assert.Equal(t, ErrorCodeInternalError, errorCode(err))
+	fault.Remove()
+	_, err = shares.GetFileShare(share.ID, logger)
+	assert.NoError(t, err)
+}
+
+func TestFaultProbability(t *testing.T) {
+	fired := func() []bool {
+		s, _, api := setupFake(t)
+		s.WithSeed(42)
+		s.InjectFault(Fault{Operation: "ListFileShares", Probability: 0.5, Code: ErrorCodeServiceError})
+
+		result := []bool{}
+		for i := 0; i < 20; i++ {
+			_, err := api.FileShareService().ListFileShares(0, "", nil, zap.NewNop())
+			result = append(result, err != nil)
+		}
+		return result
+	}
+
+	first := fired()
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+	// The same seed fires the same faults
+	assert.Equal(t, first, fired())
+}
+
+func TestFaultThrottled(t *testing.T) {
+	s, _, api := setupFake(t)
+
+	s.InjectFault(Fault{Operation: "ListFileShares", Status: http.StatusTooManyRequests, RetryAfter: 1500 * time.Millisecond})
+	_, err := api.FileShareService().ListFileShares(0, "", nil, zap.NewNop())
+	require.True(t, client.IsThrottled(err))
+	retryAfter, ok := client.RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, retryAfter)
+}
+
+func TestFaultLatency(t *testing.T) {
+	s, _, api := setupFake(t)
+
+	s.InjectFault(Fault{Operation: "ListFileShares", Latency: 50 * time.Millisecond})
+	s.InjectFault(Fault{Latency: 50 * time.Millisecond})
+	start := time.Now()
+	_, err := api.FileShareService().ListFileShares(0, "", nil, zap.NewNop())
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+
+	s.ClearFaults()
+	start = time.Now()
+	_, err = api.FileShareService().ListFileShares(0, "", nil, zap.NewNop())
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestFaultDrop(t *testing.T) {
+	s, _, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	// A dropped call never reaches the fake
+	s.InjectFault(Fault{Operation: "CreateFileShare", OnCall: 1, Drop: true})
+	_, err := shares.CreateFileShare(shareTemplate("share1"), logger)
+	assert.Error(t, err)
+	list, err := shares.ListFileShares(0, "", nil, logger)
+	require.NoError(t, err)
+	assert.Empty(t, list.Shares)
+
+	// A dropped response loses the outcome of a call which went through
+	s.InjectFault(Fault{Operation: "CreateFileShare", OnCall: 1, DropResponse: true})
+	_, err = shares.CreateFileShare(shareTemplate("share1"), logger)
+	assert.Error(t, err)
+	_, err = shares.CreateFileShare(shareTemplate("share1"), logger)
+	assert.Equal(t, ErrorCodeNameDuplicate, errorCode(err))
+}
+
+func TestFaultStuck(t *testing.T) {
+	s, clock, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	s.InjectFault(Fault{Operation: "CreateFileShare", OnCall: 1, Stuck: true})
+	stuck, err := shares.CreateFileShare(shareTemplate("stuck"), logger)
+	require.NoError(t, err)
+	share, err := shares.CreateFileShare(shareTemplate("share"), logger)
+	require.NoError(t, err)
+
+	clock.Advance(24 * time.Hour)
+	stuck, err = shares.GetFileShare(stuck.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StatePending), stuck.Status)
+	share, err = shares.GetFileShare(share.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StateStable), share.Status)
+
+	s.InjectFault(Fault{Operation: "DeleteFileShare", Stuck: true})
+	require.NoError(t, shares.DeleteFileShare(share.ID, logger))
+	clock.Advance(24 * time.Hour)
+	share, err = shares.GetFileShare(share.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StateDeleting), share.Status)
+}
+
+func TestFaultFail(t *testing.T) {
+	s, clock, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	s.InjectFault(Fault{Operation: "CreateFileShare", OnCall: 1, Fail: true})
+	share, err := shares.CreateFileShare(shareTemplate("failed"), logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StatePending), share.Status)
+
+	clock.Advance(time.Minute)
+	share, err = shares.GetFileShare(share.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StateFailed), share.Status)
+	require.Len(t, share.LifecycleReasons, 1)
+	assert.Equal(t, ErrorCodeInternalError, share.LifecycleReasons[0].Code)
+
+	// Failed shares can be deleted, and so can a share whose deletion failed
+	s.InjectFault(Fault{Operation: "DeleteFileShare", OnCall: 1, Fail: true})
+	require.NoError(t, shares.DeleteFileShare(share.ID, logger))
+	clock.Advance(time.Minute)
+	share, err = shares.GetFileShare(share.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StateFailed), share.Status)
+
+	require.NoError(t, shares.DeleteFileShare(share.ID, logger))
+	clock.Advance(time.Minute)
+	_, err = shares.GetFileShare(share.ID, logger)
+	assert.Equal(t, ErrorCodeShareNotFound, errorCode(err))
+}
@@ -0,0 +1,196 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakevpc ...
+package fakevpc
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// replication tracks a transitional replication status of a share, which becomes next at until
+type replication struct {
+	next    string
+	started time.Time
+	until   time.Time
+}
+
+// settleReplication moves the replication status of the share on once its transition is over. A replica
+// share records the sync the transition stands for
+func (sh *share) settleReplication(now time.Time) {
+	if sh.replication.next == "" || now.Before(sh.replication.until) {
+		return
+	}
+	sh.share.ReplicationStatus = sh.replication.next
+	if sh.share.ReplicationRole == models.ReplicationRoleReplica && sh.share.ReplicationStatus == models.ReplicationStatusActive {
+		started, completed := sh.replication.started, sh.replication.until
+		sh.share.LatestSync = &models.ShareLatestSync{StartedAt: &started, CompletedAt: &completed, DataTransferred: sh.share.Size << 20}
+	}
+	if sh.share.ReplicationStatus == models.ReplicationStatusDegraded {
+		sh.share.ReplicationStatusReasons = []models.ReplicationStatusReason{{Code: ErrorCodeInternalError, Message: "Fault injected: the replication failed"}}
+	}
+	sh.replication = replication{}
+}
+
+// startReplication moves the share to the transitional replication status, which becomes next once the
+// transition delay is over, or degraded when a fault fails the call r. The caller must hold the lock
+func (s *Server) startReplication(r *http.Request, sh *share, status string, next string) {
+	transition := s.transition(r, status)
+	if transition.fails {
+		next = models.ReplicationStatusDegraded
+	}
+	sh.share.ReplicationStatus = status
+	sh.share.ReplicationStatusReasons = nil
+	sh.replication = replication{next: next, started: s.now(), until: transition.until}
+	sh.version++
+}
+
+// reference returns the reference of the share, the caller must hold the lock
+func (sh *share) reference() *models.ShareReference {
+	return &models.ShareReference{ID: sh.share.ID, CRN: sh.share.CRN, Href: sh.share.Href, Name: sh.share.Name}
+}
+
+// shareByReference returns the share with the ID, CRN or href of ref, the caller must hold the lock
+func (s *Server) shareByReference(ref *models.ShareReference) *share {
+	for _, id := range s.shareIDs() {
+		sh := s.shares[id]
+		if (ref.ID != "" && sh.share.ID == ref.ID) || (ref.CRN != "" && sh.share.CRN == ref.CRN) || (ref.Href != "" && sh.share.Href == ref.Href) {
+			return sh
+		}
+	}
+	return nil
+}
+
+// replicationSource returns the source share of the replica share template, which gets the size and profile of
+// the source unless set. The caller must hold the lock
+func (s *Server) replicationSource(template *models.Share) (*share, *apiError) {
+	source := s.shareByReference(template.SourceShare)
+	if source == nil {
+		return nil, &apiError{http.StatusNotFound, ErrorCodeShareNotFound, "Source share not found"}
+	}
+	if source.state != StateStable {
+		return nil, &apiError{http.StatusConflict, ErrorCodeStatusPending, "The source share is " + source.state}
+	}
+	if source.share.ReplicationRole != models.ReplicationRoleNone {
+		return nil, &apiError{http.StatusConflict, ErrorCodeReplicationExists, "The source share already has a replication relationship"}
+	}
+	if template.ReplicationCronSpec == "" {
+		return nil, &apiError{http.StatusBadRequest, ErrorCodeBadField, "replication_cron_spec is required for a replica share"}
+	}
+	if template.Size == 0 {
+		template.Size = source.share.Size
+	}
+	if template.Profile == nil {
+		template.Profile = source.share.Profile
+	}
+	return source, nil
+}
+
+// replicate sets up the replication relationship of source and its new replica share. The caller must hold the lock
+func (s *Server) replicate(r *http.Request, source *share, replica *share) {
+	replica.share.ReplicationRole = models.ReplicationRoleReplica
+	replica.share.SourceShare = source.reference()
+	source.share.ReplicationRole = models.ReplicationRoleSource
+	source.share.ReplicaShare = replica.reference()
+	source.share.ReplicationCronSpec = ""
+	s.startReplication(r, replica, models.ReplicationStatusInitializing, models.ReplicationStatusActive)
+	s.startReplication(r, source, models.ReplicationStatusInitializing, models.ReplicationStatusActive)
+}
+
+// lookupReplica returns the replica share named by the path of r, writing an error if it is not an active
+// replica share. The caller must hold the lock
+func (s *Server) lookupReplica(w http.ResponseWriter, r *http.Request) (*share, bool) {
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return nil, false
+	}
+	if sh.share.ReplicationRole != models.ReplicationRoleReplica {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeReplicationInvalid, "The share is not a replica share")
+		return nil, false
+	}
+	if sh.share.ReplicationStatus != models.ReplicationStatusActive && sh.share.ReplicationStatus != models.ReplicationStatusDegraded {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeReplicationInvalid, "The replication of the share is "+sh.share.ReplicationStatus)
+		return nil, false
+	}
+	return sh, true
+}
+
+// failoverShare serves POST /v1/shares/{share}/failover, swapping the roles of the replica share and its source
+func (s *Server) failoverShare(w http.ResponseWriter, r *http.Request) {
+	var failoverRequest models.ShareFailoverRequest
+	if r.ContentLength != 0 && !s.decode(w, r, &failoverRequest) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replica, ok := s.lookupReplica(w, r)
+	if !ok {
+		return
+	}
+	source := s.shareByReference(replica.share.SourceShare)
+	if source == nil {
+		// The source is gone, the replica takes over on its own if the fallback policy allows it
+		if failoverRequest.FallbackPolicy != models.FailoverFallbackSplit {
+			s.writeErrorLocked(w, http.StatusConflict, ErrorCodeReplicationInvalid, "The source share cannot be reached")
+			return
+		}
+		s.split(r, replica, nil)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	replica.share.ReplicationRole = models.ReplicationRoleSource
+	replica.share.ReplicaShare = source.reference()
+	replica.share.SourceShare = nil
+	source.share.ReplicationRole = models.ReplicationRoleReplica
+	source.share.SourceShare = replica.reference()
+	source.share.ReplicaShare = nil
+	source.share.ReplicationCronSpec, replica.share.ReplicationCronSpec = replica.share.ReplicationCronSpec, ""
+	s.startReplication(r, replica, models.ReplicationStatusFailoverPending, models.ReplicationStatusActive)
+	s.startReplication(r, source, models.ReplicationStatusFailoverPending, models.ReplicationStatusActive)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// deleteShareSource serves DELETE /v1/shares/{share}/source, splitting the replica share from its source
+func (s *Server) deleteShareSource(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replica, ok := s.lookupReplica(w, r)
+	if !ok {
+		return
+	}
+	s.split(r, replica, s.shareByReference(replica.share.SourceShare))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// split ends the replication relationship of the replica share and its source, if still there. The caller must hold the lock
+func (s *Server) split(r *http.Request, replica *share, source *share) {
+	replica.share.ReplicationRole = models.ReplicationRoleNone
+	replica.share.SourceShare = nil
+	replica.share.ReplicationCronSpec = ""
+	s.startReplication(r, replica, models.ReplicationStatusSplitPending, models.ReplicationStatusNone)
+	if source != nil {
+		source.share.ReplicationRole = models.ReplicationRoleNone
+		source.share.ReplicaShare = nil
+		s.startReplication(r, source, models.ReplicationStatusSplitPending, models.ReplicationStatusNone)
+	}
+}
@@ -0,0 +1,356 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakevpc is an in-memory fake of the VPC File API, served over httptest, so that the
+// vpcclient services and the provider session can be exercised end to end without a VPC region.
+// It also serves the tags of its shares and snapshots the way the Global Tagging API does
+package fakevpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// Lifecycle states of shares, mount targets and snapshots
+const (
+	StatePending  = "pending"
+	StateStable   = "stable"
+	StateUpdating = "updating"
+	StateDeleting = "deleting"
+	StateFailed   = "failed"
+
+	// DefaultZone is given to shares created without a zone
+	DefaultZone = "us-south-1"
+	// DefaultPageLimit is the page size of list operations called without a limit
+	DefaultPageLimit = 50
+	// MaxPageLimit is the largest page size the list operations accept
+	MaxPageLimit = 100
+)
+
+// Backend error codes returned by the fake, as returned by the VPC API
+const (
+	ErrorCodeShareNotFound       = "shares_not_found"
+	ErrorCodeTargetNotFound      = "shares_target_not_found"
+	ErrorCodeSnapshotNotFound    = "shares_snapshot_not_found"
+	ErrorCodeProfileNotFound     = "shares_profile_not_found"
+	ErrorCodeSubnetNotFound      = "shares_subnet_not_found"
+	ErrorCodeStatusPending       = "shares_status_pending"
+	ErrorCodeNameDuplicate       = "shares_name_duplicate"
+	ErrorCodeSnapshotDuplicate   = "share_snapshot_name_duplicate"
+	ErrorCodeTargetOnePerVPC     = "shares_target_one_per_vpc"
+	ErrorCodeTargetsExist        = "shares_mount_targets_exist"
+	ErrorCodeCapacityInvalid     = "shares_profile_capacity_invalid"
+	ErrorCodeBadRequest          = "shares_bad_request"
+	ErrorCodeBadField            = "bad_field"
+	ErrorCodePreconditionFailed  = "shares_precondition_failed"
+	ErrorCodeInvalidRoute        = "invalid_route"
+	ErrorCodeTokenInvalid        = string(models.ErrorCodeTokenInvalid)
+	ErrorCodeInternalError       = "internal_error"
+	ErrorCodeServiceError        = "service_error"
+	ErrorCodeTooManyRequests     = "too_many_requests"
+	ErrorCodeSnapshotRateTooHigh = "share_snapshot_rate_too_high"
+	ErrorCodeReplicationInvalid  = "shares_replication_invalid_state"
+	ErrorCodeReplicationExists   = "shares_replication_relationship_exists"
+	ErrorCodeBindingNotFound     = "shares_accessor_binding_not_found"
+	ErrorCodeBindingsExist       = "shares_accessor_bindings_exist"
+	ErrorCodeTagInvalid          = "invalid_tag"
+)
+
+// Server is a fake VPC File API. It keeps shares, mount targets and snapshots in memory and moves
+// them through the pending, updating and deleting lifecycle states as the real API does
+type Server struct {
+	server *httptest.Server
+
+	mu              sync.Mutex
+	now             func() time.Time
+	transitionDelay time.Duration
+	authToken       string
+	ids             int
+	traces          int
+	calls           map[string]int
+	random          *rand.Rand
+	faults          []*InjectedFault
+
+	shares         map[string]*share
+	shareOrder     []string
+	profiles       []models.ProfileDetails
+	subnets        []models.Subnet
+	securityGroups []models.SecurityGroup
+}
+
+// lifecycle tracks the state of a resource. Transitional states end at until, in the failed state if fails is set
+type lifecycle struct {
+	state string
+	until time.Time
+	fails bool
+}
+
+// current settles the state as of now, it returns false once the resource is gone
+func (l *lifecycle) current(now time.Time) bool {
+	if now.Before(l.until) {
+		return true
+	}
+	switch {
+	case l.fails && (l.state == StatePending || l.state == StateUpdating || l.state == StateDeleting):
+		l.state = StateFailed
+	case l.state == StatePending || l.state == StateUpdating:
+		l.state = StateStable
+	case l.state == StateDeleting:
+		return false
+	}
+	return true
+}
+
+// deletable tells if the resource can be deleted in its current state
+func (l *lifecycle) deletable() bool {
+	return l.state == StateStable || l.state == StateFailed
+}
+
+// reasons returns the lifecycle reasons of the resource as the API shows them
+func (l *lifecycle) reasons() []models.LifecycleReason {
+	if l.state != StateFailed {
+		return nil
+	}
+	return []models.LifecycleReason{{Code: ErrorCodeInternalError, Message: "Fault injected: the last change of the resource failed"}}
+}
+
+// NewServer starts a fake VPC File API with the default share profile catalog
+func NewServer() *Server {
+	s := &Server{
+		now:      time.Now,
+		calls:    map[string]int{},
+		shares:   map[string]*share{},
+		profiles: defaultProfiles(),
+		random:   rand.New(rand.NewSource(1)), // #nosec G404: deterministic randomness is the point here
+	}
+
+	mux := http.NewServeMux()
+	s.route(mux, "GET /v1/shares", "ListFileShares", s.listShares)
+	s.route(mux, "POST /v1/shares", "CreateFileShare", s.createShare)
+	s.route(mux, "GET /v1/shares/{share}", "GetFileShare", s.getShare)
+	s.route(mux, "PATCH /v1/shares/{share}", "UpdateFileShare", s.updateShare)
+	s.route(mux, "DELETE /v1/shares/{share}", "DeleteFileShare", s.deleteShare)
+	s.route(mux, "POST /v1/shares/{share}/failover", "FailoverFileShare", s.failoverShare)
+	s.route(mux, "DELETE /v1/shares/{share}/source", "DeleteFileShareSource", s.deleteShareSource)
+	s.route(mux, "GET /v1/shares/{share}/accessor_bindings", "ListFileShareAccessorBindings", s.listBindings)
+	s.route(mux, "GET /v1/shares/{share}/accessor_bindings/{binding}", "GetFileShareAccessorBinding", s.getBinding)
+	s.route(mux, "DELETE /v1/shares/{share}/accessor_bindings/{binding}", "DeleteFileShareAccessorBinding", s.deleteBinding)
+	s.route(mux, "GET /v1/shares/{share}/mount_targets", "ListFileShareTargets", s.listTargets)
+	s.route(mux, "POST /v1/shares/{share}/mount_targets", "CreateFileShareTarget", s.createTarget)
+	s.route(mux, "GET /v1/shares/{share}/mount_targets/{target}", "GetFileShareTarget", s.getTarget)
+	s.route(mux, "DELETE /v1/shares/{share}/mount_targets/{target}", "DeleteFileShareTarget", s.deleteTarget)
+	s.route(mux, "GET /v1/shares/{share}/snapshots", "ListSnapshots", s.listSnapshots)
+	s.route(mux, "POST /v1/shares/{share}/snapshots", "CreateSnapshot", s.createSnapshot)
+	s.route(mux, "GET /v1/shares/{share}/snapshots/{snapshot}", "GetSnapshot", s.getSnapshot)
+	s.route(mux, "PATCH /v1/shares/{share}/snapshots/{snapshot}", "UpdateSnapshot", s.updateSnapshot)
+	s.route(mux, "DELETE /v1/shares/{share}/snapshots/{snapshot}", "DeleteSnapshot", s.deleteSnapshot)
+	s.route(mux, "GET /v1/share/profiles", "ListShareProfiles", s.listProfiles)
+	s.route(mux, "GET /v1/share/profiles/{profile}", "GetShareProfile", s.getProfile)
+	s.route(mux, "GET /v3/tags", "ListTags", s.listTags)
+	s.route(mux, "POST /v3/tags/attach", "AttachTags", s.attachTags)
+	s.route(mux, "POST /v3/tags/detach", "DetachTags", s.detachTags)
+	s.route(mux, "GET /v1/subnets", "ListSubnets", s.listSubnets)
+	s.route(mux, "GET /v1/security_groups", "ListSecurityGroups", s.listSecurityGroups)
+	s.route(mux, "/", "InvalidRoute", func(w http.ResponseWriter, r *http.Request) {
+		s.writeError(w, http.StatusNotFound, ErrorCodeInvalidRoute, "The requested route does not exist")
+	})
+
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+// URL is the base URL of the fake, to use as riaas.Config.BaseURL
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close shuts the fake down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// WithTransitionDelay sets how long resources stay pending, updating or deleting. With no
+// delay, the first read after a change already sees the final state
+func (s *Server) WithTransitionDelay(delay time.Duration) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transitionDelay = delay
+	return s
+}
+
+// WithAuthToken makes the fake reject requests which do not carry token as their bearer token
+func (s *Server) WithAuthToken(token string) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authToken = token
+	return s
+}
+
+// WithClock replaces the clock driving the lifecycle transitions
+func (s *Server) WithClock(now func() time.Time) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+	return s
+}
+
+// route registers handler for pattern under the operation name used by the vpcfilevolume services
+func (s *Server) route(mux *http.ServeMux, pattern string, operation string, handler http.HandlerFunc) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorized(r) {
+			s.writeError(w, http.StatusUnauthorized, ErrorCodeTokenInvalid, "The provided token is invalid or expired")
+			return
+		}
+		s.mu.Lock()
+		s.calls[operation]++
+		s.mu.Unlock()
+
+		w.Header().Set("X-Request-ID", r.Header.Get("X-Request-ID"))
+		s.serveWithFaults(w, r, operation, handler)
+	})
+}
+
+// CallCount returns how many authorized calls were made to operation, e.g. "GetFileShare"
+func (s *Server) CallCount(operation string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[operation]
+}
+
+// authorized checks the bearer token of r
+func (s *Server) authorized(r *http.Request) bool {
+	s.mu.Lock()
+	authToken := s.authToken
+	s.mu.Unlock()
+
+	bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if bearer == "" {
+		return false
+	}
+	return authToken == "" || bearer == authToken
+}
+
+// newID returns an ID shaped like the VPC ones, the caller must hold the lock
+func (s *Server) newID() string {
+	s.ids++
+	return fmt.Sprintf("r006-%08x-0000-4000-8000-%012x", s.ids, s.ids)
+}
+
+// crn returns the CRN of a resource, the caller must hold the lock
+func (s *Server) crn(zone string, resourceType string, id string) string {
+	return fmt.Sprintf("crn:v1:bluemix:public:is:%s:a/fakeaccount::%s:%s", zone, resourceType, id)
+}
+
+// transition starts the transitional state for the call r, which ends as told by the faults fired on r.
+// The caller must hold the lock
+func (s *Server) transition(r *http.Request, state string) lifecycle {
+	effects, _ := r.Context().Value(effectsKey{}).(faultEffects)
+	if effects.stuck {
+		return lifecycle{state: state, until: never}
+	}
+	return lifecycle{state: state, until: s.now().Add(s.transitionDelay), fails: effects.fail}
+}
+
+// writeJSON writes v as the response body
+func (s *Server) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// apiError is an error response of the fake
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+// writeError writes an error payload shaped like models.Error
+func (s *Server) writeError(w http.ResponseWriter, status int, code string, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writeErrorLocked(w, status, code, message)
+}
+
+// writeErrorLocked is writeError for callers which hold the lock
+func (s *Server) writeErrorLocked(w http.ResponseWriter, status int, code string, message string) {
+	s.traces++
+	s.writeJSON(w, status, models.Error{
+		Errors: []models.ErrorItem{{Code: models.ErrorCode(code), Message: message}},
+		Trace:  fmt.Sprintf("fake-trace-%d", s.traces),
+	})
+}
+
+// decode reads the JSON body of r into v, writing a bad request on failure
+func (s *Server) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		s.writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, "Invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// page cuts the page asked by the limit and start query values of r out of the sorted ids, and returns
+// the first and next references of the collection. start is the ID of the first item of the page.
+// The caller must hold the lock
+func (s *Server) page(w http.ResponseWriter, r *http.Request, ids []string) (pageIDs []string, limit int, first *models.HReference, next *models.HReference, ok bool) {
+	limit = DefaultPageLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > MaxPageLimit {
+			s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeBadField, "limit must be between 1 and "+strconv.Itoa(MaxPageLimit))
+			return nil, 0, nil, nil, false
+		}
+		limit = parsed
+	}
+
+	from := 0
+	if start := r.URL.Query().Get("start"); start != "" {
+		// IDs sort in creation order, so a page start deleted meanwhile resumes where it would have been
+		from = sort.SearchStrings(ids, start)
+	}
+	to := from + limit
+	if to > len(ids) {
+		to = len(ids)
+	}
+
+	first = &models.HReference{Href: s.pageHref(r, limit, "")}
+	if to < len(ids) {
+		next = &models.HReference{Href: s.pageHref(r, limit, ids[to])}
+	}
+	return ids[from:to], limit, first, next, true
+}
+
+// pageHref returns the link to the page of the collection of r starting at start
+func (s *Server) pageHref(r *http.Request, limit int, start string) string {
+	query := r.URL.Query()
+	query.Set("limit", strconv.Itoa(limit))
+	query.Del("start")
+	if start != "" {
+		query.Set("start", start)
+	}
+	return s.server.URL + r.URL.Path + "?" + query.Encode()
+}
@@ -0,0 +1,470 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fakevpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testClock is a clock which only moves when told to
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func setupFake(t *testing.T) (*Server, *testClock, riaas.RegionalAPI) {
+	clock := &testClock{now: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	s := NewServer().WithClock(clock.Now).WithTransitionDelay(time.Minute)
+	t.Cleanup(s.Close)
+
+	api, err := riaas.New(riaas.Config{BaseURL: s.URL(), GlobalTaggingURL: s.URL(), HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+	require.NoError(t, api.Login("token"))
+	return s, clock, api
+}
+
+func errorCode(err error) string {
+	var apiErr models.Error
+	switch e := err.(type) {
+	case *models.Error:
+		apiErr = *e
+	case models.Error:
+		apiErr = e
+	}
+	if len(apiErr.Errors) == 0 {
+		return ""
+	}
+	return string(apiErr.Errors[0].Code)
+}
+
+func shareTemplate(name string) *models.Share {
+	return &models.Share{
+		Name:          name,
+		Size:          10,
+		Profile:       &models.Profile{Name: "dp2"},
+		ResourceGroup: &models.ResourceGroup{ID: "rg1"},
+	}
+}
+
+func TestShareLifecycle(t *testing.T) {
+	s, clock, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	share, err := shares.CreateFileShare(shareTemplate("share1"), logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StatePending), share.Status)
+	assert.Equal(t, int64(100), share.Iops)
+	assert.Equal(t, DefaultZone, share.Zone.Name)
+
+	_, err = shares.CreateFileShare(shareTemplate("share1"), logger)
+	assert.Equal(t, ErrorCodeNameDuplicate, errorCode(err))
+
+	// The share settles once the transition delay is over
+	share, err = shares.GetFileShare(share.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StatePending), share.Status)
+	err = shares.DeleteFileShare(share.ID, logger)
+	assert.Equal(t, ErrorCodeStatusPending, errorCode(err))
+	clock.Advance(time.Minute)
+	share, err = shares.GetFileShare(share.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StateStable), share.Status)
+
+	// Updates are guarded by the ETag
+	_, etag, err := shares.GetFileShareEtag(share.ID, logger)
+	require.NoError(t, err)
+	assert.NotEmpty(t, etag)
+	err = shares.UpdateFileShareWithEtag(share.ID, `W/"stale"`, &models.Share{UserTags: []string{"a:b"}}, logger)
+	assert.Equal(t, ErrorCodePreconditionFailed, errorCode(err))
+	require.NoError(t, shares.UpdateFileShareWithEtag(share.ID, etag, &models.Share{UserTags: []string{"a:b"}}, logger))
+	err = shares.UpdateFileShareWithEtag(share.ID, etag, &models.Share{UserTags: []string{"c:d"}}, logger)
+	assert.Equal(t, ErrorCodePreconditionFailed, errorCode(err))
+
+	// Expanding goes through updating
+	share, err = shares.ExpandVolume(share.ID, &models.Share{Size: 20}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StateUpdating), share.Status)
+	assert.Equal(t, int64(20), share.Size)
+	assert.Equal(t, []string{"a:b"}, share.UserTags)
+	_, err = shares.ExpandVolume(share.ID, &models.Share{Size: 10}, logger)
+	assert.Equal(t, ErrorCodeStatusPending, errorCode(err))
+	clock.Advance(time.Minute)
+	_, err = shares.ExpandVolume(share.ID, &models.Share{Size: 10}, logger)
+	assert.Equal(t, ErrorCodeCapacityInvalid, errorCode(err))
+
+	// Deleting shares disappear once the transition delay is over
+	require.NoError(t, shares.DeleteFileShare(share.ID, logger))
+	share, err = shares.GetFileShare(share.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusType(StateDeleting), share.Status)
+	clock.Advance(time.Minute)
+	_, err = shares.GetFileShare(share.ID, logger)
+	assert.Equal(t, ErrorCodeShareNotFound, errorCode(err))
+
+	assert.Equal(t, 5, s.CallCount("GetFileShare"))
+}
+
+func TestShareTargets(t *testing.T) {
+	s, clock, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	subnet := s.AddSubnet(models.Subnet{Name: "subnet1", VPC: &provider.VPC{ID: "vpc2"}, Zone: &models.Zone{Name: DefaultZone}})
+
+	template := shareTemplate("share1")
+	template.ShareTargets = &[]models.ShareTarget{{Name: "target1", VPC: &provider.VPC{ID: "vpc1"}}}
+	share, err := shares.CreateFileShare(template, logger)
+	require.NoError(t, err)
+	require.Len(t, *share.ShareTargets, 1)
+
+	_, err = shares.CreateFileShareTarget(&models.ShareTarget{ShareID: share.ID, VPC: &provider.VPC{ID: "vpc2"}}, logger)
+	assert.Equal(t, ErrorCodeStatusPending, errorCode(err))
+	clock.Advance(time.Minute)
+
+	_, err = shares.CreateFileShareTarget(&models.ShareTarget{ShareID: share.ID, VPC: &provider.VPC{ID: "vpc1"}}, logger)
+	assert.Equal(t, ErrorCodeTargetOnePerVPC, errorCode(err))
+	_, err = shares.CreateFileShareTarget(&models.ShareTarget{ShareID: share.ID, VirtualNetworkInterface: &models.VirtualNetworkInterface{Subnet: &models.SubnetRef{ID: "unknown"}}}, logger)
+	assert.Equal(t, ErrorCodeSubnetNotFound, errorCode(err))
+
+	target, err := shares.CreateFileShareTarget(&models.ShareTarget{
+		ShareID:                 share.ID,
+		Name:                    "target2",
+		VirtualNetworkInterface: &models.VirtualNetworkInterface{Subnet: &models.SubnetRef{ID: subnet.ID}},
+	}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, StatePending, target.Status)
+	assert.Equal(t, "vpc2", target.VPC.ID)
+	assert.NotEmpty(t, target.MountPath)
+
+	clock.Advance(time.Minute)
+	target, err = shares.GetFileShareTarget(share.ID, target.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, StateStable, target.Status)
+
+	// A share cannot be deleted while it has mount targets
+	err = shares.DeleteFileShare(share.ID, logger)
+	assert.Equal(t, ErrorCodeTargetsExist, errorCode(err))
+
+	targets, err := shares.ListFileShareTargets(share.ID, nil, logger)
+	require.NoError(t, err)
+	for _, target := range targets.ShareTargets {
+		_, err = shares.DeleteFileShareTarget(&models.ShareTarget{ShareID: share.ID, ID: target.ID}, logger)
+		require.NoError(t, err)
+	}
+	clock.Advance(time.Minute)
+	_, err = shares.GetFileShareTarget(share.ID, target.ID, logger)
+	assert.Equal(t, ErrorCodeTargetNotFound, errorCode(err))
+	_, err = shares.DeleteFileShareTarget(&models.ShareTarget{ShareID: share.ID, ID: target.ID}, logger)
+	assert.Equal(t, ErrorCodeTargetNotFound, errorCode(err))
+	require.NoError(t, shares.DeleteFileShare(share.ID, logger))
+}
+
+func TestSnapshots(t *testing.T) {
+	_, clock, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+	snapshots := api.SnapshotService()
+
+	share, err := shares.CreateFileShare(shareTemplate("share1"), logger)
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+
+	snapshot, err := snapshots.CreateSnapshot(share.ID, &models.Snapshot{Name: "snap1"}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, StatePending, snapshot.LifecycleState)
+	assert.Equal(t, int64(10), snapshot.MinimumSize)
+	_, err = snapshots.CreateSnapshot(share.ID, &models.Snapshot{Name: "snap1"}, logger)
+	assert.Equal(t, ErrorCodeSnapshotDuplicate, errorCode(err))
+
+	// Shares can only be restored from stable snapshots
+	restore := shareTemplate("restored")
+	restore.Size = 0
+	restore.SourceSnapshot = &models.Snapshot{CRN: snapshot.CRN}
+	_, err = shares.CreateFileShare(restore, logger)
+	assert.Equal(t, ErrorCodeSnapshotNotFound, errorCode(err))
+	clock.Advance(time.Minute)
+	restored, err := shares.CreateFileShare(restore, logger)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), restored.Size)
+	assert.Equal(t, snapshot.ID, restored.SourceSnapshot.ID)
+
+	snapshot, err = snapshots.GetSnapshotByName(share.ID, "snap1", logger)
+	require.NoError(t, err)
+	assert.Equal(t, StateStable, snapshot.LifecycleState)
+
+	// Updates are guarded by the ETag
+	_, etag, err := snapshots.GetSnapshotEtag(share.ID, snapshot.ID, logger)
+	require.NoError(t, err)
+	err = snapshots.UpdateSnapshotWithEtag(share.ID, snapshot.ID, `W/"stale"`, &models.Snapshot{UserTags: []string{"a:b"}}, logger)
+	assert.Equal(t, ErrorCodePreconditionFailed, errorCode(err))
+	require.NoError(t, snapshots.UpdateSnapshotWithEtag(share.ID, snapshot.ID, etag, &models.Snapshot{Name: "snap2", UserTags: []string{"a:b"}}, logger))
+	err = snapshots.UpdateSnapshotWithEtag(share.ID, snapshot.ID, etag, &models.Snapshot{UserTags: []string{"c:d"}}, logger)
+	assert.Equal(t, ErrorCodePreconditionFailed, errorCode(err))
+	snapshot, err = snapshots.GetSnapshot(share.ID, snapshot.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "snap2", snapshot.Name)
+	assert.Equal(t, []string{"a:b"}, snapshot.UserTags)
+
+	require.NoError(t, snapshots.DeleteSnapshot(share.ID, snapshot.ID, logger))
+	clock.Advance(time.Minute)
+	_, err = snapshots.GetSnapshot(share.ID, snapshot.ID, logger)
+	assert.Equal(t, ErrorCodeSnapshotNotFound, errorCode(err))
+}
+
+func TestReplication(t *testing.T) {
+	_, clock, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	source, err := shares.CreateFileShare(shareTemplate("source"), logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReplicationRoleNone, source.ReplicationRole)
+
+	replicaTemplate := &models.Share{Name: "replica", SourceShare: &models.ShareReference{CRN: source.CRN}, ReplicationCronSpec: "0 */6 * * *", Zone: &models.Zone{Name: "us-south-2"}}
+	_, err = shares.CreateFileShare(replicaTemplate, logger)
+	assert.Equal(t, ErrorCodeStatusPending, errorCode(err))
+	clock.Advance(time.Minute)
+
+	replica, err := shares.CreateFileShare(replicaTemplate, logger)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), replica.Size)
+	assert.Equal(t, models.ReplicationRoleReplica, replica.ReplicationRole)
+	assert.Equal(t, models.ReplicationStatusInitializing, replica.ReplicationStatus)
+	assert.Equal(t, source.ID, replica.SourceShare.ID)
+	assert.Equal(t, ErrorCodeReplicationInvalid, errorCode(shares.FailoverFileShare(replica.ID, &models.ShareFailoverRequest{}, logger)))
+	assert.Equal(t, ErrorCodeReplicationExists, errorCode(shares.DeleteFileShare(source.ID, logger)))
+
+	clock.Advance(time.Minute)
+	replica, err = shares.GetFileShare(replica.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReplicationStatusActive, replica.ReplicationStatus)
+	require.NotNil(t, replica.LatestSync)
+
+	// Failing over swaps the roles
+	require.NoError(t, shares.FailoverFileShare(replica.ID, &models.ShareFailoverRequest{FallbackPolicy: models.FailoverFallbackFail}, logger))
+	replica, err = shares.GetFileShare(replica.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReplicationRoleSource, replica.ReplicationRole)
+	assert.Equal(t, models.ReplicationStatusFailoverPending, replica.ReplicationStatus)
+	clock.Advance(time.Minute)
+	source, err = shares.GetFileShare(source.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReplicationRoleReplica, source.ReplicationRole)
+	assert.Equal(t, models.ReplicationStatusActive, source.ReplicationStatus)
+	assert.Equal(t, "0 */6 * * *", source.ReplicationCronSpec)
+
+	// Splitting leaves two independent shares
+	assert.Equal(t, ErrorCodeReplicationInvalid, errorCode(shares.DeleteFileShareSource(replica.ID, logger)))
+	require.NoError(t, shares.DeleteFileShareSource(source.ID, logger))
+	clock.Advance(time.Minute)
+	for _, id := range []string{source.ID, replica.ID} {
+		share, err := shares.GetFileShare(id, logger)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReplicationRoleNone, share.ReplicationRole)
+		assert.Equal(t, models.ReplicationStatusNone, share.ReplicationStatus)
+		assert.Nil(t, share.SourceShare)
+		assert.Nil(t, share.ReplicaShare)
+	}
+	require.NoError(t, shares.DeleteFileShare(source.ID, logger))
+}
+
+func TestAccessorBindings(t *testing.T) {
+	s, clock, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+	subnet := s.AddSubnet(models.Subnet{Name: "subnet1", VPC: &provider.VPC{ID: "vpc2"}, Zone: &models.Zone{Name: DefaultZone}})
+
+	originTemplate := shareTemplate("origin")
+	originTemplate.AccessControlMode = "security_group"
+	originTemplate.AllowedTransitEncryptionModes = []string{"user_managed"}
+	origin, err := shares.CreateFileShare(originTemplate, logger)
+	require.NoError(t, err)
+	_, err = shares.CreateFileShare(&models.Share{Name: "accessor", OriginShare: &models.ShareReference{CRN: origin.CRN}}, logger)
+	assert.Equal(t, ErrorCodeStatusPending, errorCode(err))
+	clock.Advance(time.Minute)
+
+	accessor, err := shares.CreateFileShare(&models.Share{Name: "accessor", OriginShare: &models.ShareReference{CRN: origin.CRN}}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.AccessorBindingRoleAccessor, accessor.AccessorBindingRole)
+	assert.Equal(t, origin.ID, accessor.OriginShare.ID)
+	assert.Equal(t, int64(10), accessor.Size)
+	origin, err = shares.GetFileShare(origin.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.AccessorBindingRoleOrigin, origin.AccessorBindingRole)
+	assert.Equal(t, ErrorCodeBindingsExist, errorCode(shares.DeleteFileShare(origin.ID, logger)))
+
+	bindings, err := shares.ListFileShareAccessorBindings(origin.ID, 0, "", logger)
+	require.NoError(t, err)
+	require.Len(t, bindings.AccessorBindings, 1)
+	assert.Equal(t, accessor.CRN, bindings.AccessorBindings[0].Accessor.CRN)
+	clock.Advance(time.Minute)
+
+	// The mount targets of an accessor share need a virtual network interface and an allowed transit encryption
+	_, err = shares.CreateFileShareTarget(&models.ShareTarget{ShareID: accessor.ID, VPC: &provider.VPC{ID: "vpc2"}}, logger)
+	assert.Equal(t, ErrorCodeBadField, errorCode(err))
+	vniTarget := &models.ShareTarget{ShareID: accessor.ID, VirtualNetworkInterface: &models.VirtualNetworkInterface{Subnet: &models.SubnetRef{ID: subnet.ID}}}
+	_, err = shares.CreateFileShareTarget(vniTarget, logger)
+	assert.Equal(t, ErrorCodeBadField, errorCode(err))
+	vniTarget.TransitEncryption = "user_managed"
+	_, err = shares.CreateFileShareTarget(vniTarget, logger)
+	require.NoError(t, err)
+
+	// Revoking the access deletes the accessor share
+	bindingID := bindings.AccessorBindings[0].ID
+	require.NoError(t, shares.DeleteFileShareAccessorBinding(origin.ID, bindingID, logger))
+	binding, err := shares.GetFileShareAccessorBinding(origin.ID, bindingID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, StateDeleting, binding.LifecycleState)
+	clock.Advance(time.Minute)
+	_, err = shares.GetFileShareAccessorBinding(origin.ID, bindingID, logger)
+	assert.Equal(t, ErrorCodeBindingNotFound, errorCode(err))
+	_, err = shares.GetFileShare(accessor.ID, logger)
+	assert.Equal(t, ErrorCodeShareNotFound, errorCode(err))
+	require.NoError(t, shares.DeleteFileShare(origin.ID, logger))
+}
+
+func TestPagination(t *testing.T) {
+	_, _, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	for i := 0; i < 5; i++ {
+		_, err := shares.CreateFileShare(shareTemplate(fmt.Sprintf("share%d", i)), logger)
+		require.NoError(t, err)
+	}
+
+	page, err := shares.ListFileShares(2, "", nil, logger)
+	require.NoError(t, err)
+	assert.Len(t, page.Shares, 2)
+	assert.Equal(t, 5, page.TotalCount)
+	require.NotNil(t, page.Next)
+
+	all, err := vpcfilevolume.NewFileSharePager(shares, 2, &models.ListShareFilters{ResourceGroupID: "rg1"}, logger).All(context.Background())
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+	for i, share := range all {
+		assert.Equal(t, fmt.Sprintf("share%d", i), share.Name)
+	}
+
+	share, err := shares.GetFileShareByName("share3", logger)
+	require.NoError(t, err)
+	assert.Equal(t, "share3", share.Name)
+
+	_, err = shares.ListFileShares(MaxPageLimit+1, "", nil, logger)
+	assert.Equal(t, ErrorCodeBadField, errorCode(err))
+}
+
+func TestCatalog(t *testing.T) {
+	s, _, api := setupFake(t)
+	logger := zap.NewNop()
+	shares := api.FileShareService()
+
+	profile, err := shares.GetShareProfile("dp2", logger)
+	require.NoError(t, err)
+	assert.Equal(t, int32(32000), profile.Capacity.Max)
+	_, err = shares.GetShareProfile("unknown", logger)
+	assert.Equal(t, ErrorCodeProfileNotFound, errorCode(err))
+
+	s.AddSubnet(models.Subnet{Name: "subnet1", VPC: &provider.VPC{ID: "vpc1"}, Zone: &models.Zone{Name: "us-south-1"}})
+	s.AddSubnet(models.Subnet{Name: "subnet2", VPC: &provider.VPC{ID: "vpc1"}, Zone: &models.Zone{Name: "us-south-2"}})
+	s.AddSecurityGroup(models.SecurityGroup{Name: "sg1", VPC: &provider.VPC{ID: "vpc1"}})
+	s.AddSecurityGroup(models.SecurityGroup{Name: "sg2", VPC: &provider.VPC{ID: "vpc2"}})
+
+	subnets, err := shares.ListSubnets(0, "", &models.ListSubnetFilters{VPCID: "vpc1", ZoneName: "us-south-2"}, logger)
+	require.NoError(t, err)
+	require.Len(t, subnets.Subnets, 1)
+	assert.Equal(t, "subnet2", subnets.Subnets[0].Name)
+
+	securityGroups, err := shares.ListSecurityGroups(0, "", &models.ListSecurityGroupFilters{VPCID: "vpc2"}, logger)
+	require.NoError(t, err)
+	require.Len(t, securityGroups.SecurityGroups, 1)
+	assert.Equal(t, "sg2", securityGroups.SecurityGroups[0].Name)
+}
+
+func TestAuthToken(t *testing.T) {
+	s, _, api := setupFake(t)
+	s.WithAuthToken("another-token")
+
+	_, err := api.FileShareService().GetFileShare("r006-unknown", zap.NewNop())
+	assert.Equal(t, ErrorCodeTokenInvalid, errorCode(err))
+	assert.Equal(t, 0, s.CallCount("GetFileShare"))
+}
+
+func TestTags(t *testing.T) {
+	_, _, api := setupFake(t)
+	logger := zap.NewNop()
+	tags := api.(riaas.TaggingAPI).TagService()
+
+	template := shareTemplate("share1")
+	template.UserTags = []string{"team-a"}
+	share, err := api.FileShareService().CreateFileShare(template, logger)
+	require.NoError(t, err)
+
+	require.NoError(t, tags.AttachTags(share.CRN, models.TagTypeUser, []string{"Team-A", "env-dev"}, logger))
+	require.NoError(t, tags.AttachTags(share.CRN, models.TagTypeAccess, []string{"project:storage"}, logger))
+	assert.Equal(t, ErrorCodeTagInvalid, errorCode(tags.AttachTags(share.CRN, models.TagTypeAccess, []string{"storage"}, logger)))
+
+	list, err := tags.ListTags(share.CRN, models.TagTypeUser, 0, 0, logger)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Tag{{Name: "team-a"}, {Name: "env-dev"}}, list.Items)
+	share, err = api.FileShareService().GetFileShare(share.ID, logger)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "env-dev"}, share.UserTags)
+
+	list, err = tags.ListTags(share.CRN, models.TagTypeUser, 1, 1, logger)
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.TotalCount)
+	assert.Equal(t, []models.Tag{{Name: "env-dev"}}, list.Items)
+
+	require.NoError(t, tags.DetachTags(share.CRN, models.TagTypeUser, []string{"team-a"}, logger))
+	list, err = tags.ListTags(share.CRN, models.TagTypeUser, 0, 0, logger)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Tag{{Name: "env-dev"}}, list.Items)
+
+	list, err = tags.ListTags(share.CRN, models.TagTypeAccess, 0, 0, logger)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Tag{{Name: "project:storage"}}, list.Items)
+
+	err = tags.AttachTags("crn:v1:bluemix:public:is:us-south-1:a/fakeaccount::share:unknown", models.TagTypeUser, []string{"team-a"}, logger)
+	assert.Equal(t, string(models.ErrorCodeTagsNotUpdated), errorCode(err))
+}
@@ -0,0 +1,227 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakevpc ...
+package fakevpc
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+)
+
+// target is a mount target of a share
+type target struct {
+	lifecycle
+	target models.ShareTarget
+}
+
+// targetView returns the mount target as the API shows it, the caller must hold the lock and settle the target
+func (s *Server) targetView(t *target) *models.ShareTarget {
+	view := t.target
+	view.Status = t.state
+	view.LifecycleReasons = t.reasons()
+	return &view
+}
+
+// settleTarget returns the mount target with its state as of now, or nil once it is gone. The caller must hold the lock
+func (s *Server) settleTarget(sh *share, id string) *target {
+	t, ok := sh.targets[id]
+	if !ok {
+		return nil
+	}
+	if t.current(s.now()) {
+		return t
+	}
+	delete(sh.targets, id)
+	sh.targetOrder = remove(sh.targetOrder, id)
+	return nil
+}
+
+// targetIDs returns the IDs of the mount targets of sh which still exist, in creation order. The caller must hold the lock
+func (s *Server) targetIDs(sh *share) []string {
+	ids := []string{}
+	for _, id := range append([]string(nil), sh.targetOrder...) {
+		if s.settleTarget(sh, id) != nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// newTarget validates template and builds a pending mount target of sh from it for the call r. The caller must hold the lock
+func (s *Server) newTarget(r *http.Request, sh *share, template *models.ShareTarget) (*target, *apiError) {
+	id := s.newID()
+	if template.Name == "" {
+		template.Name = "mount-target-" + id
+	}
+
+	var vpc *provider.VPC
+	switch {
+	case template.VirtualNetworkInterface != nil && template.VirtualNetworkInterface.Subnet != nil:
+		subnet := s.subnet(template.VirtualNetworkInterface.Subnet.ID)
+		if subnet == nil {
+			return nil, &apiError{http.StatusNotFound, ErrorCodeSubnetNotFound, "Subnet " + template.VirtualNetworkInterface.Subnet.ID + " not found"}
+		}
+		vpc = subnet.VPC
+	case template.VPC != nil && template.VPC.ID != "":
+		vpc = &provider.VPC{ID: template.VPC.ID}
+	default:
+		return nil, &apiError{http.StatusBadRequest, ErrorCodeBadField, "Either vpc or virtual_network_interface is required"}
+	}
+
+	if sh.share.OriginShare != nil && template.VirtualNetworkInterface == nil {
+		return nil, &apiError{http.StatusBadRequest, ErrorCodeBadField, "The mount targets of an accessor share require a virtual_network_interface"}
+	}
+	if modes := sh.share.AllowedTransitEncryptionModes; len(modes) > 0 {
+		transitEncryption := template.TransitEncryption
+		if transitEncryption == "" {
+			transitEncryption = TransitEncryptionNone
+		}
+		if !slices.Contains(modes, transitEncryption) {
+			return nil, &apiError{http.StatusBadRequest, ErrorCodeBadField, "The transit encryption " + transitEncryption + " is not allowed by the share"}
+		}
+	}
+
+	for _, other := range sh.targets {
+		if other.target.Name == template.Name {
+			return nil, &apiError{http.StatusBadRequest, ErrorCodeNameDuplicate, "A mount target with the name " + template.Name + " already exists"}
+		}
+		if vpc != nil && other.target.VPC != nil && other.target.VPC.ID == vpc.ID {
+			return nil, &apiError{http.StatusBadRequest, ErrorCodeTargetOnePerVPC, "The share already has a mount target in VPC " + vpc.ID}
+		}
+	}
+
+	now := s.now()
+	t := &target{
+		lifecycle: s.transition(r, StatePending),
+		target:    *template,
+	}
+	t.target.ID = id
+	t.target.ShareID = sh.share.ID
+	t.target.Href = sh.share.Href + "/mount_targets/" + id
+	t.target.MountPath = "fake-nfs." + sh.share.Zone.Name + ".test:/" + strings.ReplaceAll(id, "-", "_")
+	t.target.VPC = vpc
+	t.target.Zone = sh.share.Zone
+	t.target.CreatedAt = &now
+	return t, nil
+}
+
+// lookupTarget returns the mount target named by the path of r, writing not found if there is none. The caller must hold the lock
+func (s *Server) lookupTarget(w http.ResponseWriter, r *http.Request) (*share, *target, bool) {
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	t := s.settleTarget(sh, r.PathValue("target"))
+	if t == nil {
+		s.writeErrorLocked(w, http.StatusNotFound, ErrorCodeTargetNotFound, "Mount target not found")
+		return nil, nil, false
+	}
+	return sh, t, true
+}
+
+// listTargets serves GET /v1/shares/{share}/mount_targets
+func (s *Server) listTargets(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return
+	}
+	name := r.URL.Query().Get("name")
+	ids := []string{}
+	for _, id := range s.targetIDs(sh) {
+		if name == "" || sh.targets[id].target.Name == name {
+			ids = append(ids, id)
+		}
+	}
+
+	pageIDs, limit, first, next, ok := s.page(w, r, ids)
+	if !ok {
+		return
+	}
+	list := models.ShareTargetList{First: first, Next: next, Limit: limit, TotalCount: len(ids), ShareTargets: []*models.ShareTarget{}}
+	for _, id := range pageIDs {
+		list.ShareTargets = append(list.ShareTargets, s.targetView(sh.targets[id]))
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+// createTarget serves POST /v1/shares/{share}/mount_targets
+func (s *Server) createTarget(w http.ResponseWriter, r *http.Request) {
+	var template models.ShareTarget
+	if !s.decode(w, r, &template) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return
+	}
+	if sh.state != StateStable {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeStatusPending, "The share is "+sh.state)
+		return
+	}
+	t, apiErr := s.newTarget(r, sh, &template)
+	if apiErr != nil {
+		s.writeErrorLocked(w, apiErr.status, apiErr.code, apiErr.message)
+		return
+	}
+	sh.targets[t.target.ID] = t
+	sh.targetOrder = append(sh.targetOrder, t.target.ID)
+	sh.version++
+
+	s.writeJSON(w, http.StatusCreated, s.targetView(t))
+}
+
+// getTarget serves GET /v1/shares/{share}/mount_targets/{target}
+func (s *Server) getTarget(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, t, ok := s.lookupTarget(w, r)
+	if !ok {
+		return
+	}
+	s.writeJSON(w, http.StatusOK, s.targetView(t))
+}
+
+// deleteTarget serves DELETE /v1/shares/{share}/mount_targets/{target}
+func (s *Server) deleteTarget(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, t, ok := s.lookupTarget(w, r)
+	if !ok {
+		return
+	}
+	if !t.deletable() {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeStatusPending, "The mount target is "+t.state)
+		return
+	}
+	t.lifecycle = s.transition(r, StateDeleting)
+	sh.version++
+
+	s.writeJSON(w, http.StatusAccepted, s.targetView(t))
+}
@@ -0,0 +1,409 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakevpc ...
+package fakevpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// share is a file share along with its mount targets and snapshots
+type share struct {
+	lifecycle
+	share   models.Share
+	version int
+
+	targets       map[string]*target
+	targetOrder   []string
+	snapshots     map[string]*snapshot
+	snapshotOrder []string
+
+	replication replication
+	accessTags  []string
+
+	bindings     map[string]*binding
+	bindingOrder []string
+}
+
+// etag identifies the current version of the share
+func (sh *share) etag() string {
+	return fmt.Sprintf(`W/"%s-%d"`, sh.share.ID, sh.version)
+}
+
+// view returns the share as the API shows it, the caller must hold the lock and settle the share
+func (s *Server) shareView(sh *share) *models.Share {
+	view := sh.share
+	view.Status = models.StatusType(sh.state)
+	view.LifecycleReasons = sh.reasons()
+	view.UserTags = append([]string(nil), sh.share.UserTags...)
+	view.AccessorBindingRole = s.accessorBindingRole(sh)
+
+	targets := []models.ShareTarget{}
+	for _, id := range s.targetIDs(sh) {
+		targets = append(targets, *s.targetView(sh.targets[id]))
+	}
+	view.ShareTargets = &targets
+	return &view
+}
+
+// settleShare returns the share with its state as of now, or nil once it is gone. The caller must hold the lock
+func (s *Server) settleShare(id string) *share {
+	sh, ok := s.shares[id]
+	if !ok {
+		return nil
+	}
+	if sh.current(s.now()) {
+		sh.settleReplication(s.now())
+		return sh
+	}
+	delete(s.shares, id)
+	s.shareOrder = remove(s.shareOrder, id)
+	return nil
+}
+
+// shareIDs returns the IDs of the shares which still exist, in creation order. The caller must hold the lock
+func (s *Server) shareIDs() []string {
+	ids := []string{}
+	for _, id := range append([]string(nil), s.shareOrder...) {
+		if s.settleShare(id) != nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// shareByName returns the share named name, the caller must hold the lock
+func (s *Server) shareByName(name string) *share {
+	for _, id := range s.shareIDs() {
+		if s.shares[id].share.Name == name {
+			return s.shares[id]
+		}
+	}
+	return nil
+}
+
+// lookupShare returns the share named by the path of r, writing not found if there is none. The caller must hold the lock
+func (s *Server) lookupShare(w http.ResponseWriter, r *http.Request) (*share, bool) {
+	sh := s.settleShare(r.PathValue("share"))
+	if sh == nil {
+		s.writeErrorLocked(w, http.StatusNotFound, ErrorCodeShareNotFound, "Share not found")
+		return nil, false
+	}
+	return sh, true
+}
+
+// listShares serves GET /v1/shares
+func (s *Server) listShares(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := r.URL.Query().Get("name")
+	resourceGroupID := r.URL.Query().Get("resource_group.id")
+	ids := []string{}
+	for _, id := range s.shareIDs() {
+		sh := s.shares[id]
+		if name != "" && sh.share.Name != name {
+			continue
+		}
+		if resourceGroupID != "" && (sh.share.ResourceGroup == nil || sh.share.ResourceGroup.ID != resourceGroupID) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	pageIDs, limit, first, next, ok := s.page(w, r, ids)
+	if !ok {
+		return
+	}
+	list := models.ShareList{First: first, Next: next, Limit: limit, TotalCount: len(ids), Shares: []*models.Share{}}
+	for _, id := range pageIDs {
+		list.Shares = append(list.Shares, s.shareView(s.shares[id]))
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+// createShare serves POST /v1/shares
+func (s *Server) createShare(w http.ResponseWriter, r *http.Request) {
+	var template models.Share
+	if !s.decode(w, r, &template) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	if template.Name == "" {
+		template.Name = "share-" + id
+	}
+	if s.shareByName(template.Name) != nil {
+		s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeNameDuplicate, "A share with the name "+template.Name+" already exists")
+		return
+	}
+
+	if template.OriginShare != nil {
+		s.createAccessorShare(w, r, id, template)
+		return
+	}
+
+	zone := DefaultZone
+	if template.Zone != nil && template.Zone.Name != "" {
+		zone = template.Zone.Name
+	}
+	if template.SourceSnapshot != nil {
+		source, sourceShare := s.snapshotByRef(template.SourceSnapshot)
+		if source == nil {
+			s.writeErrorLocked(w, http.StatusNotFound, ErrorCodeSnapshotNotFound, "Source snapshot not found")
+			return
+		}
+		if template.Size == 0 {
+			template.Size = sourceShare.share.Size
+		}
+		if template.Profile == nil {
+			template.Profile = sourceShare.share.Profile
+		}
+		zone = sourceShare.share.Zone.Name
+		template.SourceSnapshot = &models.Snapshot{ID: source.snapshot.ID, CRN: source.snapshot.CRN, Href: source.snapshot.Href, Name: source.snapshot.Name}
+	}
+	var replicated *share
+	if template.SourceShare != nil {
+		var apiErr *apiError
+		if replicated, apiErr = s.replicationSource(&template); apiErr != nil {
+			s.writeErrorLocked(w, apiErr.status, apiErr.code, apiErr.message)
+			return
+		}
+	}
+
+	if template.Profile == nil {
+		s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeBadField, "profile is required")
+		return
+	}
+	profile := s.profile(template.Profile.Name)
+	if profile == nil {
+		s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeProfileNotFound, "Share profile "+template.Profile.Name+" not found")
+		return
+	}
+	if !inRange(profile.Capacity, int64(template.Size)) {
+		s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeCapacityInvalid, fmt.Sprintf("Size %d is not allowed by profile %s", template.Size, profile.Name))
+		return
+	}
+	if template.Iops == 0 {
+		template.Iops = int64(profile.Iops.Default)
+	}
+	if template.ResourceGroup == nil || template.ResourceGroup.ID == "" {
+		template.ResourceGroup = &models.ResourceGroup{ID: "default"}
+	}
+
+	now := s.now()
+	sh := s.newShare(r)
+	sh.share = template
+	sh.share.ID = id
+	sh.share.CRN = s.crn(zone, "share", id)
+	sh.share.Href = s.server.URL + "/v1/shares/" + id
+	sh.share.CreatedAt = &now
+	sh.share.Profile = &models.Profile{Name: profile.Name, Href: profile.Href}
+	sh.share.Zone = &models.Zone{Name: zone, Href: s.server.URL + "/v1/regions/us-south/zones/" + zone}
+	sh.share.ShareTargets = nil
+	sh.share.ReplicationRole = models.ReplicationRoleNone
+	sh.share.ReplicationStatus = models.ReplicationStatusNone
+	sh.share.SourceShare = nil
+	sh.share.ReplicaShare = nil
+	sh.share.OriginShare = nil
+
+	if template.ShareTargets != nil {
+		for i := range *template.ShareTargets {
+			t, apiErr := s.newTarget(r, sh, &(*template.ShareTargets)[i])
+			if apiErr != nil {
+				s.writeErrorLocked(w, apiErr.status, apiErr.code, apiErr.message)
+				return
+			}
+			sh.targets[t.target.ID] = t
+			sh.targetOrder = append(sh.targetOrder, t.target.ID)
+		}
+	}
+
+	s.shares[id] = sh
+	s.shareOrder = append(s.shareOrder, id)
+	if replicated != nil {
+		s.replicate(r, replicated, sh)
+	}
+
+	w.Header().Set("ETag", sh.etag())
+	s.writeJSON(w, http.StatusCreated, s.shareView(sh))
+}
+
+// newShare returns a pending share with no mount targets, snapshots or accessor bindings yet for the call r.
+// The caller must hold the lock
+func (s *Server) newShare(r *http.Request) *share {
+	return &share{
+		lifecycle: s.transition(r, StatePending),
+		version:   1,
+		targets:   map[string]*target{},
+		snapshots: map[string]*snapshot{},
+		bindings:  map[string]*binding{},
+	}
+}
+
+// getShare serves GET /v1/shares/{share}
+func (s *Server) getShare(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("ETag", sh.etag())
+	s.writeJSON(w, http.StatusOK, s.shareView(sh))
+}
+
+// updateShare serves PATCH /v1/shares/{share}, honoring If-Match
+func (s *Server) updateShare(w http.ResponseWriter, r *http.Request) {
+	var fields map[string]json.RawMessage
+	if !s.decode(w, r, &fields) {
+		return
+	}
+	var template models.Share
+	body, _ := json.Marshal(fields)
+	if err := json.Unmarshal(body, &template); err != nil {
+		s.writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return
+	}
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != sh.etag() {
+		s.writeErrorLocked(w, http.StatusPreconditionFailed, ErrorCodePreconditionFailed, "The If-Match header does not match the current ETag of the share")
+		return
+	}
+	if sh.state != StateStable {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeStatusPending, "The share is "+sh.state)
+		return
+	}
+
+	profile := s.profile(sh.share.Profile.Name)
+	if template.Profile != nil && template.Profile.Name != "" {
+		if profile = s.profile(template.Profile.Name); profile == nil {
+			s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeProfileNotFound, "Share profile "+template.Profile.Name+" not found")
+			return
+		}
+	}
+	size := sh.share.Size
+	if template.Size != 0 {
+		if template.Size < sh.share.Size {
+			s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeCapacityInvalid, "The size of a share cannot be reduced")
+			return
+		}
+		size = template.Size
+	}
+	if !inRange(profile.Capacity, size) {
+		s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeCapacityInvalid, fmt.Sprintf("Size %d is not allowed by profile %s", size, profile.Name))
+		return
+	}
+	if template.Name != "" && template.Name != sh.share.Name {
+		if s.shareByName(template.Name) != nil {
+			s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeNameDuplicate, "A share with the name "+template.Name+" already exists")
+			return
+		}
+		sh.share.Name = template.Name
+	}
+
+	resized := size != sh.share.Size || profile.Name != sh.share.Profile.Name ||
+		(template.Iops != 0 && template.Iops != sh.share.Iops) ||
+		(template.Bandwidth != 0 && template.Bandwidth != sh.share.Bandwidth)
+	sh.share.Size = size
+	sh.share.Profile = &models.Profile{Name: profile.Name, Href: profile.Href}
+	if template.Iops != 0 {
+		sh.share.Iops = template.Iops
+	}
+	if template.Bandwidth != 0 {
+		sh.share.Bandwidth = template.Bandwidth
+	}
+	if _, ok := fields["user_tags"]; ok {
+		sh.share.UserTags = template.UserTags
+	}
+	if resized {
+		sh.lifecycle = s.transition(r, StateUpdating)
+	}
+	sh.version++
+
+	w.Header().Set("ETag", sh.etag())
+	s.writeJSON(w, http.StatusOK, s.shareView(sh))
+}
+
+// deleteShare serves DELETE /v1/shares/{share}
+func (s *Server) deleteShare(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return
+	}
+	if !sh.deletable() {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeStatusPending, "The share is "+sh.state)
+		return
+	}
+	if len(s.targetIDs(sh)) > 0 {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeTargetsExist, "The share still has mount targets")
+		return
+	}
+	if sh.share.ReplicationRole != models.ReplicationRoleNone {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeReplicationExists, "The share has a replication relationship, split it first")
+		return
+	}
+	if len(s.bindingIDs(sh)) > 0 {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeBindingsExist, "The share still has accessor bindings")
+		return
+	}
+
+	sh.lifecycle = s.transition(r, StateDeleting)
+	s.unbindAccessor(r, sh)
+	sh.version++
+	s.writeJSON(w, http.StatusAccepted, s.shareView(sh))
+}
+
+// inRange tells if value is allowed by a capacity or IOPS range of a profile
+func inRange(allowed models.CapIops, value int64) bool {
+	if allowed.Min > 0 && value < int64(allowed.Min) {
+		return false
+	}
+	if allowed.Max > 0 && value > int64(allowed.Max) {
+		return false
+	}
+	return true
+}
+
+// remove returns ids without id
+func remove(ids []string, id string) []string {
+	kept := ids[:0]
+	for _, other := range ids {
+		if other != id {
+			kept = append(kept, other)
+		}
+	}
+	return kept
+}
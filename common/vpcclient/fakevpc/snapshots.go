@@ -0,0 +1,258 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fakevpc ...
+package fakevpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// snapshot is a snapshot of a share
+type snapshot struct {
+	lifecycle
+	snapshot   models.Snapshot
+	accessTags []string
+	version    int
+}
+
+// etag identifies the current version of the snapshot
+func (sn *snapshot) etag() string {
+	return fmt.Sprintf(`W/"%s-%d"`, sn.snapshot.ID, sn.version)
+}
+
+// snapshotView returns the snapshot as the API shows it, the caller must hold the lock and settle the snapshot
+func (s *Server) snapshotView(sn *snapshot) *models.Snapshot {
+	view := sn.snapshot
+	view.LifecycleState = sn.state
+	view.LifecycleReasons = sn.reasons()
+	view.UserTags = append([]string(nil), sn.snapshot.UserTags...)
+	return &view
+}
+
+// settleSnapshot returns the snapshot with its state as of now, or nil once it is gone. The caller must hold the lock
+func (s *Server) settleSnapshot(sh *share, id string) *snapshot {
+	sn, ok := sh.snapshots[id]
+	if !ok {
+		return nil
+	}
+	if sn.current(s.now()) {
+		return sn
+	}
+	delete(sh.snapshots, id)
+	sh.snapshotOrder = remove(sh.snapshotOrder, id)
+	return nil
+}
+
+// snapshotIDs returns the IDs of the snapshots of sh which still exist, in creation order. The caller must hold the lock
+func (s *Server) snapshotIDs(sh *share) []string {
+	ids := []string{}
+	for _, id := range append([]string(nil), sh.snapshotOrder...) {
+		if s.settleSnapshot(sh, id) != nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// snapshotByRef finds the stable snapshot referenced by ID or CRN, along with its share. The caller must hold the lock
+func (s *Server) snapshotByRef(ref *models.Snapshot) (*snapshot, *share) {
+	for _, shareID := range s.shareIDs() {
+		sh := s.shares[shareID]
+		for _, id := range s.snapshotIDs(sh) {
+			sn := sh.snapshots[id]
+			if sn.state == StateStable && ((ref.ID != "" && ref.ID == id) || (ref.CRN != "" && ref.CRN == sn.snapshot.CRN)) {
+				return sn, sh
+			}
+		}
+	}
+	return nil, nil
+}
+
+// lookupSnapshot returns the snapshot named by the path of r, writing not found if there is none. The caller must hold the lock
+func (s *Server) lookupSnapshot(w http.ResponseWriter, r *http.Request) (*share, *snapshot, bool) {
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	sn := s.settleSnapshot(sh, r.PathValue("snapshot"))
+	if sn == nil {
+		s.writeErrorLocked(w, http.StatusNotFound, ErrorCodeSnapshotNotFound, "Snapshot not found")
+		return nil, nil, false
+	}
+	return sh, sn, true
+}
+
+// listSnapshots serves GET /v1/shares/{share}/snapshots
+func (s *Server) listSnapshots(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return
+	}
+	name := r.URL.Query().Get("name")
+	ids := []string{}
+	for _, id := range s.snapshotIDs(sh) {
+		if name == "" || sh.snapshots[id].snapshot.Name == name {
+			ids = append(ids, id)
+		}
+	}
+
+	pageIDs, limit, first, next, ok := s.page(w, r, ids)
+	if !ok {
+		return
+	}
+	list := models.SnapshotList{First: first, Next: next, Limit: limit, TotalCount: len(ids), Snapshots: []*models.Snapshot{}}
+	for _, id := range pageIDs {
+		list.Snapshots = append(list.Snapshots, s.snapshotView(sh.snapshots[id]))
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+// createSnapshot serves POST /v1/shares/{share}/snapshots
+func (s *Server) createSnapshot(w http.ResponseWriter, r *http.Request) {
+	var template models.Snapshot
+	if !s.decode(w, r, &template) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, ok := s.lookupShare(w, r)
+	if !ok {
+		return
+	}
+	if sh.state != StateStable {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeStatusPending, "The share is "+sh.state)
+		return
+	}
+
+	id := s.newID()
+	if template.Name == "" {
+		template.Name = "snapshot-" + id
+	}
+	for _, other := range s.snapshotIDs(sh) {
+		if sh.snapshots[other].snapshot.Name == template.Name {
+			s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeSnapshotDuplicate, "A snapshot with the name "+template.Name+" already exists")
+			return
+		}
+	}
+
+	now := s.now()
+	sn := &snapshot{
+		lifecycle: s.transition(r, StatePending),
+		snapshot:  template,
+		version:   1,
+	}
+	sn.snapshot.ID = id
+	sn.snapshot.CRN = s.crn(sh.share.Zone.Name, "share-snapshot", sh.share.ID+"/"+id)
+	sn.snapshot.Href = sh.share.Href + "/snapshots/" + id
+	sn.snapshot.CreatedAt = &now
+	sn.snapshot.CapturedAt = &now
+	sn.snapshot.MinimumSize = sh.share.Size
+	sn.snapshot.ResourceGroup = sh.share.ResourceGroup
+	sn.snapshot.ResourceType = "share_snapshot"
+	sn.snapshot.Zone = sh.share.Zone
+	sh.snapshots[id] = sn
+	sh.snapshotOrder = append(sh.snapshotOrder, id)
+
+	w.Header().Set("ETag", sn.etag())
+	s.writeJSON(w, http.StatusCreated, s.snapshotView(sn))
+}
+
+// getSnapshot serves GET /v1/shares/{share}/snapshots/{snapshot}
+func (s *Server) getSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, sn, ok := s.lookupSnapshot(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("ETag", sn.etag())
+	s.writeJSON(w, http.StatusOK, s.snapshotView(sn))
+}
+
+// updateSnapshot serves PATCH /v1/shares/{share}/snapshots/{snapshot}, it renames the snapshot and replaces its user tags
+func (s *Server) updateSnapshot(w http.ResponseWriter, r *http.Request) {
+	var fields map[string]json.RawMessage
+	if !s.decode(w, r, &fields) {
+		return
+	}
+	var template models.Snapshot
+	body, _ := json.Marshal(fields)
+	if err := json.Unmarshal(body, &template); err != nil {
+		s.writeError(w, http.StatusBadRequest, ErrorCodeBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sh, sn, ok := s.lookupSnapshot(w, r)
+	if !ok {
+		return
+	}
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != sn.etag() {
+		s.writeErrorLocked(w, http.StatusPreconditionFailed, ErrorCodePreconditionFailed, "The If-Match header does not match the current ETag of the snapshot")
+		return
+	}
+	if sn.state != StateStable {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeStatusPending, "The snapshot is "+sn.state)
+		return
+	}
+	if template.Name != "" && template.Name != sn.snapshot.Name {
+		for _, other := range s.snapshotIDs(sh) {
+			if sh.snapshots[other].snapshot.Name == template.Name {
+				s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeSnapshotDuplicate, "A snapshot with the name "+template.Name+" already exists")
+				return
+			}
+		}
+		sn.snapshot.Name = template.Name
+	}
+	if _, ok := fields["user_tags"]; ok {
+		sn.snapshot.UserTags = template.UserTags
+	}
+	sn.version++
+
+	w.Header().Set("ETag", sn.etag())
+	s.writeJSON(w, http.StatusOK, s.snapshotView(sn))
+}
+
+// deleteSnapshot serves DELETE /v1/shares/{share}/snapshots/{snapshot}
+func (s *Server) deleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, sn, ok := s.lookupSnapshot(w, r)
+	if !ok {
+		return
+	}
+	if !sn.deletable() {
+		s.writeErrorLocked(w, http.StatusConflict, ErrorCodeStatusPending, "The snapshot is "+sn.state)
+		return
+	}
+	sn.lifecycle = s.transition(r, StateDeleting)
+
+	s.writeJSON(w, http.StatusAccepted, s.snapshotView(sn))
+}
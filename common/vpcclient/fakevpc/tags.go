@@ -0,0 +1,181 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package fakevpc
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// Page sizes of the Global Tagging API
+const (
+	defaultTagPageLimit = 100
+	maxTagPageLimit     = 1000
+)
+
+// resourceTags returns the tags of tagType of the share or snapshot whose CRN is crn, nil when there is none.
+// The user tags are the ones the share and snapshot payloads show. The caller must hold the lock
+func (s *Server) resourceTags(crn string, tagType string) *[]string {
+	for _, id := range s.shareIDs() {
+		sh := s.shares[id]
+		if sh.share.CRN == crn {
+			if tagType == models.TagTypeAccess {
+				return &sh.accessTags
+			}
+			return &sh.share.UserTags
+		}
+		for _, snapshotID := range s.snapshotIDs(sh) {
+			sn := sh.snapshots[snapshotID]
+			if sn.snapshot.CRN == crn {
+				if tagType == models.TagTypeAccess {
+					return &sn.accessTags
+				}
+				return &sn.snapshot.UserTags
+			}
+		}
+	}
+	return nil
+}
+
+// tagType returns the tag_type query value of r, user by default, writing a bad request if it is unknown.
+// The caller must hold the lock
+func (s *Server) tagType(w http.ResponseWriter, r *http.Request) (string, bool) {
+	tagType := r.URL.Query().Get("tag_type")
+	switch tagType {
+	case "":
+		return models.TagTypeUser, true
+	case models.TagTypeUser, models.TagTypeAccess:
+		return tagType, true
+	}
+	s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeBadField, "tag_type must be user or access")
+	return "", false
+}
+
+// validTagNames checks the tags of request, writing a bad request if one is not valid for tagType.
+// Tags are lower cased, as the Global Tagging API does. The caller must hold the lock
+func (s *Server) validTagNames(w http.ResponseWriter, request *models.TagRequest, tagType string) bool {
+	for i, name := range request.TagNames {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || len(name) > 128 || (tagType == models.TagTypeAccess && !strings.Contains(name, ":")) {
+			s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeTagInvalid, "Tag "+strconv.Quote(name)+" is not a valid "+tagType+" tag")
+			return false
+		}
+		request.TagNames[i] = name
+	}
+	return true
+}
+
+// updateTags applies update to the tags of each resource of request and writes the results
+func (s *Server) updateTags(w http.ResponseWriter, r *http.Request, update func(tags []string, names []string) []string) {
+	var request models.TagRequest
+	if !s.decode(w, r, &request) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tagType, ok := s.tagType(w, r)
+	if !ok || !s.validTagNames(w, &request, tagType) {
+		return
+	}
+
+	results := models.TagResults{Results: []models.TagResult{}}
+	for _, resource := range request.Resources {
+		tags := s.resourceTags(resource.ResourceID, tagType)
+		if tags != nil {
+			*tags = update(*tags, request.TagNames)
+		}
+		results.Results = append(results.Results, models.TagResult{ResourceID: resource.ResourceID, IsError: tags == nil})
+	}
+	s.writeJSON(w, http.StatusOK, results)
+}
+
+// attachTags serves POST /v3/tags/attach
+func (s *Server) attachTags(w http.ResponseWriter, r *http.Request) {
+	s.updateTags(w, r, func(tags []string, names []string) []string {
+		for _, name := range names {
+			if !containsFold(tags, name) {
+				tags = append(tags, name)
+			}
+		}
+		return tags
+	})
+}
+
+// detachTags serves POST /v3/tags/detach
+func (s *Server) detachTags(w http.ResponseWriter, r *http.Request) {
+	s.updateTags(w, r, func(tags []string, names []string) []string {
+		kept := []string{}
+		for _, tag := range tags {
+			if !containsFold(names, tag) {
+				kept = append(kept, tag)
+			}
+		}
+		return kept
+	})
+}
+
+// listTags serves GET /v3/tags, the tags attached_to a resource are listed in the order they were attached
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tagType, ok := s.tagType(w, r)
+	if !ok {
+		return
+	}
+	offset, limit := 0, defaultTagPageLimit
+	if value := r.URL.Query().Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeBadField, "offset must not be negative")
+			return
+		}
+		offset = parsed
+	}
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxTagPageLimit {
+			s.writeErrorLocked(w, http.StatusBadRequest, ErrorCodeBadField, "limit must be between 1 and "+strconv.Itoa(maxTagPageLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	names := []string{}
+	if tags := s.resourceTags(r.URL.Query().Get("attached_to"), tagType); tags != nil {
+		names = *tags
+	}
+	list := models.TagList{TotalCount: len(names), Offset: offset, Limit: limit, Items: []models.Tag{}}
+	for i := offset; i < len(names) && i < offset+limit; i++ {
+		list.Items = append(list.Items, models.Tag{Name: names[i]})
+	}
+	s.writeJSON(w, http.StatusOK, list)
+}
+
+// containsFold tells if tags holds name, ignoring case
+func containsFold(tags []string, name string) bool {
+	for _, tag := range tags {
+		if strings.EqualFold(tag, name) {
+			return true
+		}
+	}
+	return false
+}
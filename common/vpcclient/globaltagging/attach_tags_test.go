@@ -0,0 +1,113 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package globaltagging_test
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/globaltagging"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas/test"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const shareCRN = "crn:v1:bluemix:public:is:us-south-1:a/account::share:share1"
+
+// GetTestContextLogger ...
+func GetTestContextLogger() (*zap.Logger, zap.AtomicLevel) {
+	consoleDebugging := zapcore.Lock(os.Stdout)
+	consoleErrors := zapcore.Lock(os.Stderr)
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = "ts"
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	traceLevel := zap.NewAtomicLevel()
+	traceLevel.SetLevel(zap.InfoLevel)
+	core := zapcore.NewTee(
+		zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), consoleDebugging, zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+			return (lvl >= traceLevel.Level()) && (lvl < zapcore.ErrorLevel)
+		})),
+		zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), consoleErrors, zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+			return lvl >= zapcore.ErrorLevel
+		})),
+	)
+	logger := zap.New(core, zap.AddCaller())
+	return logger, traceLevel
+}
+
+func TestAttachTags(t *testing.T) {
+	// Setup new style zap logger
+	logger, _ := GetTestContextLogger()
+	defer logger.Sync()
+
+	testCases := []struct {
+		name string
+
+		// Response
+		status  int
+		content string
+
+		// Expected return
+		expectErr string
+		muxVerify func(*testing.T, *http.Request)
+	}{
+		{
+			name:    "Verify that the correct endpoint is invoked",
+			status:  http.StatusOK,
+			content: "{\"results\":[{\"resource_id\":\"" + shareCRN + "\",\"is_error\":false}]}",
+			muxVerify: func(t *testing.T, r *http.Request) {
+				expectedValues := url.Values{"tag_type": []string{models.TagTypeAccess}, "version": []string{models.APIVersion}}
+				assert.Equal(t, expectedValues, r.URL.Query())
+			},
+		}, {
+			name:      "Verify that a resource reported in error is returned to the caller",
+			status:    http.StatusOK,
+			content:   "{\"results\":[{\"resource_id\":\"" + shareCRN + "\",\"is_error\":true}]}",
+			expectErr: "Trace Code:, Code:tags_not_updated, Description:AttachTags failed for resource " + shareCRN + ", RC:",
+		}, {
+			name:      "Verify that a 400 is returned to the caller",
+			status:    http.StatusBadRequest,
+			content:   "{\"errors\":[{\"message\":\"testerr\",\"code\":\"invalid_tag\"}], \"trace\":\"2af63776-4df7-4970-b52d-4e25676ec0e4\"}",
+			expectErr: "Trace Code:2af63776-4df7-4970-b52d-4e25676ec0e4, Code:invalid_tag, Description:testerr, RC:400 Bad Request",
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			expectedContent := "{\"resources\":[{\"resource_id\":\"" + shareCRN + "\"}],\"tag_names\":[\"env:prod\"]}\n"
+			mux, client, teardown := test.SetupServer(t)
+			test.SetupMuxResponse(t, mux, globaltagging.Version+"/tags/attach", http.MethodPost, &expectedContent, testcase.status, testcase.content, testcase.muxVerify)
+
+			defer teardown()
+
+			logger.Info("Test case being executed", zap.Reflect("testcase", testcase.name))
+
+			tagService := globaltagging.New(client)
+
+			err := tagService.AttachTags(shareCRN, models.TagTypeAccess, []string{"env:prod"}, logger)
+
+			if testcase.expectErr != "" && assert.Error(t, err) {
+				assert.Equal(t, testcase.expectErr, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package globaltagging ...
+package globaltagging
+
+import (
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"go.uber.org/zap"
+)
+
+// DetachTags POSTs /tags/detach
+func (ts *TagService) DetachTags(resourceCRN string, tagType string, tagNames []string, ctxLogger *zap.Logger) error {
+	ctxLogger.Debug("Entry Backend DetachTags")
+	defer ctxLogger.Debug("Exit Backend DetachTags")
+
+	defer util.TimeTracker("DetachTags", time.Now())
+
+	operation := &client.Operation{
+		Name:        "DetachTags",
+		Method:      "POST",
+		PathPattern: detachPath,
+	}
+
+	tagRequest := models.TagRequest{
+		Resources: []models.TagResource{{ResourceID: resourceCRN}},
+		TagNames:  tagNames,
+	}
+
+	var results models.TagResults
+	var apiErr models.Error
+
+	// The request is passed by pointer, so that no resource group is added to the payload
+	request := ts.client.NewRequest(operation)
+	ctxLogger.Info("Equivalent curl command and payload details", zap.Reflect("URL", request.URL()), zap.Reflect("Payload", tagRequest), zap.Reflect("Operation", operation))
+
+	_, err := request.AddQueryValue("tag_type", tagType).JSONBody(&tagRequest).JSONSuccess(&results).JSONError(&apiErr).Invoke()
+	if err != nil {
+		return err
+	}
+
+	return checkResults("DetachTags", &results)
+}
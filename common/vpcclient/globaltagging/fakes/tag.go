@@ -0,0 +1,296 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/globaltagging"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"go.uber.org/zap"
+)
+
+type TagService struct {
+	AttachTagsStub        func(string, string, []string, *zap.Logger) error
+	attachTagsMutex       sync.RWMutex
+	attachTagsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+		arg4 *zap.Logger
+	}
+	attachTagsReturns struct {
+		result1 error
+	}
+	attachTagsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DetachTagsStub        func(string, string, []string, *zap.Logger) error
+	detachTagsMutex       sync.RWMutex
+	detachTagsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+		arg4 *zap.Logger
+	}
+	detachTagsReturns struct {
+		result1 error
+	}
+	detachTagsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListTagsStub        func(string, string, int, int, *zap.Logger) (*models.TagList, error)
+	listTagsMutex       sync.RWMutex
+	listTagsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 int
+		arg5 *zap.Logger
+	}
+	listTagsReturns struct {
+		result1 *models.TagList
+		result2 error
+	}
+	listTagsReturnsOnCall map[int]struct {
+		result1 *models.TagList
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *TagService) AttachTags(arg1 string, arg2 string, arg3 []string, arg4 *zap.Logger) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.attachTagsMutex.Lock()
+	ret, specificReturn := fake.attachTagsReturnsOnCall[len(fake.attachTagsArgsForCall)]
+	fake.attachTagsArgsForCall = append(fake.attachTagsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+		arg4 *zap.Logger
+	}{arg1, arg2, arg3Copy, arg4})
+	stub := fake.AttachTagsStub
+	fakeReturns := fake.attachTagsReturns
+	fake.recordInvocation("AttachTags", []interface{}{arg1, arg2, arg3Copy, arg4})
+	fake.attachTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TagService) AttachTagsCallCount() int {
+	fake.attachTagsMutex.RLock()
+	defer fake.attachTagsMutex.RUnlock()
+	return len(fake.attachTagsArgsForCall)
+}
+
+func (fake *TagService) AttachTagsCalls(stub func(string, string, []string, *zap.Logger) error) {
+	fake.attachTagsMutex.Lock()
+	defer fake.attachTagsMutex.Unlock()
+	fake.AttachTagsStub = stub
+}
+
+func (fake *TagService) AttachTagsArgsForCall(i int) (string, string, []string, *zap.Logger) {
+	fake.attachTagsMutex.RLock()
+	defer fake.attachTagsMutex.RUnlock()
+	argsForCall := fake.attachTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *TagService) AttachTagsReturns(result1 error) {
+	fake.attachTagsMutex.Lock()
+	defer fake.attachTagsMutex.Unlock()
+	fake.AttachTagsStub = nil
+	fake.attachTagsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TagService) AttachTagsReturnsOnCall(i int, result1 error) {
+	fake.attachTagsMutex.Lock()
+	defer fake.attachTagsMutex.Unlock()
+	fake.AttachTagsStub = nil
+	if fake.attachTagsReturnsOnCall == nil {
+		fake.attachTagsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.attachTagsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TagService) DetachTags(arg1 string, arg2 string, arg3 []string, arg4 *zap.Logger) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.detachTagsMutex.Lock()
+	ret, specificReturn := fake.detachTagsReturnsOnCall[len(fake.detachTagsArgsForCall)]
+	fake.detachTagsArgsForCall = append(fake.detachTagsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+		arg4 *zap.Logger
+	}{arg1, arg2, arg3Copy, arg4})
+	stub := fake.DetachTagsStub
+	fakeReturns := fake.detachTagsReturns
+	fake.recordInvocation("DetachTags", []interface{}{arg1, arg2, arg3Copy, arg4})
+	fake.detachTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *TagService) DetachTagsCallCount() int {
+	fake.detachTagsMutex.RLock()
+	defer fake.detachTagsMutex.RUnlock()
+	return len(fake.detachTagsArgsForCall)
+}
+
+func (fake *TagService) DetachTagsCalls(stub func(string, string, []string, *zap.Logger) error) {
+	fake.detachTagsMutex.Lock()
+	defer fake.detachTagsMutex.Unlock()
+	fake.DetachTagsStub = stub
+}
+
+func (fake *TagService) DetachTagsArgsForCall(i int) (string, string, []string, *zap.Logger) {
+	fake.detachTagsMutex.RLock()
+	defer fake.detachTagsMutex.RUnlock()
+	argsForCall := fake.detachTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *TagService) DetachTagsReturns(result1 error) {
+	fake.detachTagsMutex.Lock()
+	defer fake.detachTagsMutex.Unlock()
+	fake.DetachTagsStub = nil
+	fake.detachTagsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TagService) DetachTagsReturnsOnCall(i int, result1 error) {
+	fake.detachTagsMutex.Lock()
+	defer fake.detachTagsMutex.Unlock()
+	fake.DetachTagsStub = nil
+	if fake.detachTagsReturnsOnCall == nil {
+		fake.detachTagsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.detachTagsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TagService) ListTags(arg1 string, arg2 string, arg3 int, arg4 int, arg5 *zap.Logger) (*models.TagList, error) {
+	fake.listTagsMutex.Lock()
+	ret, specificReturn := fake.listTagsReturnsOnCall[len(fake.listTagsArgsForCall)]
+	fake.listTagsArgsForCall = append(fake.listTagsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 int
+		arg5 *zap.Logger
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ListTagsStub
+	fakeReturns := fake.listTagsReturns
+	fake.recordInvocation("ListTags", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.listTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TagService) ListTagsCallCount() int {
+	fake.listTagsMutex.RLock()
+	defer fake.listTagsMutex.RUnlock()
+	return len(fake.listTagsArgsForCall)
+}
+
+func (fake *TagService) ListTagsCalls(stub func(string, string, int, int, *zap.Logger) (*models.TagList, error)) {
+	fake.listTagsMutex.Lock()
+	defer fake.listTagsMutex.Unlock()
+	fake.ListTagsStub = stub
+}
+
+func (fake *TagService) ListTagsArgsForCall(i int) (string, string, int, int, *zap.Logger) {
+	fake.listTagsMutex.RLock()
+	defer fake.listTagsMutex.RUnlock()
+	argsForCall := fake.listTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *TagService) ListTagsReturns(result1 *models.TagList, result2 error) {
+	fake.listTagsMutex.Lock()
+	defer fake.listTagsMutex.Unlock()
+	fake.ListTagsStub = nil
+	fake.listTagsReturns = struct {
+		result1 *models.TagList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagService) ListTagsReturnsOnCall(i int, result1 *models.TagList, result2 error) {
+	fake.listTagsMutex.Lock()
+	defer fake.listTagsMutex.Unlock()
+	fake.ListTagsStub = nil
+	if fake.listTagsReturnsOnCall == nil {
+		fake.listTagsReturnsOnCall = make(map[int]struct {
+			result1 *models.TagList
+			result2 error
+		})
+	}
+	fake.listTagsReturnsOnCall[i] = struct {
+		result1 *models.TagList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.attachTagsMutex.RLock()
+	defer fake.attachTagsMutex.RUnlock()
+	fake.detachTagsMutex.RLock()
+	defer fake.detachTagsMutex.RUnlock()
+	fake.listTagsMutex.RLock()
+	defer fake.listTagsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *TagService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ globaltagging.TagManager = new(TagService)
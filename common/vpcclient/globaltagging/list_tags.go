@@ -0,0 +1,66 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package globaltagging ...
+package globaltagging
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"go.uber.org/zap"
+)
+
+// ListTags GETs /tags attached to the resource
+func (ts *TagService) ListTags(resourceCRN string, tagType string, offset int, limit int, ctxLogger *zap.Logger) (*models.TagList, error) {
+	ctxLogger.Debug("Entry Backend ListTags")
+	defer ctxLogger.Debug("Exit Backend ListTags")
+
+	defer util.TimeTracker("ListTags", time.Now())
+
+	operation := &client.Operation{
+		Name:        "ListTags",
+		Method:      "GET",
+		PathPattern: tagsPath,
+	}
+
+	var tags models.TagList
+	var apiErr models.Error
+
+	request := ts.client.NewRequest(operation)
+	ctxLogger.Info("Equivalent curl command", zap.Reflect("URL", request.URL()), zap.Reflect("Operation", operation))
+
+	req := request.JSONSuccess(&tags).JSONError(&apiErr)
+	req.AddQueryValue("attached_to", resourceCRN)
+	req.AddQueryValue("tag_type", tagType)
+
+	if offset > 0 {
+		req.AddQueryValue("offset", strconv.Itoa(offset))
+	}
+
+	if limit > 0 {
+		req.AddQueryValue("limit", strconv.Itoa(limit))
+	}
+
+	_, err := req.Invoke()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tags, nil
+}
@@ -0,0 +1,111 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package globaltagging_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/globaltagging"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas/test"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestListTags(t *testing.T) {
+	// Setup new style zap logger
+	logger, _ := GetTestContextLogger()
+	defer logger.Sync()
+
+	testCases := []struct {
+		name string
+
+		// Response
+		status  int
+		content string
+
+		offset int
+		limit  int
+
+		// Expected return
+		expectErr string
+		verify    func(t *testing.T, tags *models.TagList, err error)
+		muxVerify func(*testing.T, *http.Request)
+	}{
+		{
+			name:   "Verify that the correct endpoint is invoked",
+			status: http.StatusNoContent,
+			muxVerify: func(t *testing.T, r *http.Request) {
+				expectedValues := url.Values{"attached_to": []string{shareCRN}, "tag_type": []string{models.TagTypeUser}, "version": []string{models.APIVersion}}
+				assert.Equal(t, expectedValues, r.URL.Query())
+			},
+		}, {
+			name:      "Verify that a 404 is returned to the caller",
+			status:    http.StatusNotFound,
+			content:   "{\"errors\":[{\"message\":\"testerr\",\"code\":\"not_found\"}], \"trace\":\"2af63776-4df7-4970-b52d-4e25676ec0e4\"}",
+			expectErr: "Trace Code:2af63776-4df7-4970-b52d-4e25676ec0e4, Code:not_found, Description:testerr, RC:404 Not Found",
+		}, {
+			name:   "Verify that offset and limit are added to the query",
+			offset: 100,
+			limit:  50,
+			status: http.StatusNoContent,
+			muxVerify: func(t *testing.T, r *http.Request) {
+				assert.Equal(t, "100", r.URL.Query().Get("offset"))
+				assert.Equal(t, "50", r.URL.Query().Get("limit"))
+			},
+		}, {
+			name:    "Verify that the tags are parsed correctly",
+			status:  http.StatusOK,
+			content: "{\"total_count\":2,\"offset\":0,\"limit\":100,\"items\":[{\"name\":\"team-a\"},{\"name\":\"team-b\"}]}",
+			verify: func(t *testing.T, tags *models.TagList, err error) {
+				if assert.NotNil(t, tags) {
+					assert.Equal(t, 2, tags.TotalCount)
+					assert.Equal(t, []models.Tag{{Name: "team-a"}, {Name: "team-b"}}, tags.Items)
+				}
+			},
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			emptyString := ""
+			mux, client, teardown := test.SetupServer(t)
+			test.SetupMuxResponse(t, mux, globaltagging.Version+"/tags", http.MethodGet, &emptyString, testcase.status, testcase.content, testcase.muxVerify)
+
+			defer teardown()
+
+			logger.Info("Test case being executed", zap.Reflect("testcase", testcase.name))
+
+			tagService := globaltagging.New(client)
+
+			tags, err := tagService.ListTags(shareCRN, models.TagTypeUser, testcase.offset, testcase.limit, logger)
+
+			if testcase.expectErr != "" && assert.Error(t, err) {
+				assert.Equal(t, testcase.expectErr, err.Error())
+				assert.Nil(t, tags)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, tags)
+			}
+
+			if testcase.verify != nil {
+				testcase.verify(t, tags, err)
+			}
+		})
+	}
+}
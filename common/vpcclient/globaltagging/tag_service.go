@@ -0,0 +1,68 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package globaltagging is the client of the IBM Cloud Global Tagging API, which attaches user
+// and access tags to resources by CRN
+package globaltagging
+
+import (
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"go.uber.org/zap"
+)
+
+// TagManager operations
+//
+//go:generate counterfeiter -o fakes/tag.go --fake-name TagService . TagManager
+type TagManager interface {
+
+	// AttachTags attaches the tags of tagType, models.TagTypeUser or models.TagTypeAccess, to the resource
+	AttachTags(resourceCRN string, tagType string, tagNames []string, ctxLogger *zap.Logger) error
+
+	// DetachTags detaches the tags of tagType from the resource
+	DetachTags(resourceCRN string, tagType string, tagNames []string, ctxLogger *zap.Logger) error
+
+	// ListTags lists the tags of tagType attached to the resource
+	ListTags(resourceCRN string, tagType string, offset int, limit int, ctxLogger *zap.Logger) (*models.TagList, error)
+}
+
+// TagService ...
+type TagService struct {
+	client client.SessionClient
+}
+
+var _ TagManager = &TagService{}
+
+// New ...
+func New(client client.SessionClient) TagManager {
+	return &TagService{
+		client: client,
+	}
+}
+
+// checkResults turns the results reported in error into an error
+func checkResults(operation string, results *models.TagResults) error {
+	for _, result := range results.Results {
+		if result.IsError {
+			return &models.Error{
+				Errors: []models.ErrorItem{{
+					Code:    models.ErrorCodeTagsNotUpdated,
+					Message: operation + " failed for resource " + result.ResourceID,
+				}},
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package models ...
+package models
+
+import "time"
+
+// Accessor binding roles of a share
+const (
+	// AccessorBindingRoleNone is the role of a share which is neither an origin nor an accessor share
+	AccessorBindingRoleNone = "none"
+	// AccessorBindingRoleOrigin is the role of a share which other accounts access through accessor shares
+	AccessorBindingRoleOrigin = "origin"
+	// AccessorBindingRoleAccessor is the role of a share which gives access to the data of an origin share
+	AccessorBindingRoleAccessor = "accessor"
+)
+
+// ShareAccessorBinding binds an origin share to one of its accessor shares
+type ShareAccessorBinding struct {
+	ID        string     `json:"id,omitempty"`
+	Href      string     `json:"href,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Accessor is the accessor share, which may be in another account
+	Accessor *ShareAccessor `json:"accessor,omitempty"`
+	// Status of the binding named - deleting, failed, pending, stable, updating, waiting, suspended
+	LifecycleState string `json:"lifecycle_state,omitempty"`
+	ResourceType   string `json:"resource_type,omitempty"`
+}
+
+// ShareAccessor is the accessor share of an accessor binding
+type ShareAccessor struct {
+	CRN  string `json:"crn,omitempty"`
+	Href string `json:"href,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Remote is set when the accessor share is in another account
+	Remote       *ShareRemote `json:"remote,omitempty"`
+	ResourceType string       `json:"resource_type,omitempty"`
+}
+
+// ShareRemote tells where a share of another account lives
+type ShareRemote struct {
+	Account *AccountReference `json:"account,omitempty"`
+}
+
+// AccountReference ...
+type AccountReference struct {
+	ID           string `json:"id,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+}
+
+// ShareAccessorBindingList ...
+type ShareAccessorBindingList struct {
+	First            *HReference             `json:"first,omitempty"`
+	Next             *HReference             `json:"next,omitempty"`
+	AccessorBindings []*ShareAccessorBinding `json:"accessor_bindings"`
+	Limit            int                     `json:"limit,omitempty"`
+	TotalCount       int                     `json:"total_count,omitempty"`
+}
@@ -43,6 +43,8 @@ const (
 	ErrorCodeInvalidState ErrorCode = "invalid_state"
 	ErrorCodeNotFound     ErrorCode = "not_found"
 	ErrorCodeTokenInvalid ErrorCode = "token_invalid"
+	// ErrorCodeTagsNotUpdated is set when the Global Tagging API reports a resource whose tags were not updated
+	ErrorCodeTagsNotUpdated ErrorCode = "tags_not_updated"
 )
 
 // Error ...
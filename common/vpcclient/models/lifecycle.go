@@ -0,0 +1,97 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package models ...
+package models
+
+// LifecycleStateFailed is the lifecycle state of a resource whose last change failed
+const LifecycleStateFailed = "failed"
+
+// LifecycleReason explains the current lifecycle state of a resource, typically why it failed
+type LifecycleReason struct {
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message,omitempty"`
+	MoreInfo string `json:"more_info,omitempty"`
+}
+
+// LifecycleResource is a resource with a lifecycle state, i.e. a share, a mount target, a snapshot or an accessor binding
+type LifecycleResource interface {
+	// GetLifecycleState returns the lifecycle state, e.g. stable or failed, or empty for a nil resource
+	GetLifecycleState() string
+	// GetLifecycleReasons returns the reasons for the lifecycle state, if any
+	GetLifecycleReasons() []LifecycleReason
+}
+
+// GetLifecycleState ...
+func (s *Share) GetLifecycleState() string {
+	if s == nil {
+		return ""
+	}
+	return string(s.Status)
+}
+
+// GetLifecycleReasons ...
+func (s *Share) GetLifecycleReasons() []LifecycleReason {
+	if s == nil {
+		return nil
+	}
+	return s.LifecycleReasons
+}
+
+// GetLifecycleState ...
+func (st *ShareTarget) GetLifecycleState() string {
+	if st == nil {
+		return ""
+	}
+	return st.Status
+}
+
+// GetLifecycleReasons ...
+func (st *ShareTarget) GetLifecycleReasons() []LifecycleReason {
+	if st == nil {
+		return nil
+	}
+	return st.LifecycleReasons
+}
+
+// GetLifecycleState ...
+func (s *Snapshot) GetLifecycleState() string {
+	if s == nil {
+		return ""
+	}
+	return s.LifecycleState
+}
+
+// GetLifecycleReasons ...
+func (s *Snapshot) GetLifecycleReasons() []LifecycleReason {
+	if s == nil {
+		return nil
+	}
+	return s.LifecycleReasons
+}
+
+// GetLifecycleState ...
+func (b *ShareAccessorBinding) GetLifecycleState() string {
+	if b == nil {
+		return ""
+	}
+	return b.LifecycleState
+}
+
+// GetLifecycleReasons ...
+func (b *ShareAccessorBinding) GetLifecycleReasons() []LifecycleReason {
+	return nil
+}
@@ -26,18 +26,43 @@ type Profile struct {
 
 type ProfileDetails struct {
 	Profile
+	Bandwidth    CapIops `json:"bandwidth,omitempty"`
 	Capacity     CapIops `json:"capacity,omitempty"`
 	Family       string  `json:"family,omitempty"`
 	Iops         CapIops `json:"iops,omitempty"`
 	ResourceType string  `json:"resource_type,omitempty"`
 }
 
+// ProfileList ...
+type ProfileList struct {
+	First      *HReference      `json:"first,omitempty"`
+	Next       *HReference      `json:"next,omitempty"`
+	Profiles   []ProfileDetails `json:"profiles"`
+	Limit      int              `json:"limit,omitempty"`
+	TotalCount int              `json:"total_count,omitempty"`
+}
+
 // CapIops
 type CapIops struct {
-	Default int32  `json:"default,omitempty"`
-	Max     int32  `json:"max,omitempty"`
-	Min     int32  `json:"min,omitempty"`
-	Step    int32  `json:"step,omitempty"`
-	Type    string `json:"type,omitempty"`
-	Value   int32  `json:"value,omitempty"`
+	Default int32   `json:"default,omitempty"`
+	Max     int32   `json:"max,omitempty"`
+	Min     int32   `json:"min,omitempty"`
+	Step    int32   `json:"step,omitempty"`
+	Type    string  `json:"type,omitempty"`
+	Value   int32   `json:"value,omitempty"`
+	Values  []int32 `json:"values,omitempty"`
 }
+
+// Types of CapIops, telling which of its fields hold the allowed values
+const (
+	// CapIopsFixed allows Value only
+	CapIopsFixed = "fixed"
+	// CapIopsRange allows Min to Max, in steps of Step from Min
+	CapIopsRange = "range"
+	// CapIopsEnum allows Values only
+	CapIopsEnum = "enum"
+	// CapIopsDependent depends on other properties of the share, e.g. its size
+	CapIopsDependent = "dependent"
+	// CapIopsDependentRange is a range whose bounds depend on other properties of the share
+	CapIopsDependentRange = "dependent_range"
+)
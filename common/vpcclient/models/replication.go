@@ -0,0 +1,74 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package models ...
+package models
+
+import "time"
+
+// Replication roles of a share
+const (
+	ReplicationRoleNone    = "none"
+	ReplicationRoleReplica = "replica"
+	ReplicationRoleSource  = "source"
+)
+
+// Replication statuses of a share
+const (
+	ReplicationStatusActive          = "active"
+	ReplicationStatusDegraded        = "degraded"
+	ReplicationStatusFailoverPending = "failover_pending"
+	ReplicationStatusInitializing    = "initializing"
+	ReplicationStatusNone            = "none"
+	ReplicationStatusSplitPending    = "split_pending"
+)
+
+// Fallback policies of a failover, telling what to do when the source share cannot be reached in time
+const (
+	FailoverFallbackFail  = "fail"
+	FailoverFallbackSplit = "split"
+)
+
+// ShareReference identifies a share by ID, CRN or href, e.g. the source share of a replica
+type ShareReference struct {
+	CRN  string `json:"crn,omitempty"`
+	Href string `json:"href,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// ShareLatestSync is the latest completed sync of a replica share with its source
+type ShareLatestSync struct {
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// DataTransferred is the number of bytes copied by the sync
+	DataTransferred int64 `json:"data_transferred,omitempty"`
+}
+
+// ReplicationStatusReason explains the current replication status of a share, typically why it is degraded
+type ReplicationStatusReason struct {
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message,omitempty"`
+	MoreInfo string `json:"more_info,omitempty"`
+}
+
+// ShareFailoverRequest asks a replica share to take over from its source
+type ShareFailoverRequest struct {
+	// FallbackPolicy is FailoverFallbackFail or FailoverFallbackSplit
+	FallbackPolicy string `json:"fallback_policy,omitempty"`
+	// Timeout is how long to wait for the source share, in seconds, before applying the fallback policy
+	Timeout int64 `json:"timeout,omitempty"`
+}
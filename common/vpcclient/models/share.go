@@ -38,15 +38,34 @@ type Share struct {
 	CreatedAt      *time.Time     `json:"created_at,omitempty"`
 	UserTags       []string       `json:"user_tags,omitempty"`
 	// Status of share named - deleted, deleting, failed, pending, stable, updating, waiting, suspended
-	Status            StatusType     `json:"lifecycle_state,omitempty"`
-	ShareTargets      *[]ShareTarget `json:"mount_targets,omitempty"`
-	Zone              *Zone          `json:"zone,omitempty"`
-	AccessControlMode string         `json:"access_control_mode,omitempty"`
+	Status            StatusType        `json:"lifecycle_state,omitempty"`
+	LifecycleReasons  []LifecycleReason `json:"lifecycle_reasons,omitempty"`
+	ShareTargets      *[]ShareTarget    `json:"mount_targets,omitempty"`
+	Zone              *Zone             `json:"zone,omitempty"`
+	AccessControlMode string            `json:"access_control_mode,omitempty"`
+	// SourceShare is the share a replica share is replicated from
+	SourceShare *ShareReference `json:"source_share,omitempty"`
+	// ReplicaShare is the replica share of a source share
+	ReplicaShare *ShareReference `json:"replica_share,omitempty"`
+	// ReplicationCronSpec is the schedule of the syncs of a replica share, in cron format
+	ReplicationCronSpec      string                    `json:"replication_cron_spec,omitempty"`
+	ReplicationRole          string                    `json:"replication_role,omitempty"`
+	ReplicationStatus        string                    `json:"replication_status,omitempty"`
+	ReplicationStatusReasons []ReplicationStatusReason `json:"replication_status_reasons,omitempty"`
+	LatestSync               *ShareLatestSync          `json:"latest_sync,omitempty"`
+	// AccessorBindingRole is the role of the share in accessor bindings, one of AccessorBindingRoleNone, Origin or Accessor
+	AccessorBindingRole string `json:"accessor_binding_role,omitempty"`
+	// OriginShare is the share an accessor share gives access to, possibly in another account
+	OriginShare *ShareReference `json:"origin_share,omitempty"`
+	// AllowedTransitEncryptionModes are the transit encryption modes the mount targets of the share may use
+	AllowedTransitEncryptionModes []string `json:"allowed_transit_encryption_modes,omitempty"`
 }
 
 // ListShareTargerFilters ...
 type ListShareTargetFilters struct {
 	ShareTargetName string `json:"name,omitempty"`
+	Start           string `json:"start,omitempty"`
+	Limit           int    `json:"limit,omitempty"`
 }
 
 // ListShareFilters ...
@@ -30,8 +30,9 @@ type ShareTarget struct {
 	MountPath string `json:"mount_path,omitempty"`
 	Name      string `json:"name,omitempty"`
 	// Status of share target named - deleted, deleting, failed, pending, stable, updating, waiting, suspended
-	Status string        `json:"lifecycle_state,omitempty"`
-	VPC    *provider.VPC `json:"vpc,omitempty"`
+	Status           string            `json:"lifecycle_state,omitempty"`
+	LifecycleReasons []LifecycleReason `json:"lifecycle_reasons,omitempty"`
+	VPC              *provider.VPC     `json:"vpc,omitempty"`
 	//EncryptionInTransit
 	TransitEncryption       string                   `json:"transit_encryption,omitempty"`
 	AccessProtocol          string                   `json:"access_protocol,omitempty"`
@@ -47,6 +47,7 @@ type Snapshot struct {
 	Status           string            `json:"status,omitempty"`
 	ResourceType     string            `json:"resource_type,omitempty"`
 	LifecycleState   string            `json:"lifecycle_state,omitempty"`
+	LifecycleReasons []LifecycleReason `json:"lifecycle_reasons,omitempty"`
 	UserTags         []string          `json:"user_tags,omitempty"`
 	BackupPolicyPlan *BackupPolicyPlan `json:"backup_policy_plan,omitempty"`
 	Zone             *Zone             `json:"zone,omitempty"`
@@ -0,0 +1,61 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package models ...
+package models
+
+// Tag types of the Global Tagging API
+const (
+	// TagTypeUser is the type of the tags users attach to organise their resources
+	TagTypeUser = "user"
+	// TagTypeAccess is the type of the tags IAM access policies refer to, shaped as key:value
+	TagTypeAccess = "access"
+)
+
+// TagResource is a resource tags are attached to or detached from
+type TagResource struct {
+	ResourceID string `json:"resource_id"`
+}
+
+// TagRequest attaches tags to or detaches tags from resources
+type TagRequest struct {
+	Resources []TagResource `json:"resources"`
+	TagNames  []string      `json:"tag_names"`
+}
+
+// TagResults is the outcome of a TagRequest, one result per resource
+type TagResults struct {
+	Results []TagResult `json:"results,omitempty"`
+}
+
+// TagResult is the outcome of a TagRequest for one resource
+type TagResult struct {
+	ResourceID string `json:"resource_id,omitempty"`
+	IsError    bool   `json:"is_error,omitempty"`
+}
+
+// Tag ...
+type Tag struct {
+	Name string `json:"name,omitempty"`
+}
+
+// TagList is a page of the tags attached to a resource
+type TagList struct {
+	TotalCount int   `json:"total_count,omitempty"`
+	Offset     int   `json:"offset,omitempty"`
+	Limit      int   `json:"limit,omitempty"`
+	Items      []Tag `json:"items"`
+}
@@ -0,0 +1,177 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package pager walks the paginated list collections of the VPC API by following their next references
+package pager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// DefaultMaxPages guards against a backend which never stops returning next references
+const DefaultMaxPages = 10000
+
+// ErrInvalidNext is returned when the next reference of a page has no usable start token
+var ErrInvalidNext = errors.New("invalid next page reference")
+
+// ErrPageLoop is returned when a next reference points back to a page which was already fetched
+var ErrPageLoop = errors.New("next page reference loops back to an already fetched page")
+
+// ErrTooManyPages is returned when a collection has more pages than the pager allows
+var ErrTooManyPages = errors.New("too many pages")
+
+// PageFunc fetches the page starting at start, an empty start meaning the first page. It returns the
+// items of the page and the reference to the next page, which is nil on the last page
+type PageFunc[T any] func(start string) ([]T, *models.HReference, error)
+
+// Pager iterates over the pages of a list collection
+type Pager[T any] struct {
+	fetch    PageFunc[T]
+	start    string
+	done     bool
+	pages    int
+	seen     map[string]bool
+	maxPages int
+	retry    func(func() error) error
+}
+
+// New creates a pager which fetches its pages through fetch
+func New[T any](fetch PageFunc[T]) *Pager[T] {
+	return &Pager[T]{
+		fetch:    fetch,
+		seen:     map[string]bool{},
+		maxPages: DefaultMaxPages,
+	}
+}
+
+// WithMaxPages limits the number of pages which are fetched
+func (p *Pager[T]) WithMaxPages(maxPages int) *Pager[T] {
+	p.maxPages = maxPages
+	return p
+}
+
+// WithRetry fetches each page through retry, e.g. the retry of a session, so that a failed page is fetched again
+// without restarting from the first page
+func (p *Pager[T]) WithRetry(retry func(func() error) error) *Pager[T] {
+	p.retry = retry
+	return p
+}
+
+// HasNext tells if there are more pages to fetch
+func (p *Pager[T]) HasNext() bool {
+	return !p.done
+}
+
+// Next fetches the next page
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if p.maxPages > 0 && p.pages >= p.maxPages {
+		p.done = true
+		return nil, fmt.Errorf("%w: more than %d pages", ErrTooManyPages, p.maxPages)
+	}
+
+	var items []T
+	var next *models.HReference
+	fetch := func() error {
+		var err error
+		items, next, err = p.fetch(p.start)
+		return err
+	}
+	var err error
+	if p.retry != nil {
+		err = p.retry(fetch)
+	} else {
+		err = fetch()
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.pages++
+	p.seen[p.start] = true
+
+	if next == nil || next.Href == "" {
+		p.done = true
+		return items, nil
+	}
+
+	start, err := NextStart(next)
+	if err != nil {
+		p.done = true
+		return items, err
+	}
+	if p.seen[start] {
+		p.done = true
+		return items, fmt.Errorf("%w: start=%s", ErrPageLoop, start)
+	}
+	p.start = start
+	return items, nil
+}
+
+// ForEach calls visit for every item of every remaining page. It stops early, without error,
+// as soon as visit returns false
+func (p *Pager[T]) ForEach(ctx context.Context, visit func(item T) bool) error {
+	for p.HasNext() {
+		items, err := p.Next(ctx)
+		for _, item := range items {
+			if !visit(item) {
+				p.done = true
+				return nil
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// All collects the items of every remaining page
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	err := p.ForEach(ctx, func(item T) bool {
+		all = append(all, item)
+		return true
+	})
+	return all, err
+}
+
+// NextStart extracts the start token from the next page reference returned by the VPC API,
+// e.g. https://us-south.iaas.cloud.ibm.com/v1/shares?limit=50&start=r006-1234. It returns an
+// empty token when there is no next page
+func NextStart(next *models.HReference) (string, error) {
+	if next == nil || next.Href == "" {
+		return "", nil
+	}
+	nextURL, err := url.Parse(next.Href)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidNext, err)
+	}
+	start := nextURL.Query().Get("start")
+	if start == "" {
+		return "", fmt.Errorf("%w: no start in %s", ErrInvalidNext, next.Href)
+	}
+	return start, nil
+}
@@ -0,0 +1,203 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// pages serves the items by page, keyed by start token, and records the requested tokens
+type pages struct {
+	items     map[string][]int
+	next      map[string]string
+	requested []string
+}
+
+func (p *pages) fetch(start string) ([]int, *models.HReference, error) {
+	p.requested = append(p.requested, start)
+	var next *models.HReference
+	if href, ok := p.next[start]; ok {
+		next = &models.HReference{Href: href}
+	}
+	return p.items[start], next, nil
+}
+
+func threePages() *pages {
+	return &pages{
+		items: map[string][]int{"": {1, 2}, "r006-a&b": {3, 4}, "r006-c": {5}},
+		next: map[string]string{
+			"":         "https://us-south.iaas.cloud.ibm.com/v1/shares?limit=2&start=r006-a%26b",
+			"r006-a&b": "https://us-south.iaas.cloud.ibm.com/v1/shares?start=r006-c&limit=2",
+		},
+	}
+}
+
+func TestAll(t *testing.T) {
+	source := threePages()
+	items, err := New(source.fetch).All(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, []string{"", "r006-a&b", "r006-c"}, source.requested)
+}
+
+func TestForEachStopsEarly(t *testing.T) {
+	source := threePages()
+	p := New(source.fetch)
+	var visited []int
+	err := p.ForEach(context.Background(), func(item int) bool {
+		visited = append(visited, item)
+		return item != 3
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, visited)
+	assert.Equal(t, []string{"", "r006-a&b"}, source.requested)
+	assert.False(t, p.HasNext())
+}
+
+func TestNext(t *testing.T) {
+	p := New(threePages().fetch)
+	var pageSizes []int
+	for p.HasNext() {
+		items, err := p.Next(context.Background())
+		assert.NoError(t, err)
+		pageSizes = append(pageSizes, len(items))
+	}
+	assert.Equal(t, []int{2, 2, 1}, pageSizes)
+
+	items, err := p.Next(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, items)
+}
+
+func TestPagerErrors(t *testing.T) {
+	errBackend := errors.New("backend down")
+
+	testCases := []struct {
+		testCaseName string
+		source       *pages
+		fetchErr     error
+		maxPages     int
+		expectedErr  error
+		expectedList []int
+	}{
+		{
+			testCaseName: "Fetch fails",
+			source:       threePages(),
+			fetchErr:     errBackend,
+			expectedErr:  errBackend,
+		}, {
+			testCaseName: "Next without start",
+			source: &pages{
+				items: map[string][]int{"": {1}},
+				next:  map[string]string{"": "https://us-south.iaas.cloud.ibm.com/v1/shares?limit=2"},
+			},
+			expectedErr:  ErrInvalidNext,
+			expectedList: []int{1},
+		}, {
+			testCaseName: "Next loops back",
+			source: &pages{
+				items: map[string][]int{"": {1}, "a": {2}},
+				next:  map[string]string{"": "https://x/v1/shares?start=a", "a": "https://x/v1/shares?start=a"},
+			},
+			expectedErr:  ErrPageLoop,
+			expectedList: []int{1, 2},
+		}, {
+			testCaseName: "Too many pages",
+			source:       threePages(),
+			maxPages:     2,
+			expectedErr:  ErrTooManyPages,
+			expectedList: []int{1, 2, 3, 4},
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.testCaseName, func(t *testing.T) {
+			fetch := testcase.source.fetch
+			if testcase.fetchErr != nil {
+				fetch = func(start string) ([]int, *models.HReference, error) {
+					return nil, nil, testcase.fetchErr
+				}
+			}
+			p := New(fetch)
+			if testcase.maxPages > 0 {
+				p.WithMaxPages(testcase.maxPages)
+			}
+			items, err := p.All(context.Background())
+			assert.ErrorIs(t, err, testcase.expectedErr)
+			assert.Equal(t, testcase.expectedList, items)
+		})
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	source := threePages()
+	failures := 0
+	flaky := func(start string) ([]int, *models.HReference, error) {
+		if start == "r006-a&b" && failures == 0 {
+			failures++
+			return nil, nil, errors.New("internal error")
+		}
+		return source.fetch(start)
+	}
+	retries := 0
+	items, err := New(flaky).WithRetry(func(fetch func() error) error {
+		err := fetch()
+		if err != nil {
+			retries++
+			err = fetch()
+		}
+		return err
+	}).All(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	assert.Equal(t, 1, retries)
+	// Only the failed page is fetched again
+	assert.Equal(t, []string{"", "r006-a&b", "r006-c"}, source.requested)
+}
+
+func TestContextCancelled(t *testing.T) {
+	source := threePages()
+	ctx, cancel := context.WithCancel(context.Background())
+	p := New(source.fetch)
+	err := p.ForEach(ctx, func(item int) bool {
+		cancel()
+		return true
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []string{""}, source.requested)
+}
+
+func TestNextStart(t *testing.T) {
+	start, err := NextStart(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", start)
+
+	start, err = NextStart(&models.HReference{Href: "https://eu-gb.iaas.cloud.ibm.com/v1/shares?start=3e898aa7&limit=1&name=eu-gb-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "3e898aa7", start)
+
+	_, err = NextStart(&models.HReference{Href: "https://eu-gb.iaas.cloud.ibm.com/v1/shares?limit=1"})
+	assert.ErrorIs(t, err, ErrInvalidNext)
+
+	_, err = NextStart(&models.HReference{Href: "://bad url"})
+	assert.ErrorIs(t, err, ErrInvalidNext)
+}
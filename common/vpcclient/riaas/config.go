@@ -21,6 +21,8 @@ import (
 	"context"
 	"io"
 	"net/http"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
 )
 
 // Config for the Session
@@ -38,6 +40,17 @@ type Config struct {
 	Context       context.Context
 	APIVersion    string
 	APIGeneration int
+
+	// GlobalTaggingURL is the endpoint of the Global Tagging API, the session has no tag service when it is empty
+	GlobalTaggingURL string
+
+	// RateLimit is the number of requests per second the session may send, 0 means no limit
+	RateLimit float64
+	// RateLimitBurst is the number of requests which may be sent at once before RateLimit applies
+	RateLimitBurst int
+	// RateLimiter paces the requests instead of RateLimit and RateLimitBurst. Set it to share
+	// one budget across all the sessions created from this Config
+	RateLimiter client.RateLimiter
 }
 
 func (c Config) httpClient() *http.Client {
@@ -48,6 +61,16 @@ func (c Config) httpClient() *http.Client {
 	return http.DefaultClient
 }
 
+func (c Config) rateLimiter() client.RateLimiter {
+	if c.RateLimiter != nil {
+		return c.RateLimiter
+	}
+	if c.RateLimit > 0 {
+		return client.NewTokenBucket(c.RateLimit, c.RateLimitBurst)
+	}
+	return nil
+}
+
 func (c Config) baseURL() string {
 	return c.BaseURL
 }
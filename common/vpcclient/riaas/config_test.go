@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,3 +42,16 @@ func TestConfig(t *testing.T) {
 	assert.NotNil(t, cfg.httpClient())
 	assert.Equal(t, "http://gc", cfg.baseURL())
 }
+
+func TestConfigRateLimiter(t *testing.T) {
+	cfg := Config{}
+	assert.Nil(t, cfg.rateLimiter())
+
+	cfg.RateLimit = 5
+	cfg.RateLimitBurst = 10
+	assert.IsType(t, &client.TokenBucket{}, cfg.rateLimiter())
+
+	shared := client.NewTokenBucket(1, 1)
+	cfg.RateLimiter = shared
+	assert.Equal(t, shared, cfg.rateLimiter())
+}
@@ -23,6 +23,7 @@ import (
 	"strconv"
 
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/globaltagging"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
 )
@@ -38,12 +39,36 @@ type RegionalAPI interface {
 	SnapshotService() vpcfilevolume.SnapshotManager
 }
 
+// ContextAwareAPI is implemented by RegionalAPI clients which can bind their requests to a
+// caller supplied context, so that a deadline or cancellation reaches the underlying HTTP calls
+type ContextAwareAPI interface {
+	WithContext(ctx context.Context) RegionalAPI
+}
+
+// TokenSourceAPI is implemented by RegionalAPI clients which can take their authentication
+// token from a TokenSource, so that the token is refreshed for the lifetime of the session
+type TokenSourceAPI interface {
+	LoginWithTokenSource(source client.TokenSource) error
+}
+
+// TaggingAPI is implemented by RegionalAPI clients which can reach the Global Tagging API.
+// TagService returns nil when the client was configured without a Global Tagging endpoint
+type TaggingAPI interface {
+	TagService() globaltagging.TagManager
+}
+
 var _ RegionalAPI = &Session{}
+var _ ContextAwareAPI = &Session{}
+var _ TokenSourceAPI = &Session{}
+var _ TaggingAPI = &Session{}
 
 // Session is a base implementation of the RegionalAPI interface
 type Session struct {
 	client client.SessionClient
 	config Config
+
+	// tagging is the client of the Global Tagging API, nil when Config.GlobalTaggingURL is empty
+	tagging client.SessionClient
 }
 
 // New creates a new Session volume, using the supplied config
@@ -77,9 +102,29 @@ func New(config Config) (*Session, error) {
 	if config.DebugWriter != nil {
 		riaasClient.WithDebug(config.DebugWriter)
 	}
+
+	limiter := config.rateLimiter()
+	if limiter != nil {
+		riaasClient.WithRateLimiter(limiter)
+	}
+
+	// The Global Tagging API is neither versioned by date nor scoped to a resource group. Its calls are paced
+	// by the same limiter as the VPC ones
+	var taggingClient client.SessionClient
+	if config.GlobalTaggingURL != "" {
+		taggingClient = client.New(ctx, config.GlobalTaggingURL, url.Values{}, config.httpClient(), config.ContextID, "")
+		if config.DebugWriter != nil {
+			taggingClient.WithDebug(config.DebugWriter)
+		}
+		if limiter != nil {
+			taggingClient.WithRateLimiter(limiter)
+		}
+	}
+
 	return &Session{
-		client: riaasClient,
-		config: config,
+		client:  riaasClient,
+		config:  config,
+		tagging: taggingClient,
 	}, nil
 }
 
@@ -87,9 +132,39 @@ func New(config Config) (*Session, error) {
 // which is used for all requests to the API
 func (s *Session) Login(token string) error {
 	s.client.WithAuthToken(token)
+	if s.tagging != nil {
+		s.tagging.WithAuthToken(token)
+	}
+	return nil
+}
+
+// LoginWithTokenSource configures the session with the supplied token source
+// which provides the authentication token for all requests to the API
+func (s *Session) LoginWithTokenSource(source client.TokenSource) error {
+	s.client.WithTokenSource(source)
+	if s.tagging != nil {
+		s.tagging.WithTokenSource(source)
+	}
 	return nil
 }
 
+// WithContext returns a copy of the session whose requests are bound to the supplied context
+func (s *Session) WithContext(ctx context.Context) RegionalAPI {
+	return s.withContext(ctx)
+}
+
+// withContext returns a copy of the session whose requests, the tagging ones included, are bound to ctx
+func (s *Session) withContext(ctx context.Context) *Session {
+	scoped := &Session{
+		client: s.client.WithContext(ctx),
+		config: s.config,
+	}
+	if s.tagging != nil {
+		scoped.tagging = s.tagging.WithContext(ctx)
+	}
+	return scoped
+}
+
 // VolumeFileService returns the Volume service for managing file volumes
 func (s *Session) FileShareService() vpcfilevolume.FileShareManager {
 	return vpcfilevolume.New(s.client)
@@ -100,6 +175,14 @@ func (s *Session) SnapshotService() vpcfilevolume.SnapshotManager {
 	return vpcfilevolume.NewSnapshotManager(s.client)
 }
 
+// TagService returns the Tag service for managing the tags of resources, nil without a Global Tagging endpoint
+func (s *Session) TagService() globaltagging.TagManager {
+	if s.tagging == nil {
+		return nil
+	}
+	return globaltagging.New(s.tagging)
+}
+
 // RegionalAPIClientProvider declares an interface for a provider that can supply a new
 // RegionalAPI client session
 //
@@ -126,6 +209,16 @@ type IKSSession struct {
 }
 
 var _ RegionalAPI = &IKSSession{}
+var _ ContextAwareAPI = &IKSSession{}
+var _ TokenSourceAPI = &IKSSession{}
+var _ TaggingAPI = &IKSSession{}
+
+// WithContext returns a copy of the IKS session whose requests are bound to the supplied context
+func (s *IKSSession) WithContext(ctx context.Context) RegionalAPI {
+	return &IKSSession{
+		Session: *s.Session.withContext(ctx),
+	}
+}
 
 // FileShareService returns the FileShare service for managing shares
 func (s *IKSSession) FileShareService() vpcfilevolume.FileShareManager {
@@ -19,12 +19,19 @@ package riaas
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client/fakes"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
 func TestLogin(t *testing.T) {
@@ -43,6 +50,23 @@ func TestLogin(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLoginWithTokenSource(t *testing.T) {
+	sessionClient := &fakes.SessionClient{}
+
+	riaas := Session{
+		client: sessionClient,
+	}
+
+	source := client.NewRefreshingTokenSource("token", time.Time{}, time.Minute, nil)
+	err := riaas.LoginWithTokenSource(source)
+
+	if assert.Equal(t, 1, sessionClient.WithTokenSourceCallCount()) {
+		assert.Equal(t, source, sessionClient.WithTokenSourceArgsForCall(0))
+	}
+
+	assert.NoError(t, err)
+}
+
 func TestNewSession(t *testing.T) {
 	var b bytes.Buffer
 	cfg := Config{
@@ -98,3 +122,78 @@ func TestVolumeFileServicewithIKSSession(t *testing.T) {
 	volumeManager := (&IKSSession{}).FileShareService()
 	assert.NotNil(t, volumeManager)
 }
+
+func TestWithContext(t *testing.T) {
+	ctx := context.Background()
+
+	sessionClient := &fakes.SessionClient{}
+	scopedClient := &fakes.SessionClient{}
+	sessionClient.WithContextReturns(scopedClient)
+
+	session := &Session{client: sessionClient}
+	scoped, ok := session.WithContext(ctx).(*Session)
+	if assert.True(t, ok) {
+		assert.Equal(t, scopedClient, scoped.client)
+	}
+	assert.Equal(t, sessionClient, session.client)
+	assert.Equal(t, ctx, sessionClient.WithContextArgsForCall(0))
+
+	iksSession := &IKSSession{Session: Session{client: sessionClient}}
+	iksScoped, ok := iksSession.WithContext(ctx).(*IKSSession)
+	if assert.True(t, ok) {
+		assert.Equal(t, scopedClient, iksScoped.client)
+	}
+}
+
+func TestTagService(t *testing.T) {
+	session, err := New(Config{BaseURL: "http://gc"})
+	assert.NoError(t, err)
+	assert.Nil(t, session.TagService())
+
+	session, err = New(Config{BaseURL: "http://gc", GlobalTaggingURL: "http://tags"})
+	assert.NoError(t, err)
+	assert.NotNil(t, session.TagService())
+
+	sessionClient := &fakes.SessionClient{}
+	taggingClient := &fakes.SessionClient{}
+	scopedClient := &fakes.SessionClient{}
+	taggingClient.WithContextReturns(scopedClient)
+
+	session = &Session{client: sessionClient, tagging: taggingClient}
+	assert.NoError(t, session.Login("token"))
+	if assert.Equal(t, 1, taggingClient.WithAuthTokenCallCount()) {
+		assert.Equal(t, "token", taggingClient.WithAuthTokenArgsForCall(0))
+	}
+
+	scoped, ok := session.WithContext(context.Background()).(*Session)
+	if assert.True(t, ok) {
+		assert.Equal(t, scopedClient, scoped.tagging)
+	}
+}
+
+// countingLimiter counts the requests it lets through
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return nil
+}
+
+func TestTagServiceRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total_count":0,"items":[]}`))
+	}))
+	defer server.Close()
+
+	limiter := &countingLimiter{}
+	session, err := New(Config{BaseURL: server.URL, GlobalTaggingURL: server.URL, HTTPClient: server.Client(), RateLimiter: limiter})
+	assert.NoError(t, err)
+	assert.NoError(t, session.Login("token"))
+
+	_, err = session.TagService().ListTags("crn:v1:bluemix:public:is:us-south-1:a/account::share:share1", models.TagTypeUser, 0, 10, zap.NewNop())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), limiter.waits.Load())
+}
@@ -36,4 +36,9 @@ const (
 	snapshotsPath      = shareIDPath + "/snapshots"
 	snapshotIDParam    = "snapshot-id"
 	snapshotIDPath     = snapshotsPath + "/{" + snapshotIDParam + "}"
+	shareFailoverPath  = shareIDPath + "/failover"
+	shareSourcePath    = shareIDPath + "/source"
+	bindingsPath       = shareIDPath + "/accessor_bindings"
+	bindingIDParam     = "binding-id"
+	bindingIDPath      = bindingsPath + "/{" + bindingIDParam + "}"
 )
@@ -0,0 +1,52 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package vpcfilevolume ...
+package vpcfilevolume
+
+import (
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"go.uber.org/zap"
+)
+
+// DeleteFileShareSource DELETEs to /shares/{share-id}/source, splitting the replica share from its source share
+func (vs *FileShareService) DeleteFileShareSource(shareID string, ctxLogger *zap.Logger) error {
+	ctxLogger.Debug("Entry Backend DeleteFileShareSource")
+	defer ctxLogger.Debug("Exit Backend DeleteFileShareSource")
+
+	defer util.TimeTracker("DeleteFileShareSource", time.Now())
+
+	operation := &client.Operation{
+		Name:        "DeleteFileShareSource",
+		Method:      "DELETE",
+		PathPattern: shareSourcePath,
+	}
+
+	var apiErr models.Error
+
+	request := vs.client.NewRequest(operation)
+	ctxLogger.Info("Equivalent curl command", zap.Reflect("URL", request.URL()), zap.Reflect("Operation", operation))
+
+	_, err := request.PathParameter(shareIDParam, shareID).JSONError(&apiErr).Invoke()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
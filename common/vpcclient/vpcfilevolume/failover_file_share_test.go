@@ -0,0 +1,82 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcvfileolume_test ...
+package vpcfilevolume_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas/test"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestFailoverFileShare(t *testing.T) {
+	// Setup new style zap logger
+	logger, _ := GetTestContextLogger()
+	defer logger.Sync()
+
+	testCases := []struct {
+		name string
+
+		// Response
+		status  int
+		content string
+
+		// Expected return
+		expectErr string
+	}{
+		{
+			name:   "Verify that the correct endpoint is invoked",
+			status: http.StatusAccepted,
+		}, {
+			name:      "Verify that a 409 is returned to the caller",
+			status:    http.StatusConflict,
+			content:   "{\"errors\":[{\"message\":\"testerr\",\"Code\":\"shares_replication_invalid_state\"}], \"trace\":\"2af63776-4df7-4970-b52d-4e25676ec0e4\"}",
+			expectErr: "Trace Code:2af63776-4df7-4970-b52d-4e25676ec0e4, Code:shares_replication_invalid_state, Description:testerr, RC:409 Conflict",
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			mux, client, teardown := test.SetupServer(t)
+			test.SetupMuxResponse(t, mux, vpcfilevolume.Version+"/shares/share-id/failover", http.MethodPost, nil, testcase.status, testcase.content, func(t *testing.T, r *http.Request) {
+				var failoverRequest models.ShareFailoverRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&failoverRequest))
+				assert.Equal(t, models.ShareFailoverRequest{FallbackPolicy: models.FailoverFallbackSplit, Timeout: 300}, failoverRequest)
+			})
+
+			defer teardown()
+
+			logger.Info("Test case being executed", zap.Reflect("testcase", testcase.name))
+
+			shareService := vpcfilevolume.New(client)
+
+			err := shareService.FailoverFileShare("share-id", &models.ShareFailoverRequest{FallbackPolicy: models.FailoverFallbackSplit, Timeout: 300}, logger)
+
+			if testcase.expectErr != "" && assert.Error(t, err) {
+				assert.Equal(t, testcase.expectErr, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -52,6 +52,31 @@ type FileShareService struct {
 	deleteFileShareReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteFileShareAccessorBindingStub        func(string, string, *zap.Logger) error
+	deleteFileShareAccessorBindingMutex       sync.RWMutex
+	deleteFileShareAccessorBindingArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *zap.Logger
+	}
+	deleteFileShareAccessorBindingReturns struct {
+		result1 error
+	}
+	deleteFileShareAccessorBindingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteFileShareSourceStub        func(string, *zap.Logger) error
+	deleteFileShareSourceMutex       sync.RWMutex
+	deleteFileShareSourceArgsForCall []struct {
+		arg1 string
+		arg2 *zap.Logger
+	}
+	deleteFileShareSourceReturns struct {
+		result1 error
+	}
+	deleteFileShareSourceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DeleteFileShareTargetStub        func(*models.ShareTarget, *zap.Logger) (*http.Response, error)
 	deleteFileShareTargetMutex       sync.RWMutex
 	deleteFileShareTargetArgsForCall []struct {
@@ -81,6 +106,19 @@ type FileShareService struct {
 		result1 *models.Share
 		result2 error
 	}
+	FailoverFileShareStub        func(string, *models.ShareFailoverRequest, *zap.Logger) error
+	failoverFileShareMutex       sync.RWMutex
+	failoverFileShareArgsForCall []struct {
+		arg1 string
+		arg2 *models.ShareFailoverRequest
+		arg3 *zap.Logger
+	}
+	failoverFileShareReturns struct {
+		result1 error
+	}
+	failoverFileShareReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetFileShareStub        func(string, *zap.Logger) (*models.Share, error)
 	getFileShareMutex       sync.RWMutex
 	getFileShareArgsForCall []struct {
@@ -95,6 +133,21 @@ type FileShareService struct {
 		result1 *models.Share
 		result2 error
 	}
+	GetFileShareAccessorBindingStub        func(string, string, *zap.Logger) (*models.ShareAccessorBinding, error)
+	getFileShareAccessorBindingMutex       sync.RWMutex
+	getFileShareAccessorBindingArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *zap.Logger
+	}
+	getFileShareAccessorBindingReturns struct {
+		result1 *models.ShareAccessorBinding
+		result2 error
+	}
+	getFileShareAccessorBindingReturnsOnCall map[int]struct {
+		result1 *models.ShareAccessorBinding
+		result2 error
+	}
 	GetFileShareByNameStub        func(string, *zap.Logger) (*models.Share, error)
 	getFileShareByNameMutex       sync.RWMutex
 	getFileShareByNameArgsForCall []struct {
@@ -169,6 +222,22 @@ type FileShareService struct {
 		result1 *models.ProfileDetails
 		result2 error
 	}
+	ListFileShareAccessorBindingsStub        func(string, int, string, *zap.Logger) (*models.ShareAccessorBindingList, error)
+	listFileShareAccessorBindingsMutex       sync.RWMutex
+	listFileShareAccessorBindingsArgsForCall []struct {
+		arg1 string
+		arg2 int
+		arg3 string
+		arg4 *zap.Logger
+	}
+	listFileShareAccessorBindingsReturns struct {
+		result1 *models.ShareAccessorBindingList
+		result2 error
+	}
+	listFileShareAccessorBindingsReturnsOnCall map[int]struct {
+		result1 *models.ShareAccessorBindingList
+		result2 error
+	}
 	ListFileShareTargetsStub        func(string, *models.ListShareTargetFilters, *zap.Logger) (*models.ShareTargetList, error)
 	listFileShareTargetsMutex       sync.RWMutex
 	listFileShareTargetsArgsForCall []struct {
@@ -216,6 +285,21 @@ type FileShareService struct {
 		result1 *models.SecurityGroupList
 		result2 error
 	}
+	ListShareProfilesStub        func(int, string, *zap.Logger) (*models.ProfileList, error)
+	listShareProfilesMutex       sync.RWMutex
+	listShareProfilesArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 *zap.Logger
+	}
+	listShareProfilesReturns struct {
+		result1 *models.ProfileList
+		result2 error
+	}
+	listShareProfilesReturnsOnCall map[int]struct {
+		result1 *models.ProfileList
+		result2 error
+	}
 	ListSubnetsStub        func(int, string, *models.ListSubnetFilters, *zap.Logger) (*models.SubnetList, error)
 	listSubnetsMutex       sync.RWMutex
 	listSubnetsArgsForCall []struct {
@@ -454,6 +538,131 @@ func (fake *FileShareService) DeleteFileShareReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FileShareService) DeleteFileShareAccessorBinding(arg1 string, arg2 string, arg3 *zap.Logger) error {
+	fake.deleteFileShareAccessorBindingMutex.Lock()
+	ret, specificReturn := fake.deleteFileShareAccessorBindingReturnsOnCall[len(fake.deleteFileShareAccessorBindingArgsForCall)]
+	fake.deleteFileShareAccessorBindingArgsForCall = append(fake.deleteFileShareAccessorBindingArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *zap.Logger
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteFileShareAccessorBindingStub
+	fakeReturns := fake.deleteFileShareAccessorBindingReturns
+	fake.recordInvocation("DeleteFileShareAccessorBinding", []interface{}{arg1, arg2, arg3})
+	fake.deleteFileShareAccessorBindingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FileShareService) DeleteFileShareAccessorBindingCallCount() int {
+	fake.deleteFileShareAccessorBindingMutex.RLock()
+	defer fake.deleteFileShareAccessorBindingMutex.RUnlock()
+	return len(fake.deleteFileShareAccessorBindingArgsForCall)
+}
+
+func (fake *FileShareService) DeleteFileShareAccessorBindingCalls(stub func(string, string, *zap.Logger) error) {
+	fake.deleteFileShareAccessorBindingMutex.Lock()
+	defer fake.deleteFileShareAccessorBindingMutex.Unlock()
+	fake.DeleteFileShareAccessorBindingStub = stub
+}
+
+func (fake *FileShareService) DeleteFileShareAccessorBindingArgsForCall(i int) (string, string, *zap.Logger) {
+	fake.deleteFileShareAccessorBindingMutex.RLock()
+	defer fake.deleteFileShareAccessorBindingMutex.RUnlock()
+	argsForCall := fake.deleteFileShareAccessorBindingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FileShareService) DeleteFileShareAccessorBindingReturns(result1 error) {
+	fake.deleteFileShareAccessorBindingMutex.Lock()
+	defer fake.deleteFileShareAccessorBindingMutex.Unlock()
+	fake.DeleteFileShareAccessorBindingStub = nil
+	fake.deleteFileShareAccessorBindingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FileShareService) DeleteFileShareAccessorBindingReturnsOnCall(i int, result1 error) {
+	fake.deleteFileShareAccessorBindingMutex.Lock()
+	defer fake.deleteFileShareAccessorBindingMutex.Unlock()
+	fake.DeleteFileShareAccessorBindingStub = nil
+	if fake.deleteFileShareAccessorBindingReturnsOnCall == nil {
+		fake.deleteFileShareAccessorBindingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteFileShareAccessorBindingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FileShareService) DeleteFileShareSource(arg1 string, arg2 *zap.Logger) error {
+	fake.deleteFileShareSourceMutex.Lock()
+	ret, specificReturn := fake.deleteFileShareSourceReturnsOnCall[len(fake.deleteFileShareSourceArgsForCall)]
+	fake.deleteFileShareSourceArgsForCall = append(fake.deleteFileShareSourceArgsForCall, struct {
+		arg1 string
+		arg2 *zap.Logger
+	}{arg1, arg2})
+	stub := fake.DeleteFileShareSourceStub
+	fakeReturns := fake.deleteFileShareSourceReturns
+	fake.recordInvocation("DeleteFileShareSource", []interface{}{arg1, arg2})
+	fake.deleteFileShareSourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FileShareService) DeleteFileShareSourceCallCount() int {
+	fake.deleteFileShareSourceMutex.RLock()
+	defer fake.deleteFileShareSourceMutex.RUnlock()
+	return len(fake.deleteFileShareSourceArgsForCall)
+}
+
+func (fake *FileShareService) DeleteFileShareSourceCalls(stub func(string, *zap.Logger) error) {
+	fake.deleteFileShareSourceMutex.Lock()
+	defer fake.deleteFileShareSourceMutex.Unlock()
+	fake.DeleteFileShareSourceStub = stub
+}
+
+func (fake *FileShareService) DeleteFileShareSourceArgsForCall(i int) (string, *zap.Logger) {
+	fake.deleteFileShareSourceMutex.RLock()
+	defer fake.deleteFileShareSourceMutex.RUnlock()
+	argsForCall := fake.deleteFileShareSourceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FileShareService) DeleteFileShareSourceReturns(result1 error) {
+	fake.deleteFileShareSourceMutex.Lock()
+	defer fake.deleteFileShareSourceMutex.Unlock()
+	fake.DeleteFileShareSourceStub = nil
+	fake.deleteFileShareSourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FileShareService) DeleteFileShareSourceReturnsOnCall(i int, result1 error) {
+	fake.deleteFileShareSourceMutex.Lock()
+	defer fake.deleteFileShareSourceMutex.Unlock()
+	fake.DeleteFileShareSourceStub = nil
+	if fake.deleteFileShareSourceReturnsOnCall == nil {
+		fake.deleteFileShareSourceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteFileShareSourceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FileShareService) DeleteFileShareTarget(arg1 *models.ShareTarget, arg2 *zap.Logger) (*http.Response, error) {
 	fake.deleteFileShareTargetMutex.Lock()
 	ret, specificReturn := fake.deleteFileShareTargetReturnsOnCall[len(fake.deleteFileShareTargetArgsForCall)]
@@ -585,6 +794,69 @@ func (fake *FileShareService) ExpandVolumeReturnsOnCall(i int, result1 *models.S
 	}{result1, result2}
 }
 
+func (fake *FileShareService) FailoverFileShare(arg1 string, arg2 *models.ShareFailoverRequest, arg3 *zap.Logger) error {
+	fake.failoverFileShareMutex.Lock()
+	ret, specificReturn := fake.failoverFileShareReturnsOnCall[len(fake.failoverFileShareArgsForCall)]
+	fake.failoverFileShareArgsForCall = append(fake.failoverFileShareArgsForCall, struct {
+		arg1 string
+		arg2 *models.ShareFailoverRequest
+		arg3 *zap.Logger
+	}{arg1, arg2, arg3})
+	stub := fake.FailoverFileShareStub
+	fakeReturns := fake.failoverFileShareReturns
+	fake.recordInvocation("FailoverFileShare", []interface{}{arg1, arg2, arg3})
+	fake.failoverFileShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FileShareService) FailoverFileShareCallCount() int {
+	fake.failoverFileShareMutex.RLock()
+	defer fake.failoverFileShareMutex.RUnlock()
+	return len(fake.failoverFileShareArgsForCall)
+}
+
+func (fake *FileShareService) FailoverFileShareCalls(stub func(string, *models.ShareFailoverRequest, *zap.Logger) error) {
+	fake.failoverFileShareMutex.Lock()
+	defer fake.failoverFileShareMutex.Unlock()
+	fake.FailoverFileShareStub = stub
+}
+
+func (fake *FileShareService) FailoverFileShareArgsForCall(i int) (string, *models.ShareFailoverRequest, *zap.Logger) {
+	fake.failoverFileShareMutex.RLock()
+	defer fake.failoverFileShareMutex.RUnlock()
+	argsForCall := fake.failoverFileShareArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FileShareService) FailoverFileShareReturns(result1 error) {
+	fake.failoverFileShareMutex.Lock()
+	defer fake.failoverFileShareMutex.Unlock()
+	fake.FailoverFileShareStub = nil
+	fake.failoverFileShareReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FileShareService) FailoverFileShareReturnsOnCall(i int, result1 error) {
+	fake.failoverFileShareMutex.Lock()
+	defer fake.failoverFileShareMutex.Unlock()
+	fake.FailoverFileShareStub = nil
+	if fake.failoverFileShareReturnsOnCall == nil {
+		fake.failoverFileShareReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.failoverFileShareReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FileShareService) GetFileShare(arg1 string, arg2 *zap.Logger) (*models.Share, error) {
 	fake.getFileShareMutex.Lock()
 	ret, specificReturn := fake.getFileShareReturnsOnCall[len(fake.getFileShareArgsForCall)]
@@ -650,6 +922,72 @@ func (fake *FileShareService) GetFileShareReturnsOnCall(i int, result1 *models.S
 	}{result1, result2}
 }
 
+func (fake *FileShareService) GetFileShareAccessorBinding(arg1 string, arg2 string, arg3 *zap.Logger) (*models.ShareAccessorBinding, error) {
+	fake.getFileShareAccessorBindingMutex.Lock()
+	ret, specificReturn := fake.getFileShareAccessorBindingReturnsOnCall[len(fake.getFileShareAccessorBindingArgsForCall)]
+	fake.getFileShareAccessorBindingArgsForCall = append(fake.getFileShareAccessorBindingArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *zap.Logger
+	}{arg1, arg2, arg3})
+	stub := fake.GetFileShareAccessorBindingStub
+	fakeReturns := fake.getFileShareAccessorBindingReturns
+	fake.recordInvocation("GetFileShareAccessorBinding", []interface{}{arg1, arg2, arg3})
+	fake.getFileShareAccessorBindingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FileShareService) GetFileShareAccessorBindingCallCount() int {
+	fake.getFileShareAccessorBindingMutex.RLock()
+	defer fake.getFileShareAccessorBindingMutex.RUnlock()
+	return len(fake.getFileShareAccessorBindingArgsForCall)
+}
+
+func (fake *FileShareService) GetFileShareAccessorBindingCalls(stub func(string, string, *zap.Logger) (*models.ShareAccessorBinding, error)) {
+	fake.getFileShareAccessorBindingMutex.Lock()
+	defer fake.getFileShareAccessorBindingMutex.Unlock()
+	fake.GetFileShareAccessorBindingStub = stub
+}
+
+func (fake *FileShareService) GetFileShareAccessorBindingArgsForCall(i int) (string, string, *zap.Logger) {
+	fake.getFileShareAccessorBindingMutex.RLock()
+	defer fake.getFileShareAccessorBindingMutex.RUnlock()
+	argsForCall := fake.getFileShareAccessorBindingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FileShareService) GetFileShareAccessorBindingReturns(result1 *models.ShareAccessorBinding, result2 error) {
+	fake.getFileShareAccessorBindingMutex.Lock()
+	defer fake.getFileShareAccessorBindingMutex.Unlock()
+	fake.GetFileShareAccessorBindingStub = nil
+	fake.getFileShareAccessorBindingReturns = struct {
+		result1 *models.ShareAccessorBinding
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FileShareService) GetFileShareAccessorBindingReturnsOnCall(i int, result1 *models.ShareAccessorBinding, result2 error) {
+	fake.getFileShareAccessorBindingMutex.Lock()
+	defer fake.getFileShareAccessorBindingMutex.Unlock()
+	fake.GetFileShareAccessorBindingStub = nil
+	if fake.getFileShareAccessorBindingReturnsOnCall == nil {
+		fake.getFileShareAccessorBindingReturnsOnCall = make(map[int]struct {
+			result1 *models.ShareAccessorBinding
+			result2 error
+		})
+	}
+	fake.getFileShareAccessorBindingReturnsOnCall[i] = struct {
+		result1 *models.ShareAccessorBinding
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FileShareService) GetFileShareByName(arg1 string, arg2 *zap.Logger) (*models.Share, error) {
 	fake.getFileShareByNameMutex.Lock()
 	ret, specificReturn := fake.getFileShareByNameReturnsOnCall[len(fake.getFileShareByNameArgsForCall)]
@@ -980,6 +1318,73 @@ func (fake *FileShareService) GetShareProfileReturnsOnCall(i int, result1 *model
 	}{result1, result2}
 }
 
+func (fake *FileShareService) ListFileShareAccessorBindings(arg1 string, arg2 int, arg3 string, arg4 *zap.Logger) (*models.ShareAccessorBindingList, error) {
+	fake.listFileShareAccessorBindingsMutex.Lock()
+	ret, specificReturn := fake.listFileShareAccessorBindingsReturnsOnCall[len(fake.listFileShareAccessorBindingsArgsForCall)]
+	fake.listFileShareAccessorBindingsArgsForCall = append(fake.listFileShareAccessorBindingsArgsForCall, struct {
+		arg1 string
+		arg2 int
+		arg3 string
+		arg4 *zap.Logger
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ListFileShareAccessorBindingsStub
+	fakeReturns := fake.listFileShareAccessorBindingsReturns
+	fake.recordInvocation("ListFileShareAccessorBindings", []interface{}{arg1, arg2, arg3, arg4})
+	fake.listFileShareAccessorBindingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FileShareService) ListFileShareAccessorBindingsCallCount() int {
+	fake.listFileShareAccessorBindingsMutex.RLock()
+	defer fake.listFileShareAccessorBindingsMutex.RUnlock()
+	return len(fake.listFileShareAccessorBindingsArgsForCall)
+}
+
+func (fake *FileShareService) ListFileShareAccessorBindingsCalls(stub func(string, int, string, *zap.Logger) (*models.ShareAccessorBindingList, error)) {
+	fake.listFileShareAccessorBindingsMutex.Lock()
+	defer fake.listFileShareAccessorBindingsMutex.Unlock()
+	fake.ListFileShareAccessorBindingsStub = stub
+}
+
+func (fake *FileShareService) ListFileShareAccessorBindingsArgsForCall(i int) (string, int, string, *zap.Logger) {
+	fake.listFileShareAccessorBindingsMutex.RLock()
+	defer fake.listFileShareAccessorBindingsMutex.RUnlock()
+	argsForCall := fake.listFileShareAccessorBindingsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FileShareService) ListFileShareAccessorBindingsReturns(result1 *models.ShareAccessorBindingList, result2 error) {
+	fake.listFileShareAccessorBindingsMutex.Lock()
+	defer fake.listFileShareAccessorBindingsMutex.Unlock()
+	fake.ListFileShareAccessorBindingsStub = nil
+	fake.listFileShareAccessorBindingsReturns = struct {
+		result1 *models.ShareAccessorBindingList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FileShareService) ListFileShareAccessorBindingsReturnsOnCall(i int, result1 *models.ShareAccessorBindingList, result2 error) {
+	fake.listFileShareAccessorBindingsMutex.Lock()
+	defer fake.listFileShareAccessorBindingsMutex.Unlock()
+	fake.ListFileShareAccessorBindingsStub = nil
+	if fake.listFileShareAccessorBindingsReturnsOnCall == nil {
+		fake.listFileShareAccessorBindingsReturnsOnCall = make(map[int]struct {
+			result1 *models.ShareAccessorBindingList
+			result2 error
+		})
+	}
+	fake.listFileShareAccessorBindingsReturnsOnCall[i] = struct {
+		result1 *models.ShareAccessorBindingList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FileShareService) ListFileShareTargets(arg1 string, arg2 *models.ListShareTargetFilters, arg3 *zap.Logger) (*models.ShareTargetList, error) {
 	fake.listFileShareTargetsMutex.Lock()
 	ret, specificReturn := fake.listFileShareTargetsReturnsOnCall[len(fake.listFileShareTargetsArgsForCall)]
@@ -1180,6 +1585,72 @@ func (fake *FileShareService) ListSecurityGroupsReturnsOnCall(i int, result1 *mo
 	}{result1, result2}
 }
 
+func (fake *FileShareService) ListShareProfiles(arg1 int, arg2 string, arg3 *zap.Logger) (*models.ProfileList, error) {
+	fake.listShareProfilesMutex.Lock()
+	ret, specificReturn := fake.listShareProfilesReturnsOnCall[len(fake.listShareProfilesArgsForCall)]
+	fake.listShareProfilesArgsForCall = append(fake.listShareProfilesArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 *zap.Logger
+	}{arg1, arg2, arg3})
+	stub := fake.ListShareProfilesStub
+	fakeReturns := fake.listShareProfilesReturns
+	fake.recordInvocation("ListShareProfiles", []interface{}{arg1, arg2, arg3})
+	fake.listShareProfilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FileShareService) ListShareProfilesCallCount() int {
+	fake.listShareProfilesMutex.RLock()
+	defer fake.listShareProfilesMutex.RUnlock()
+	return len(fake.listShareProfilesArgsForCall)
+}
+
+func (fake *FileShareService) ListShareProfilesCalls(stub func(int, string, *zap.Logger) (*models.ProfileList, error)) {
+	fake.listShareProfilesMutex.Lock()
+	defer fake.listShareProfilesMutex.Unlock()
+	fake.ListShareProfilesStub = stub
+}
+
+func (fake *FileShareService) ListShareProfilesArgsForCall(i int) (int, string, *zap.Logger) {
+	fake.listShareProfilesMutex.RLock()
+	defer fake.listShareProfilesMutex.RUnlock()
+	argsForCall := fake.listShareProfilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FileShareService) ListShareProfilesReturns(result1 *models.ProfileList, result2 error) {
+	fake.listShareProfilesMutex.Lock()
+	defer fake.listShareProfilesMutex.Unlock()
+	fake.ListShareProfilesStub = nil
+	fake.listShareProfilesReturns = struct {
+		result1 *models.ProfileList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FileShareService) ListShareProfilesReturnsOnCall(i int, result1 *models.ProfileList, result2 error) {
+	fake.listShareProfilesMutex.Lock()
+	defer fake.listShareProfilesMutex.Unlock()
+	fake.ListShareProfilesStub = nil
+	if fake.listShareProfilesReturnsOnCall == nil {
+		fake.listShareProfilesReturnsOnCall = make(map[int]struct {
+			result1 *models.ProfileList
+			result2 error
+		})
+	}
+	fake.listShareProfilesReturnsOnCall[i] = struct {
+		result1 *models.ProfileList
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FileShareService) ListSubnets(arg1 int, arg2 string, arg3 *models.ListSubnetFilters, arg4 *zap.Logger) (*models.SubnetList, error) {
 	fake.listSubnetsMutex.Lock()
 	ret, specificReturn := fake.listSubnetsReturnsOnCall[len(fake.listSubnetsArgsForCall)]
@@ -1382,12 +1853,20 @@ func (fake *FileShareService) Invocations() map[string][][]interface{} {
 	defer fake.createFileShareTargetMutex.RUnlock()
 	fake.deleteFileShareMutex.RLock()
 	defer fake.deleteFileShareMutex.RUnlock()
+	fake.deleteFileShareAccessorBindingMutex.RLock()
+	defer fake.deleteFileShareAccessorBindingMutex.RUnlock()
+	fake.deleteFileShareSourceMutex.RLock()
+	defer fake.deleteFileShareSourceMutex.RUnlock()
 	fake.deleteFileShareTargetMutex.RLock()
 	defer fake.deleteFileShareTargetMutex.RUnlock()
 	fake.expandVolumeMutex.RLock()
 	defer fake.expandVolumeMutex.RUnlock()
+	fake.failoverFileShareMutex.RLock()
+	defer fake.failoverFileShareMutex.RUnlock()
 	fake.getFileShareMutex.RLock()
 	defer fake.getFileShareMutex.RUnlock()
+	fake.getFileShareAccessorBindingMutex.RLock()
+	defer fake.getFileShareAccessorBindingMutex.RUnlock()
 	fake.getFileShareByNameMutex.RLock()
 	defer fake.getFileShareByNameMutex.RUnlock()
 	fake.getFileShareEtagMutex.RLock()
@@ -1398,12 +1877,16 @@ func (fake *FileShareService) Invocations() map[string][][]interface{} {
 	defer fake.getFileShareTargetByNameMutex.RUnlock()
 	fake.getShareProfileMutex.RLock()
 	defer fake.getShareProfileMutex.RUnlock()
+	fake.listFileShareAccessorBindingsMutex.RLock()
+	defer fake.listFileShareAccessorBindingsMutex.RUnlock()
 	fake.listFileShareTargetsMutex.RLock()
 	defer fake.listFileShareTargetsMutex.RUnlock()
 	fake.listFileSharesMutex.RLock()
 	defer fake.listFileSharesMutex.RUnlock()
 	fake.listSecurityGroupsMutex.RLock()
 	defer fake.listSecurityGroupsMutex.RUnlock()
+	fake.listShareProfilesMutex.RLock()
+	defer fake.listShareProfilesMutex.RUnlock()
 	fake.listSubnetsMutex.RLock()
 	defer fake.listSubnetsMutex.RUnlock()
 	fake.updateFileShareWithEtagMutex.RLock()
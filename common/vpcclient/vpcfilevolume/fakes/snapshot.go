@@ -68,6 +68,23 @@ type SnapshotManager struct {
 		result1 *models.Snapshot
 		result2 error
 	}
+	GetSnapshotEtagStub        func(string, string, *zap.Logger) (*models.Snapshot, string, error)
+	getSnapshotEtagMutex       sync.RWMutex
+	getSnapshotEtagArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *zap.Logger
+	}
+	getSnapshotEtagReturns struct {
+		result1 *models.Snapshot
+		result2 string
+		result3 error
+	}
+	getSnapshotEtagReturnsOnCall map[int]struct {
+		result1 *models.Snapshot
+		result2 string
+		result3 error
+	}
 	ListSnapshotsStub        func(string, int, string, *models.LisSnapshotFilters, *zap.Logger) (*models.SnapshotList, error)
 	listSnapshotsMutex       sync.RWMutex
 	listSnapshotsArgsForCall []struct {
@@ -85,6 +102,21 @@ type SnapshotManager struct {
 		result1 *models.SnapshotList
 		result2 error
 	}
+	UpdateSnapshotWithEtagStub        func(string, string, string, *models.Snapshot, *zap.Logger) error
+	updateSnapshotWithEtagMutex       sync.RWMutex
+	updateSnapshotWithEtagArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 *models.Snapshot
+		arg5 *zap.Logger
+	}
+	updateSnapshotWithEtagReturns struct {
+		result1 error
+	}
+	updateSnapshotWithEtagReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -350,6 +382,75 @@ func (fake *SnapshotManager) GetSnapshotByNameReturnsOnCall(i int, result1 *mode
 	}{result1, result2}
 }
 
+func (fake *SnapshotManager) GetSnapshotEtag(arg1 string, arg2 string, arg3 *zap.Logger) (*models.Snapshot, string, error) {
+	fake.getSnapshotEtagMutex.Lock()
+	ret, specificReturn := fake.getSnapshotEtagReturnsOnCall[len(fake.getSnapshotEtagArgsForCall)]
+	fake.getSnapshotEtagArgsForCall = append(fake.getSnapshotEtagArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *zap.Logger
+	}{arg1, arg2, arg3})
+	stub := fake.GetSnapshotEtagStub
+	fakeReturns := fake.getSnapshotEtagReturns
+	fake.recordInvocation("GetSnapshotEtag", []interface{}{arg1, arg2, arg3})
+	fake.getSnapshotEtagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *SnapshotManager) GetSnapshotEtagCallCount() int {
+	fake.getSnapshotEtagMutex.RLock()
+	defer fake.getSnapshotEtagMutex.RUnlock()
+	return len(fake.getSnapshotEtagArgsForCall)
+}
+
+func (fake *SnapshotManager) GetSnapshotEtagCalls(stub func(string, string, *zap.Logger) (*models.Snapshot, string, error)) {
+	fake.getSnapshotEtagMutex.Lock()
+	defer fake.getSnapshotEtagMutex.Unlock()
+	fake.GetSnapshotEtagStub = stub
+}
+
+func (fake *SnapshotManager) GetSnapshotEtagArgsForCall(i int) (string, string, *zap.Logger) {
+	fake.getSnapshotEtagMutex.RLock()
+	defer fake.getSnapshotEtagMutex.RUnlock()
+	argsForCall := fake.getSnapshotEtagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *SnapshotManager) GetSnapshotEtagReturns(result1 *models.Snapshot, result2 string, result3 error) {
+	fake.getSnapshotEtagMutex.Lock()
+	defer fake.getSnapshotEtagMutex.Unlock()
+	fake.GetSnapshotEtagStub = nil
+	fake.getSnapshotEtagReturns = struct {
+		result1 *models.Snapshot
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *SnapshotManager) GetSnapshotEtagReturnsOnCall(i int, result1 *models.Snapshot, result2 string, result3 error) {
+	fake.getSnapshotEtagMutex.Lock()
+	defer fake.getSnapshotEtagMutex.Unlock()
+	fake.GetSnapshotEtagStub = nil
+	if fake.getSnapshotEtagReturnsOnCall == nil {
+		fake.getSnapshotEtagReturnsOnCall = make(map[int]struct {
+			result1 *models.Snapshot
+			result2 string
+			result3 error
+		})
+	}
+	fake.getSnapshotEtagReturnsOnCall[i] = struct {
+		result1 *models.Snapshot
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *SnapshotManager) ListSnapshots(arg1 string, arg2 int, arg3 string, arg4 *models.LisSnapshotFilters, arg5 *zap.Logger) (*models.SnapshotList, error) {
 	fake.listSnapshotsMutex.Lock()
 	ret, specificReturn := fake.listSnapshotsReturnsOnCall[len(fake.listSnapshotsArgsForCall)]
@@ -418,6 +519,71 @@ func (fake *SnapshotManager) ListSnapshotsReturnsOnCall(i int, result1 *models.S
 	}{result1, result2}
 }
 
+func (fake *SnapshotManager) UpdateSnapshotWithEtag(arg1 string, arg2 string, arg3 string, arg4 *models.Snapshot, arg5 *zap.Logger) error {
+	fake.updateSnapshotWithEtagMutex.Lock()
+	ret, specificReturn := fake.updateSnapshotWithEtagReturnsOnCall[len(fake.updateSnapshotWithEtagArgsForCall)]
+	fake.updateSnapshotWithEtagArgsForCall = append(fake.updateSnapshotWithEtagArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 *models.Snapshot
+		arg5 *zap.Logger
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.UpdateSnapshotWithEtagStub
+	fakeReturns := fake.updateSnapshotWithEtagReturns
+	fake.recordInvocation("UpdateSnapshotWithEtag", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.updateSnapshotWithEtagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SnapshotManager) UpdateSnapshotWithEtagCallCount() int {
+	fake.updateSnapshotWithEtagMutex.RLock()
+	defer fake.updateSnapshotWithEtagMutex.RUnlock()
+	return len(fake.updateSnapshotWithEtagArgsForCall)
+}
+
+func (fake *SnapshotManager) UpdateSnapshotWithEtagCalls(stub func(string, string, string, *models.Snapshot, *zap.Logger) error) {
+	fake.updateSnapshotWithEtagMutex.Lock()
+	defer fake.updateSnapshotWithEtagMutex.Unlock()
+	fake.UpdateSnapshotWithEtagStub = stub
+}
+
+func (fake *SnapshotManager) UpdateSnapshotWithEtagArgsForCall(i int) (string, string, string, *models.Snapshot, *zap.Logger) {
+	fake.updateSnapshotWithEtagMutex.RLock()
+	defer fake.updateSnapshotWithEtagMutex.RUnlock()
+	argsForCall := fake.updateSnapshotWithEtagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *SnapshotManager) UpdateSnapshotWithEtagReturns(result1 error) {
+	fake.updateSnapshotWithEtagMutex.Lock()
+	defer fake.updateSnapshotWithEtagMutex.Unlock()
+	fake.UpdateSnapshotWithEtagStub = nil
+	fake.updateSnapshotWithEtagReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SnapshotManager) UpdateSnapshotWithEtagReturnsOnCall(i int, result1 error) {
+	fake.updateSnapshotWithEtagMutex.Lock()
+	defer fake.updateSnapshotWithEtagMutex.Unlock()
+	fake.UpdateSnapshotWithEtagStub = nil
+	if fake.updateSnapshotWithEtagReturnsOnCall == nil {
+		fake.updateSnapshotWithEtagReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateSnapshotWithEtagReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *SnapshotManager) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -429,8 +595,12 @@ func (fake *SnapshotManager) Invocations() map[string][][]interface{} {
 	defer fake.getSnapshotMutex.RUnlock()
 	fake.getSnapshotByNameMutex.RLock()
 	defer fake.getSnapshotByNameMutex.RUnlock()
+	fake.getSnapshotEtagMutex.RLock()
+	defer fake.getSnapshotEtagMutex.RUnlock()
 	fake.listSnapshotsMutex.RLock()
 	defer fake.listSnapshotsMutex.RUnlock()
+	fake.updateSnapshotWithEtagMutex.RLock()
+	defer fake.updateSnapshotWithEtagMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -34,6 +34,9 @@ type FileShareManager interface {
 	// Get the share profile by using profile name
 	GetShareProfile(profileName string, ctxLogger *zap.Logger) (*models.ProfileDetails, error)
 
+	// Get all share profiles of the region
+	ListShareProfiles(limit int, start string, ctxLogger *zap.Logger) (*models.ProfileList, error)
+
 	// Create the file share with authorisation by passing required information in the share object
 	CreateFileShare(volumeTemplate *models.Share, ctxLogger *zap.Logger) (*models.Share, error)
 
@@ -52,12 +55,27 @@ type FileShareManager interface {
 	// Get the file share etag by using ID
 	GetFileShareEtag(shareID string, ctxLogger *zap.Logger) (*models.Share, string, error)
 
-	// UpdateFileShareWithEtag updates the shares with tags by passing etag in header
+	// UpdateFileShareWithEtag updates the share fields set in the template by passing etag in header
 	UpdateFileShareWithEtag(shareID string, etag string, shareTemplate *models.Share, ctxLogger *zap.Logger) error
 
 	// Delete the file share
 	DeleteFileShare(shareID string, ctxLogger *zap.Logger) error
 
+	// FailoverFileShare makes the replica share take over from its source share
+	FailoverFileShare(shareID string, failoverRequest *models.ShareFailoverRequest, ctxLogger *zap.Logger) error
+
+	// DeleteFileShareSource splits the replica share from its source share, making it an independent share
+	DeleteFileShareSource(shareID string, ctxLogger *zap.Logger) error
+
+	// ListFileShareAccessorBindings lists the accessor bindings of the origin share
+	ListFileShareAccessorBindings(shareID string, limit int, start string, ctxLogger *zap.Logger) (*models.ShareAccessorBindingList, error)
+
+	// GetFileShareAccessorBinding gets the accessor binding of the origin share by using binding ID
+	GetFileShareAccessorBinding(shareID string, bindingID string, ctxLogger *zap.Logger) (*models.ShareAccessorBinding, error)
+
+	// DeleteFileShareAccessorBinding revokes the access of an accessor share to the origin share
+	DeleteFileShareAccessorBinding(shareID string, bindingID string, ctxLogger *zap.Logger) error
+
 	//CreateFileShareTarget creates file share target
 	CreateFileShareTarget(shareTargetRequest *models.ShareTarget, ctxLogger *zap.Logger) (*models.ShareTarget, error)
 
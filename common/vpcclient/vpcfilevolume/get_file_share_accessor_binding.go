@@ -0,0 +1,56 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package vpcfilevolume ...
+// Package vpcfilevolume ...
+package vpcfilevolume
+
+import (
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"go.uber.org/zap"
+)
+
+// GetFileShareAccessorBinding GETs from /shares/{share-id}/accessor_bindings/{binding-id}
+func (vs *FileShareService) GetFileShareAccessorBinding(shareID string, bindingID string, ctxLogger *zap.Logger) (*models.ShareAccessorBinding, error) {
+	ctxLogger.Debug("Entry Backend GetFileShareAccessorBinding")
+	defer ctxLogger.Debug("Exit Backend GetFileShareAccessorBinding")
+
+	defer util.TimeTracker("GetFileShareAccessorBinding", time.Now())
+
+	operation := &client.Operation{
+		Name:        "GetFileShareAccessorBinding",
+		Method:      "GET",
+		PathPattern: bindingIDPath,
+	}
+
+	var binding models.ShareAccessorBinding
+	var apiErr models.Error
+
+	request := vs.client.NewRequest(operation).PathParameter(shareIDParam, shareID)
+	ctxLogger.Info("Equivalent curl command", zap.Reflect("URL", request.URL()), zap.Reflect("Operation", operation))
+
+	req := request.PathParameter(bindingIDParam, bindingID)
+
+	_, err := req.JSONSuccess(&binding).JSONError(&apiErr).Invoke()
+	if err != nil {
+		return nil, err
+	}
+
+	return &binding, nil
+}
@@ -0,0 +1,55 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcfilevolume ...
+package vpcfilevolume
+
+import (
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"go.uber.org/zap"
+)
+
+// GetSnapshotEtag GETs from /shares/{share_id}/snapshots/{id}, along with the ETag of the snapshot
+func (ss *SnapshotService) GetSnapshotEtag(shareID string, snapshotID string, ctxLogger *zap.Logger) (*models.Snapshot, string, error) {
+	ctxLogger.Debug("Entry Backend GetSnapshotEtag")
+	defer ctxLogger.Debug("Exit Backend GetSnapshotEtag")
+
+	defer util.TimeTracker("GetSnapshotEtag", time.Now())
+
+	operation := &client.Operation{
+		Name:        "GetSnapshotEtag",
+		Method:      "GET",
+		PathPattern: snapshotIDPath,
+	}
+
+	var snapshot models.Snapshot
+	var apiErr models.Error
+
+	request := ss.client.NewRequest(operation).PathParameter(shareIDParam, shareID)
+	ctxLogger.Info("Equivalent curl command", zap.Reflect("URL", request.URL()), zap.Reflect("Operation", operation))
+
+	req := request.PathParameter(snapshotIDParam, snapshotID)
+	resp, err := req.JSONSuccess(&snapshot).JSONError(&apiErr).Invoke()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &snapshot, resp.Header.Get("etag"), nil
+}
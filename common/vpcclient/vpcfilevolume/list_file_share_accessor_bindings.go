@@ -0,0 +1,65 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package vpcfilevolume ...
+// Package vpcfilevolume ...
+package vpcfilevolume
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"go.uber.org/zap"
+)
+
+// ListFileShareAccessorBindings GETs /shares/{share-id}/accessor_bindings
+func (vs *FileShareService) ListFileShareAccessorBindings(shareID string, limit int, start string, ctxLogger *zap.Logger) (*models.ShareAccessorBindingList, error) {
+	ctxLogger.Debug("Entry Backend ListFileShareAccessorBindings")
+	defer ctxLogger.Debug("Exit Backend ListFileShareAccessorBindings")
+
+	defer util.TimeTracker("ListFileShareAccessorBindings", time.Now())
+
+	operation := &client.Operation{
+		Name:        "ListFileShareAccessorBindings",
+		Method:      "GET",
+		PathPattern: bindingsPath,
+	}
+
+	var bindings models.ShareAccessorBindingList
+	var apiErr models.Error
+
+	request := vs.client.NewRequest(operation).PathParameter(shareIDParam, shareID)
+	ctxLogger.Info("Equivalent curl command", zap.Reflect("URL", request.URL()), zap.Reflect("Operation", operation))
+
+	req := request.JSONSuccess(&bindings).JSONError(&apiErr)
+
+	if limit > 0 {
+		req.AddQueryValue("limit", strconv.Itoa(limit))
+	}
+
+	if start != "" {
+		req.AddQueryValue("start", start)
+	}
+
+	_, err := req.Invoke()
+	if err != nil {
+		return nil, err
+	}
+
+	return &bindings, nil
+}
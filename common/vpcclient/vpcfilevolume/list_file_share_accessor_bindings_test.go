@@ -0,0 +1,110 @@
+/**
+ * Copyright 2021 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package vpcfilevolume_test ...
+package vpcfilevolume_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas/test"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestListFileShareAccessorBindings(t *testing.T) {
+	// Setup new style zap logger
+	logger, _ := GetTestContextLogger()
+	defer logger.Sync()
+
+	testCases := []struct {
+		name string
+
+		// Response
+		status  int
+		content string
+
+		limit int
+		start string
+
+		// Expected return
+		expectErr string
+		verify    func(t *testing.T, bindings *models.ShareAccessorBindingList, err error)
+		muxVerify func(*testing.T, *http.Request)
+	}{
+		{
+			name:   "Verify that the correct endpoint is invoked",
+			status: http.StatusNoContent,
+		}, {
+			name:      "Verify that a 404 is returned to the caller",
+			status:    http.StatusNotFound,
+			content:   "{\"errors\":[{\"message\":\"testerr\",\"Code\":\"shares_not_found\"}], \"trace\":\"2af63776-4df7-4970-b52d-4e25676ec0e4\"}",
+			expectErr: "Trace Code:2af63776-4df7-4970-b52d-4e25676ec0e4, Code:shares_not_found, Description:testerr, RC:404 Not Found",
+		}, {
+			name:   "Verify that limit and start are added to the query",
+			limit:  12,
+			start:  "x-y-z",
+			status: http.StatusNoContent,
+			muxVerify: func(t *testing.T, r *http.Request) {
+				expectedValues := url.Values{"limit": []string{"12"}, "start": []string{"x-y-z"}, "version": []string{models.APIVersion}}
+				assert.Equal(t, expectedValues, r.URL.Query())
+			},
+		}, {
+			name:    "Verify that the accessor bindings are parsed correctly",
+			status:  http.StatusOK,
+			content: "{\"limit\":50,\"accessor_bindings\":[{\"id\":\"binding1\",\"lifecycle_state\":\"stable\",\"accessor\":{\"crn\":\"crn:v1:bluemix:public:is:us-south-1:a/account2::share:share2\",\"remote\":{\"account\":{\"id\":\"account2\"}}}}]}",
+			verify: func(t *testing.T, bindings *models.ShareAccessorBindingList, err error) {
+				if assert.NotNil(t, bindings) && assert.Len(t, bindings.AccessorBindings, 1) {
+					binding := bindings.AccessorBindings[0]
+					assert.Equal(t, "binding1", binding.ID)
+					assert.Equal(t, "stable", binding.GetLifecycleState())
+					assert.Equal(t, "account2", binding.Accessor.Remote.Account.ID)
+				}
+			},
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			emptyString := ""
+			mux, client, teardown := test.SetupServer(t)
+			test.SetupMuxResponse(t, mux, vpcfilevolume.Version+"/shares/share-id/accessor_bindings", http.MethodGet, &emptyString, testcase.status, testcase.content, testcase.muxVerify)
+
+			defer teardown()
+
+			logger.Info("Test case being executed", zap.Reflect("testcase", testcase.name))
+
+			shareService := vpcfilevolume.New(client)
+
+			bindings, err := shareService.ListFileShareAccessorBindings("share-id", testcase.limit, testcase.start, logger)
+
+			if testcase.expectErr != "" && assert.Error(t, err) {
+				assert.Equal(t, testcase.expectErr, err.Error())
+				assert.Nil(t, bindings)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, bindings)
+			}
+
+			if testcase.verify != nil {
+				testcase.verify(t, bindings, err)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@
 package vpcfilevolume
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
@@ -53,6 +54,12 @@ func (vs *FileShareService) ListFileShareTargets(shareID string, filters *models
 		if filters.ShareTargetName != "" {
 			req.AddQueryValue("name", filters.ShareTargetName)
 		}
+		if filters.Limit > 0 {
+			req.AddQueryValue("limit", strconv.Itoa(filters.Limit))
+		}
+		if filters.Start != "" {
+			req.AddQueryValue("start", filters.Start)
+		}
 	}
 
 	_, err := req.Invoke()
@@ -0,0 +1,66 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcfilevolume ...
+package vpcfilevolume
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"go.uber.org/zap"
+)
+
+// ListShareProfiles GETs /shares/profiles
+func (vs *FileShareService) ListShareProfiles(limit int, start string, ctxLogger *zap.Logger) (*models.ProfileList, error) {
+	ctxLogger.Debug("Entry Backend ListShareProfiles")
+	defer ctxLogger.Debug("Exit Backend ListShareProfiles")
+
+	defer util.TimeTracker("ListShareProfiles", time.Now())
+
+	operation := &client.Operation{
+		Name:        "ListShareProfiles",
+		Method:      "GET",
+		PathPattern: shareProfiles,
+	}
+
+	var profiles models.ProfileList
+	var apiErr models.Error
+
+	request := vs.client.NewRequest(operation)
+
+	req := request.JSONSuccess(&profiles).JSONError(&apiErr)
+
+	if limit > 0 {
+		req.AddQueryValue("limit", strconv.Itoa(limit))
+	}
+
+	if start != "" {
+		req.AddQueryValue("start", start)
+	}
+
+	ctxLogger.Info("Equivalent curl command", zap.Reflect("URL", req.URL()))
+
+	_, err := req.Invoke()
+	if err != nil {
+		return nil, err
+	}
+
+	return &profiles, nil
+}
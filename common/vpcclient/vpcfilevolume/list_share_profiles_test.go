@@ -0,0 +1,101 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcvolume_test ...
+package vpcfilevolume_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas/test"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestListShareProfiles(t *testing.T) {
+	// Setup new style zap logger
+	logger, _ := GetTestContextLogger()
+	defer logger.Sync()
+
+	testCases := []struct {
+		name string
+
+		// Response
+		status  int
+		content string
+
+		limit int
+		start string
+
+		// Expected return
+		expectErr string
+		verify    func(*testing.T, *models.ProfileList)
+	}{
+		{
+			name:   "Verify that the correct endpoint is invoked",
+			status: http.StatusNoContent,
+		}, {
+			name:      "Verify that a 404 is returned to the caller",
+			status:    http.StatusNotFound,
+			content:   "{\"errors\":[{\"message\":\"testerr\",\"Code\":\"profile_list_not_found\"}], \"trace\":\"2af63776-4df7-4970-b52d-4e25676ec0e4\"}",
+			expectErr: "Trace Code:2af63776-4df7-4970-b52d-4e25676ec0e4, Code:profile_list_not_found, Description:testerr, RC:404 Not Found",
+		}, {
+			name:    "Verify that the profiles are returned",
+			limit:   2,
+			start:   "dp2",
+			status:  http.StatusOK,
+			content: "{\"profiles\":[{\"name\":\"dp2\",\"capacity\":{\"max\":32000,\"min\":10,\"step\":1,\"type\":\"range\"},\"iops\":{\"type\":\"dependent_range\",\"min\":100,\"max\":96000}},{\"name\":\"custom\",\"iops\":{\"type\":\"enum\",\"values\":[1000,2000]}}],\"limit\":2,\"total_count\":2}",
+			verify: func(t *testing.T, profiles *models.ProfileList) {
+				if assert.Len(t, profiles.Profiles, 2) {
+					assert.Equal(t, "dp2", profiles.Profiles[0].Name)
+					assert.Equal(t, int32(32000), profiles.Profiles[0].Capacity.Max)
+					assert.Equal(t, models.CapIopsEnum, profiles.Profiles[1].Iops.Type)
+					assert.Equal(t, []int32{1000, 2000}, profiles.Profiles[1].Iops.Values)
+				}
+			},
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			mux, client, teardown := test.SetupServer(t)
+			test.SetupMuxResponse(t, mux, vpcfilevolume.Version+"/share/profiles", http.MethodGet, nil, testcase.status, testcase.content, nil)
+
+			defer teardown()
+
+			logger.Info("Test case being executed", zap.Reflect("testcase", testcase.name))
+
+			shareFileService := vpcfilevolume.New(client)
+
+			profiles, err := shareFileService.ListShareProfiles(testcase.limit, testcase.start, logger)
+
+			if testcase.expectErr != "" && assert.Error(t, err) {
+				assert.Equal(t, testcase.expectErr, err.Error())
+				assert.Nil(t, profiles)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, profiles)
+			}
+
+			if testcase.verify != nil {
+				testcase.verify(t, profiles)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcfilevolume ...
+package vpcfilevolume
+
+import (
+	"errors"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/pager"
+	"go.uber.org/zap"
+)
+
+// NewFileSharePager returns a pager over all the file shares matching filters
+func NewFileSharePager(vs FileShareManager, limit int, filters *models.ListShareFilters, ctxLogger *zap.Logger) *pager.Pager[*models.Share] {
+	return pager.New(func(start string) ([]*models.Share, *models.HReference, error) {
+		shares, err := vs.ListFileShares(limit, start, filters, ctxLogger)
+		if err != nil || shares == nil {
+			return nil, nil, err
+		}
+		return shares.Shares, shares.Next, nil
+	})
+}
+
+// NewSnapshotPager returns a pager over all the snapshots of shareID matching filters
+func NewSnapshotPager(ss SnapshotManager, shareID string, limit int, filters *models.LisSnapshotFilters, ctxLogger *zap.Logger) *pager.Pager[*models.Snapshot] {
+	return pager.New(func(start string) ([]*models.Snapshot, *models.HReference, error) {
+		snapshots, err := ss.ListSnapshots(shareID, limit, start, filters, ctxLogger)
+		if err != nil || snapshots == nil {
+			return nil, nil, err
+		}
+		return snapshots.Snapshots, snapshots.Next, nil
+	})
+}
+
+// NewSubnetPager returns a pager over all the subnets matching filters, a missing subnet list being an error
+func NewSubnetPager(vs FileShareManager, limit int, filters *models.ListSubnetFilters, ctxLogger *zap.Logger) *pager.Pager[models.Subnet] {
+	return pager.New(func(start string) ([]models.Subnet, *models.HReference, error) {
+		subnets, err := vs.ListSubnets(limit, start, filters, ctxLogger)
+		if err == nil && subnets == nil {
+			err = errors.New("Subnet list is empty")
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return subnets.Subnets, subnets.Next, nil
+	})
+}
+
+// NewSecurityGroupPager returns a pager over all the security groups matching filters, a missing security group
+// list being an error
+func NewSecurityGroupPager(vs FileShareManager, limit int, filters *models.ListSecurityGroupFilters, ctxLogger *zap.Logger) *pager.Pager[models.SecurityGroup] {
+	return pager.New(func(start string) ([]models.SecurityGroup, *models.HReference, error) {
+		securityGroups, err := vs.ListSecurityGroups(limit, start, filters, ctxLogger)
+		if err == nil && securityGroups == nil {
+			err = errors.New("SecurityGroup list is empty")
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return securityGroups.SecurityGroups, securityGroups.Next, nil
+	})
+}
+
+// NewShareTargetPager returns a pager over all the mount targets of shareID matching filters
+func NewShareTargetPager(vs FileShareManager, shareID string, limit int, filters *models.ListShareTargetFilters, ctxLogger *zap.Logger) *pager.Pager[*models.ShareTarget] {
+	return pager.New(func(start string) ([]*models.ShareTarget, *models.HReference, error) {
+		pageFilters := models.ListShareTargetFilters{}
+		if filters != nil {
+			pageFilters = *filters
+		}
+		pageFilters.Limit = limit
+		pageFilters.Start = start
+		targets, err := vs.ListFileShareTargets(shareID, &pageFilters, ctxLogger)
+		if err != nil || targets == nil {
+			return nil, nil, err
+		}
+		return targets.ShareTargets, targets.Next, nil
+	})
+}
+
+// NewShareProfilePager returns a pager over all the share profiles
+func NewShareProfilePager(vs FileShareManager, limit int, ctxLogger *zap.Logger) *pager.Pager[models.ProfileDetails] {
+	return pager.New(func(start string) ([]models.ProfileDetails, *models.HReference, error) {
+		profiles, err := vs.ListShareProfiles(limit, start, ctxLogger)
+		if err != nil || profiles == nil {
+			return nil, nil, err
+		}
+		return profiles.Profiles, profiles.Next, nil
+	})
+}
+
+// NewAccessorBindingPager returns a pager over all the accessor bindings of the origin share shareID
+func NewAccessorBindingPager(vs FileShareManager, shareID string, limit int, ctxLogger *zap.Logger) *pager.Pager[*models.ShareAccessorBinding] {
+	return pager.New(func(start string) ([]*models.ShareAccessorBinding, *models.HReference, error) {
+		bindings, err := vs.ListFileShareAccessorBindings(shareID, limit, start, ctxLogger)
+		if err != nil || bindings == nil {
+			return nil, nil, err
+		}
+		return bindings.AccessorBindings, bindings.Next, nil
+	})
+}
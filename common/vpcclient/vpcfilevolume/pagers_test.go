@@ -0,0 +1,112 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcvolume_test ...
+package vpcfilevolume_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas/test"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
+	"github.com/stretchr/testify/assert"
+)
+
+// servePages answers the list requests on path with the page matching their start query value
+func servePages(t *testing.T, mux *http.ServeMux, path string, pages map[string]string, requested *[]string) {
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		start := r.URL.Query().Get("start")
+		*requested = append(*requested, start)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, pages[start])
+	})
+}
+
+func TestFileSharePager(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+	defer logger.Sync()
+
+	mux, client, teardown := test.SetupServer(t)
+	defer teardown()
+
+	var requested []string
+	servePages(t, mux, vpcfilevolume.Version+"/shares", map[string]string{
+		"":       `{"shares":[{"id":"share1"},{"id":"share2"}],"next":{"href":"https://us-south.iaas.cloud.ibm.com/v1/shares?limit=2&start=r006-2"}}`,
+		"r006-2": `{"shares":[{"id":"share3"}]}`,
+	}, &requested)
+
+	shares, err := vpcfilevolume.NewFileSharePager(vpcfilevolume.New(client), 2, &models.ListShareFilters{ResourceGroupID: "rg"}, logger).All(context.Background())
+	assert.NoError(t, err)
+	var ids []string
+	for _, share := range shares {
+		ids = append(ids, share.ID)
+	}
+	assert.Equal(t, []string{"share1", "share2", "share3"}, ids)
+	assert.Equal(t, []string{"", "r006-2"}, requested)
+}
+
+func TestShareTargetPager(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+	defer logger.Sync()
+
+	mux, client, teardown := test.SetupServer(t)
+	defer teardown()
+
+	var requested []string
+	servePages(t, mux, vpcfilevolume.Version+"/shares/share1/mount_targets", map[string]string{
+		"":       `{"mount_targets":[{"id":"target1"},{"id":"target2"}],"next":{"href":"https://us-south.iaas.cloud.ibm.com/v1/shares/share1/mount_targets?start=r006-2&limit=2"}}`,
+		"r006-2": `{"mount_targets":[{"id":"target3"}]}`,
+	}, &requested)
+
+	var ids []string
+	err := vpcfilevolume.NewShareTargetPager(vpcfilevolume.New(client), "share1", 2, nil, logger).ForEach(context.Background(), func(target *models.ShareTarget) bool {
+		ids = append(ids, target.ID)
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"target1", "target2", "target3"}, ids)
+	assert.Equal(t, []string{"", "r006-2"}, requested)
+}
+
+func TestSubnetPagerStopsEarly(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+	defer logger.Sync()
+
+	mux, client, teardown := test.SetupServer(t)
+	defer teardown()
+
+	var requested []string
+	servePages(t, mux, vpcfilevolume.Version+"/subnets", map[string]string{
+		"":       `{"subnets":[{"id":"subnet1"},{"id":"subnet2"}],"next":{"href":"https://us-south.iaas.cloud.ibm.com/v1/subnets?limit=2&start=r006-2"}}`,
+		"r006-2": `{"subnets":[{"id":"subnet3"}]}`,
+	}, &requested)
+
+	var found string
+	err := vpcfilevolume.NewSubnetPager(vpcfilevolume.New(client), 2, nil, logger).ForEach(context.Background(), func(subnet models.Subnet) bool {
+		found = subnet.ID
+		return subnet.ID != "subnet2"
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "subnet2", found)
+	assert.Equal(t, []string{""}, requested)
+}
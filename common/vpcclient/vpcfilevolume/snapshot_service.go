@@ -41,6 +41,12 @@ type SnapshotManager interface {
 
 	// List all the  snapshots for a given volume
 	ListSnapshots(shareID string, limit int, start string, filters *models.LisSnapshotFilters, ctxLogger *zap.Logger) (*models.SnapshotList, error)
+
+	// Get the snapshot along with its ETag
+	GetSnapshotEtag(shareID string, snapshotID string, ctxLogger *zap.Logger) (*models.Snapshot, string, error)
+
+	// Update the snapshot, guarded by its ETag
+	UpdateSnapshotWithEtag(shareID string, snapshotID string, etag string, snapshotTemplate *models.Snapshot, ctxLogger *zap.Logger) error
 }
 
 // SnapshotService ...
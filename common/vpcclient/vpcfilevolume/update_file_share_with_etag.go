@@ -26,7 +26,7 @@ import (
 	"go.uber.org/zap"
 )
 
-// UpdateVolume PATCH to /shares for updating user tags only
+// UpdateFileShareWithEtag PATCH to /shares for updating the fields set in shareTemplate, guarded by etag
 func (vs *FileShareService) UpdateFileShareWithEtag(shareID string, etag string, shareTemplate *models.Share, ctxLogger *zap.Logger) error {
 	ctxLogger.Debug("Entry Backend UpdateVolumeWithEtag")
 	defer ctxLogger.Debug("Exit Backend UpdateVolumeWithEtag")
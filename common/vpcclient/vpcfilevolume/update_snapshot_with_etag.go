@@ -0,0 +1,56 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package vpcfilevolume ...
+package vpcfilevolume
+
+import (
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"go.uber.org/zap"
+)
+
+// UpdateSnapshotWithEtag PATCH to /shares/{share_id}/snapshots/{id} for updating the fields set in snapshotTemplate, guarded by etag
+func (ss *SnapshotService) UpdateSnapshotWithEtag(shareID string, snapshotID string, etag string, snapshotTemplate *models.Snapshot, ctxLogger *zap.Logger) error {
+	ctxLogger.Debug("Entry Backend UpdateSnapshotWithEtag")
+	defer ctxLogger.Debug("Exit Backend UpdateSnapshotWithEtag")
+
+	defer util.TimeTracker("UpdateSnapshotWithEtag", time.Now())
+
+	operation := &client.Operation{
+		Name:        "UpdateSnapshot",
+		Method:      "PATCH",
+		PathPattern: snapshotIDPath,
+	}
+
+	var apiErr models.Error
+
+	request := ss.client.NewRequest(operation)
+	request.SetHeader("If-Match", etag)
+
+	req := request.PathParameter(shareIDParam, shareID).PathParameter(snapshotIDParam, snapshotID)
+	ctxLogger.Info("Equivalent curl command and payload details", zap.Reflect("URL", req.URL()), zap.Reflect("Payload", snapshotTemplate), zap.Reflect("Operation", operation))
+	_, err := req.JSONBody(snapshotTemplate).JSONError(&apiErr).Invoke()
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	vpcfile "github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// AccessorVolumeRequest is the request for an accessor share, which gives this account access to the data of an
+// origin share, usually of another account. The origin share must be in security group access control mode
+type AccessorVolumeRequest struct {
+	// Name is the name of the accessor share
+	Name string
+	// OriginVolumeCRN is the CRN of the origin share
+	OriginVolumeCRN string
+	// ResourceGroup is the resource group of the accessor share, the default resource group of the account when nil
+	ResourceGroup *provider.ResourceGroup
+}
+
+// CreateAccessorVolume creates an accessor share of the origin share, and waits for it to be stable. Its mount
+// targets are then created with CreateVolumeAccessPoint in security group mode, with a transit encryption the
+// origin share allows
+func (vpcs *VPCSession) CreateAccessorVolume(accessorRequest AccessorVolumeRequest) (*provider.Volume, error) {
+	vpcs.Logger.Debug("Entry of CreateAccessorVolume method...")
+	defer vpcs.Logger.Debug("Exit from CreateAccessorVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "CreateAccessorVolume", time.Now())
+
+	operation, err := vpcs.StartCreateAccessorVolume(accessorRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = vpcs.WaitOperation(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcs.Logger.Info("Accessor volume got valid (stable) state", zap.Reflect("VolumeDetails", operation.Volume))
+	return operation.Volume, nil
+}
+
+// StartCreateAccessorVolume asks for an accessor share of the origin share, and returns the operation to check
+// back on for the accessor share to be stable
+func (vpcs *VPCSession) StartCreateAccessorVolume(accessorRequest AccessorVolumeRequest) (*Operation, error) {
+	vpcs.Logger.Debug("Entry of StartCreateAccessorVolume method...")
+	defer vpcs.Logger.Debug("Exit from StartCreateAccessorVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "StartCreateAccessorVolume", time.Now())
+
+	vpcs.Logger.Info("Basic validation for CreateAccessorVolume request... ", zap.Reflect("AccessorVolumeRequest", accessorRequest))
+	if accessorRequest.Name == "" {
+		return nil, userError.GetUserError("InvalidVolumeName", nil, nil)
+	}
+	if !IsValidCRNFormat(accessorRequest.OriginVolumeCRN) {
+		return nil, userError.GetUserError("InvalidAccessorVolumeRequest", nil, accessorRequest.Name, "the CRN of the origin file share is not valid")
+	}
+
+	// The accessor share gets its profile, size and zone from the origin share
+	shareTemplate := &models.Share{
+		Name:        accessorRequest.Name,
+		OriginShare: &models.ShareReference{CRN: accessorRequest.OriginVolumeCRN},
+	}
+	if accessorRequest.ResourceGroup != nil && (accessorRequest.ResourceGroup.ID != "" || accessorRequest.ResourceGroup.Name != "") {
+		shareTemplate.ResourceGroup = &models.ResourceGroup{ID: accessorRequest.ResourceGroup.ID, Name: accessorRequest.ResourceGroup.Name}
+	}
+
+	vpcs.Logger.Info("Calling VPC provider for accessor volume creation...", zap.Reflect("ShareTemplate", shareTemplate))
+	var volume *models.Share
+	err := vpcs.retry(func() error {
+		var err error
+		volume, err = vpcs.Apiclient.FileShareService().CreateFileShare(shareTemplate, vpcs.Logger)
+		return err
+	})
+	if err != nil {
+		vpcs.Logger.Error("Failed to create accessor volume from VPC provider", zap.Reflect("BackendError", err))
+		return nil, userError.GetUserError("FailedToCreateAccessorVolume", err, accessorRequest.Name, accessorRequest.OriginVolumeCRN)
+	}
+
+	vpcs.Logger.Info("Successfully created accessor volume from VPC provider...", zap.Reflect("VolumeDetails", volume))
+	operation := newOperation(CreateAccessorVolumeOperation, volume.ID, StatusStable)
+	operation.Volume = FromProviderToLibVolume(volume, vpcs.Logger)
+	return operation, nil
+}
+
+// CreateAccessorVolumeWithContext creates an accessor share of the origin share, giving up on the backend calls and
+// the wait for stable state once ctx is done
+func (vpcs *VPCSession) CreateAccessorVolumeWithContext(ctx context.Context, accessorRequest AccessorVolumeRequest) (*provider.Volume, error) {
+	return vpcs.withContext(ctx).CreateAccessorVolume(accessorRequest)
+}
+
+// ListVolumeAccessorBindings lists every accessor binding of the origin file share, following the pages of the
+// collection. Each binding names its accessor share, along with the account of the accessor share when remote
+func (vpcs *VPCSession) ListVolumeAccessorBindings(volumeID string) ([]*models.ShareAccessorBinding, error) {
+	vpcs.Logger.Debug("Entry of ListVolumeAccessorBindings method...")
+	defer vpcs.Logger.Debug("Exit from ListVolumeAccessorBindings method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "ListVolumeAccessorBindings", time.Now())
+
+	if !IsValidVolumeIDFormat(volumeID) {
+		return nil, userError.GetUserError("InvalidVolumeID", nil, volumeID)
+	}
+
+	bindingPager := vpcfile.NewAccessorBindingPager(vpcs.Apiclient.FileShareService(), volumeID, maxLimit, vpcs.Logger).WithRetry(vpcs.retry)
+
+	bindings, err := bindingPager.All(vpcs.requestContext())
+	if err != nil {
+		return nil, userError.GetUserError("ListAccessorBindingsFailed", err, volumeID)
+	}
+
+	vpcs.Logger.Info("Successfully retrieved accessor bindings", zap.Reflect("VolumeID", volumeID), zap.Int("count", len(bindings)))
+	return bindings, nil
+}
+
+// ListVolumeAccessorBindingsWithContext lists the accessor bindings of the origin file share, giving up on the backend calls once ctx is done
+func (vpcs *VPCSession) ListVolumeAccessorBindingsWithContext(ctx context.Context, volumeID string) ([]*models.ShareAccessorBinding, error) {
+	return vpcs.withContext(ctx).ListVolumeAccessorBindings(volumeID)
+}
+
+// DeleteVolumeAccessorBinding revokes the access of an accessor share to the origin file share, and waits for the
+// binding to be gone. The accessor share goes along with it. A binding which is already gone is not an error
+func (vpcs *VPCSession) DeleteVolumeAccessorBinding(volumeID string, bindingID string) error {
+	vpcs.Logger.Debug("Entry of DeleteVolumeAccessorBinding method...")
+	defer vpcs.Logger.Debug("Exit from DeleteVolumeAccessorBinding method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "DeleteVolumeAccessorBinding", time.Now())
+
+	if !IsValidVolumeIDFormat(volumeID) {
+		return userError.GetUserError("InvalidVolumeID", nil, volumeID)
+	}
+	if bindingID == "" {
+		return userError.GetUserError("ErrorRequiredFieldMissing", nil, "bindingID")
+	}
+
+	vpcs.Logger.Info("Deleting accessor binding from VPC provider...", zap.Reflect("VolumeID", volumeID), zap.Reflect("BindingID", bindingID))
+	err := vpcs.flexyRetry(func() (error, bool) {
+		err := vpcs.Apiclient.FileShareService().DeleteFileShareAccessorBinding(volumeID, bindingID, vpcs.Logger)
+		if err != nil {
+			return err, vpcs.GetRetryPolicy().SkipRetry(err)
+		}
+		return nil, true
+	})
+	if hasErrorCode(err, AccessorBindingNotFound) {
+		vpcs.Logger.Warn("Accessor binding does not exist returning success", zap.Reflect("err", err))
+		return nil
+	}
+	if err != nil {
+		return userError.GetUserError("FailedToDeleteAccessorBinding", err, bindingID, volumeID)
+	}
+
+	err = WaitForAccessorBindingDeletion(vpcs, volumeID, bindingID)
+	if err != nil {
+		return userError.GetUserError("FailedToDeleteAccessorBinding", err, bindingID, volumeID)
+	}
+	vpcs.Logger.Info("Successfully deleted the accessor binding")
+	return nil
+}
+
+// DeleteVolumeAccessorBindingWithContext deletes the accessor binding, giving up on the backend calls and the wait
+// for deletion once ctx is done
+func (vpcs *VPCSession) DeleteVolumeAccessorBindingWithContext(ctx context.Context, volumeID string, bindingID string) error {
+	return vpcs.withContext(ctx).DeleteVolumeAccessorBinding(volumeID, bindingID)
+}
+
+// WaitForAccessorBindingDeletion waits for the accessor binding to be gone. A deletion still in progress once the
+// wait times out is not an error, the backend completes it on its own
+func WaitForAccessorBindingDeletion(vpcs *VPCSession, volumeID string, bindingID string) error {
+	vpcs.Logger.Debug("Entry of WaitForAccessorBindingDeletion method...")
+	defer vpcs.Logger.Debug("Exit from WaitForAccessorBindingDeletion method...")
+
+	_, err := pollLifecycle(vpcs, LifecycleWait[*models.ShareAccessorBinding]{
+		Operation: WaitForAccessorBindingDeletionOp,
+		ID:        bindingID,
+		Get: func() (*models.ShareAccessorBinding, error) {
+			return vpcs.Apiclient.FileShareService().GetFileShareAccessorBinding(volumeID, bindingID, vpcs.Logger)
+		},
+	})
+
+	var timeoutErr *PollTimeoutError
+	if errors.As(err, &timeoutErr) {
+		vpcs.Logger.Warn("Accessor binding is still being deleted", zap.Reflect("bindingID", bindingID), zap.Error(err))
+		return nil
+	}
+	if err == nil {
+		vpcs.Logger.Info("Accessor binding got deleted.", zap.Reflect("bindingID", bindingID))
+	}
+	return err
+}
+
+// WaitForAccessorBindingDeletionWithContext is WaitForAccessorBindingDeletion which stops polling once ctx is done
+func WaitForAccessorBindingDeletionWithContext(ctx context.Context, vpcs *VPCSession, volumeID string, bindingID string) error {
+	return WaitForAccessorBindingDeletion(vpcs.withContext(ctx), volumeID, bindingID)
+}
@@ -0,0 +1,119 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"testing"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOriginVolume creates a share in security group mode whose mount targets must use user managed transit encryption
+func fakeOriginVolume(t *testing.T, vpcs *VPCSession) *models.Share {
+	origin, err := vpcs.Apiclient.FileShareService().CreateFileShare(&models.Share{
+		Name:                          "fake-origin",
+		Size:                          10,
+		Profile:                       &models.Profile{Name: "dp2"},
+		AccessControlMode:             SecurityGroup,
+		AllowedTransitEncryptionModes: []string{"user_managed"},
+	}, vpcs.Logger)
+	require.NoError(t, err)
+	require.NoError(t, WaitForValidVolumeState(vpcs, origin.ID))
+	return origin
+}
+
+func TestAccessorVolume(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+	subnet := fake.AddSubnet(models.Subnet{ID: "subnet1", VPC: &provider.VPC{ID: "vpc2"}, Zone: &models.Zone{Name: fakevpc.DefaultZone}})
+	origin := fakeOriginVolume(t, vpcs)
+
+	accessor, err := vpcs.CreateAccessorVolume(AccessorVolumeRequest{Name: "fake-accessor", OriginVolumeCRN: origin.CRN})
+	require.NoError(t, err)
+	share, err := vpcs.Apiclient.FileShareService().GetFileShare(accessor.VolumeID, vpcs.Logger)
+	require.NoError(t, err)
+	assert.Equal(t, models.AccessorBindingRoleAccessor, share.AccessorBindingRole)
+	assert.Equal(t, origin.ID, share.OriginShare.ID)
+
+	// The mount target of the accessor share must use a transit encryption the origin share allows
+	accessPointRequest := provider.VolumeAccessPointRequest{
+		VolumeID:          accessor.VolumeID,
+		SubnetID:          subnet.ID,
+		AccessControlMode: SecurityGroup,
+	}
+	_, err = vpcs.CreateVolumeAccessPoint(accessPointRequest)
+	assert.Equal(t, string(userError.CreateVolumeAccessPointFailed), userErrorCode(err))
+	accessPointRequest.TransitEncryption = "user_managed"
+	accessPoint, err := vpcs.CreateVolumeAccessPoint(accessPointRequest)
+	require.NoError(t, err)
+	accessPointRequest.AccessPointID = accessPoint.AccessPointID
+	_, err = vpcs.WaitForCreateVolumeAccessPoint(accessPointRequest)
+	require.NoError(t, err)
+
+	bindings, err := vpcs.ListVolumeAccessorBindings(origin.ID)
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, accessor.VolumeID, bindings[0].Accessor.ID)
+
+	// Revoking the access takes the accessor share away
+	require.NoError(t, vpcs.DeleteVolumeAccessorBinding(origin.ID, bindings[0].ID))
+	_, err = vpcs.GetVolume(accessor.VolumeID)
+	assert.Error(t, err)
+	bindings, err = vpcs.ListVolumeAccessorBindings(origin.ID)
+	require.NoError(t, err)
+	assert.Empty(t, bindings)
+
+	// A binding which is already gone takes a single call
+	deletes := fake.CallCount("DeleteFileShareAccessorBinding")
+	assert.NoError(t, vpcs.DeleteVolumeAccessorBinding(origin.ID, "r006-binding"))
+	assert.Equal(t, deletes+1, fake.CallCount("DeleteFileShareAccessorBinding"))
+}
+
+func TestCreateAccessorVolumeFailures(t *testing.T) {
+	t.Run("invalid requests", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+
+		_, err := vpcs.CreateAccessorVolume(AccessorVolumeRequest{OriginVolumeCRN: "crn:v1:bluemix:public:is:us-south-1:a/account::share:share1"})
+		assert.Equal(t, "InvalidVolumeName", userErrorCode(err))
+		_, err = vpcs.CreateAccessorVolume(AccessorVolumeRequest{Name: "fake-accessor", OriginVolumeCRN: "share1"})
+		assert.Equal(t, "InvalidAccessorVolumeRequest", userErrorCode(err))
+		assert.Equal(t, 0, fake.CallCount("CreateFileShare"))
+	})
+
+	t.Run("origin in vpc mode", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		origin, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+
+		_, err = vpcs.CreateAccessorVolume(AccessorVolumeRequest{Name: "fake-accessor", OriginVolumeCRN: origin.CRN})
+		assert.Equal(t, "FailedToCreateAccessorVolume", userErrorCode(err))
+	})
+
+	t.Run("origin with accessors cannot be deleted", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		origin := fakeOriginVolume(t, vpcs)
+		_, err := vpcs.CreateAccessorVolume(AccessorVolumeRequest{Name: "fake-accessor", OriginVolumeCRN: origin.CRN})
+		require.NoError(t, err)
+
+		err = vpcs.DeleteVolume(&provider.Volume{VolumeID: origin.ID})
+		assert.Error(t, err)
+	})
+}
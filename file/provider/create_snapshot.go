@@ -18,6 +18,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -31,8 +32,23 @@ import (
 
 const snapshotReadyState = "stable"
 
+// CreateSnapshotOptions control how CreateSnapshotWithOptions creates a snapshot
+type CreateSnapshotOptions struct {
+	// WaitForReady blocks until the snapshot is stable, as WaitForSnapshotReady does
+	WaitForReady bool
+	// ReadyTimeout bounds the wait for the snapshot to be stable, the poll settings of WaitForSnapshotReadyOp apply when 0
+	ReadyTimeout time.Duration
+}
+
 // CreateSnapshot creates snapshot
 func (vpcs *VPCSession) CreateSnapshot(sourceVolumeID string, snapshotParameters provider.SnapshotParameters) (*provider.Snapshot, error) {
+	return vpcs.CreateSnapshotWithOptions(sourceVolumeID, snapshotParameters, CreateSnapshotOptions{})
+}
+
+// CreateSnapshotWithOptions creates snapshot as CreateSnapshot does, then waits for it to be stable when options
+// ask for it. The snapshot created is returned along with the error when it does not get ready, so that the
+// caller can clean it up
+func (vpcs *VPCSession) CreateSnapshotWithOptions(sourceVolumeID string, snapshotParameters provider.SnapshotParameters, options CreateSnapshotOptions) (*provider.Snapshot, error) {
 	vpcs.Logger.Info("Entry CreateSnapshot", zap.Reflect("snapshotRequest", snapshotParameters), zap.Reflect("sourceVolumeID", sourceVolumeID))
 	defer vpcs.Logger.Info("Exit CreateSnapshot", zap.Reflect("snapshotRequest", snapshotParameters), zap.Reflect("sourceVolumeID", sourceVolumeID))
 	defer metrics.UpdateDurationFromStart(vpcs.Logger, "CreateSnapshot", time.Now())
@@ -54,7 +70,7 @@ func (vpcs *VPCSession) CreateSnapshot(sourceVolumeID string, snapshotParameters
 		UserTags: tags,
 	}
 
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		snapshotResult, err = vpcs.Apiclient.SnapshotService().CreateSnapshot(sourceVolumeID, snapshotTemplate, vpcs.Logger)
 		return err
 	})
@@ -66,9 +82,28 @@ func (vpcs *VPCSession) CreateSnapshot(sourceVolumeID string, snapshotParameters
 	// Converting provider snapshot to lib snapshot type
 	snapshotResponse := FromProviderToLibSnapshot(sourceVolumeID, snapshotResult, vpcs.Logger)
 	vpcs.Logger.Info("SnapshotResponse", zap.Reflect("snapshotResponse", snapshotResponse))
+
+	if options.WaitForReady && !snapshotResponse.ReadyToUse {
+		readySnapshot, err := vpcs.WaitForSnapshotReady(sourceVolumeID, snapshotResult.ID, options.ReadyTimeout)
+		if err != nil {
+			return snapshotResponse, err
+		}
+		snapshotResponse = readySnapshot.Snapshot
+	}
 	return snapshotResponse, err
 }
 
+// CreateSnapshotWithOptionsWithContext is CreateSnapshotWithOptions which gives up on the backend calls and the
+// wait for the snapshot to be stable once ctx is done
+func (vpcs *VPCSession) CreateSnapshotWithOptionsWithContext(ctx context.Context, sourceVolumeID string, snapshotParameters provider.SnapshotParameters, options CreateSnapshotOptions) (*provider.Snapshot, error) {
+	return vpcs.withContext(ctx).CreateSnapshotWithOptions(sourceVolumeID, snapshotParameters, options)
+}
+
+// CreateSnapshotWithContext creates snapshot, giving up on the backend calls once ctx is done
+func (vpcs *VPCSession) CreateSnapshotWithContext(ctx context.Context, sourceVolumeID string, snapshotParameters provider.SnapshotParameters) (*provider.Snapshot, error) {
+	return vpcs.withContext(ctx).CreateSnapshot(sourceVolumeID, snapshotParameters)
+}
+
 // validateSnapshotRequest validates request for snapshot
 func (vpcs *VPCSession) validateSnapshotRequest(sourceVolumeID string) error {
 	var err error
@@ -18,6 +18,10 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
@@ -39,6 +43,27 @@ func (vpcs *VPCSession) CreateVolume(volumeRequest provider.Volume) (volumeRespo
 	defer vpcs.Logger.Debug("Exit from CreateVolume method...")
 	defer metrics.UpdateDurationFromStart(vpcs.Logger, "CreateVolume", time.Now())
 
+	operation, err := vpcs.StartCreateVolume(volumeRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcs.Logger.Info("Waiting for volume to be in valid (stable) state", zap.Reflect("VolumeDetails", operation.Volume))
+	err = vpcs.WaitOperation(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcs.Logger.Info("VolumeResponse", zap.Reflect("volumeResponse", operation.Volume))
+	return operation.Volume, nil
+}
+
+// StartCreateVolume asks for the file share creation, and returns the operation to check back on for the share to be stable
+func (vpcs *VPCSession) StartCreateVolume(volumeRequest provider.Volume) (*Operation, error) {
+	vpcs.Logger.Debug("Entry of StartCreateVolume method...")
+	defer vpcs.Logger.Debug("Exit from StartCreateVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "StartCreateVolume", time.Now())
+
 	var iops int64
 	var bandwidth int32
 	vpcs.Logger.Info("Basic validation for CreateVolume request... ", zap.Reflect("RequestedVolumeDetails", volumeRequest))
@@ -47,8 +72,203 @@ func (vpcs *VPCSession) CreateVolume(volumeRequest provider.Volume) (volumeRespo
 		return nil, err
 	}
 
+	err = vpcs.validateAgainstShareProfile(volumeRequest.VPCVolume.Profile.Name, int64(*volumeRequest.Capacity), iops, bandwidth)
+	if err != nil {
+		return nil, err
+	}
+
 	vpcs.Logger.Info("Successfully validated inputs for CreateVolume request... ")
 
+	shareTemplate := buildShareTemplate(volumeRequest, resourceGroup, iops, bandwidth)
+
+	vpcs.Logger.Info("Calling VPC provider for volume creation...")
+	var volume *models.Share
+
+	err = vpcs.retry(func() error {
+		volume, err = vpcs.Apiclient.FileShareService().CreateFileShare(shareTemplate, vpcs.Logger)
+		return err
+	})
+
+	if hasErrorCode(err, SharesNameDuplicate) {
+		// An earlier attempt may have created the share before failing, e.g. on a lost response or a restart
+		vpcs.Logger.Info("File share with the same name already exists, checking if it can be adopted", zap.Reflect("Name", shareTemplate.Name))
+		volume, err = vpcs.adoptExistingShare(volumeRequest, shareTemplate, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err != nil {
+		vpcs.Logger.Debug("Failed to create volume from VPC provider", zap.Reflect("BackendError", err))
+		return nil, userError.GetUserError("FailedToPlaceOrder", err)
+	}
+
+	vpcs.Logger.Info("Successfully created volume from VPC provider...", zap.Reflect("VolumeDetails", volume))
+	operation := newOperation(CreateVolumeOperation, volume.ID, StatusStable)
+
+	// Converting share to lib volume type
+	operation.Volume = FromProviderToLibVolume(volume, vpcs.Logger)
+	// VPC does have region yet . So use requested region in response
+	operation.Volume.Region = volumeRequest.Region
+
+	/* // TBD Return reuested tag as is if not tags returned by backend
+	if len(volumeResponse.Tags) == 0 && len(volumeRequest.Tags) > 0 {
+		volumeResponse.Tags = volumeRequest.Tags
+	} */
+	return operation, nil
+}
+
+// CreateVolumeWithContext creates file share, giving up on the backend calls and the wait for stable state once ctx is done
+func (vpcs *VPCSession) CreateVolumeWithContext(ctx context.Context, volumeRequest provider.Volume) (*provider.Volume, error) {
+	return vpcs.withContext(ctx).CreateVolume(volumeRequest)
+}
+
+// adoptExistingShare returns the existing share named after the request when it matches the request, so that
+// creating a volume again after a failed attempt picks up the share that attempt created. createErr is the name
+// duplicate error of the creation, returned as is when the share cannot be found
+func (vpcs *VPCSession) adoptExistingShare(volumeRequest provider.Volume, shareTemplate *models.Share, createErr error) (*models.Share, error) {
+	var existing *models.Share
+	err := vpcs.retry(func() error {
+		var err error
+		existing, err = vpcs.Apiclient.FileShareService().GetFileShareByName(shareTemplate.Name, vpcs.Logger)
+		return err
+	})
+	if err != nil || existing == nil {
+		vpcs.Logger.Warn("Failed to find the file share with the same name", zap.Reflect("Name", shareTemplate.Name), zap.Error(err))
+		return nil, userError.GetUserError("FailedToPlaceOrder", createErr)
+	}
+
+	// Only a share which is still being created or is ready can be adopted, e.g. a failed one never gets ready
+	if state := existing.GetLifecycleState(); state != StatusPending && state != StatusStable {
+		vpcs.Logger.Warn("File share with the same name cannot be adopted in its lifecycle state", zap.Reflect("ExistingVolume", existing), zap.String("State", state))
+		return nil, userError.GetUserError("VolumeNameConflictState", createErr, existing.Name, existing.ID, state)
+	}
+
+	mismatches := shareMismatches(existing, shareTemplate, volumeRequest.VPCID)
+	if len(mismatches) > 0 {
+		vpcs.Logger.Warn("File share with the same name does not match the request", zap.Reflect("ExistingVolume", existing), zap.Strings("Mismatches", mismatches))
+		return nil, userError.GetUserError("VolumeNameConflict", createErr, existing.Name, existing.ID, strings.Join(mismatches, "; "))
+	}
+
+	vpcs.Logger.Info("Adopting the existing file share", zap.Reflect("VolumeDetails", existing))
+	return existing, nil
+}
+
+// shareMismatches lists the fields of the existing share which differ from the share template, and the
+// mount target VPC when vpcID is set. Fields left unset in the template are not compared
+func shareMismatches(existing *models.Share, shareTemplate *models.Share, vpcID string) []string {
+	mismatches := []string{}
+	mismatch := func(field string, requested string, found string) {
+		if found == "" {
+			found = "none"
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s (requested %s, found %s)", field, requested, found))
+	}
+
+	if shareTemplate.Profile != nil {
+		found := ""
+		if existing.Profile != nil {
+			found = existing.Profile.Name
+		}
+		if found != shareTemplate.Profile.Name {
+			mismatch("profile", shareTemplate.Profile.Name, found)
+		}
+	}
+	if existing.Size != shareTemplate.Size {
+		mismatch("size", strconv.FormatInt(shareTemplate.Size, 10), strconv.FormatInt(existing.Size, 10))
+	}
+	if shareTemplate.Zone != nil {
+		found := ""
+		if existing.Zone != nil {
+			found = existing.Zone.Name
+		}
+		if found != shareTemplate.Zone.Name {
+			mismatch("zone", shareTemplate.Zone.Name, found)
+		}
+	}
+	if shareTemplate.ResourceGroup != nil && existing.ResourceGroup != nil {
+		if shareTemplate.ResourceGroup.ID != "" && existing.ResourceGroup.ID != shareTemplate.ResourceGroup.ID {
+			mismatch("resource group", shareTemplate.ResourceGroup.ID, existing.ResourceGroup.ID)
+		} else if shareTemplate.ResourceGroup.ID == "" && existing.ResourceGroup.Name != "" && existing.ResourceGroup.Name != shareTemplate.ResourceGroup.Name {
+			mismatch("resource group", shareTemplate.ResourceGroup.Name, existing.ResourceGroup.Name)
+		}
+	}
+	if vpcID != "" {
+		found := []string{}
+		matched := false
+		if existing.ShareTargets != nil {
+			for _, target := range *existing.ShareTargets {
+				if target.VPC != nil {
+					found = append(found, target.VPC.ID)
+					matched = matched || target.VPC.ID == vpcID
+				}
+			}
+		}
+		if !matched {
+			mismatch("mount target VPC", vpcID, strings.Join(found, ", "))
+		}
+	}
+	return mismatches
+}
+
+// validateVolumeRequest validating volume request
+func validateVolumeRequest(volumeRequest provider.Volume) (models.ResourceGroup, int64, int32, error) {
+	problems := volumeRequestProblems(volumeRequest)
+	if len(problems) > 0 {
+		return models.ResourceGroup{}, 0, 0, problems[0]
+	}
+
+	resourceGroup := models.ResourceGroup{}
+	if len(volumeRequest.VPCVolume.ResourceGroup.ID) > 0 {
+		resourceGroup.ID = volumeRequest.VPCVolume.ResourceGroup.ID
+	}
+	if len(volumeRequest.VPCVolume.ResourceGroup.Name) > 0 {
+		// get the resource group ID from resource group name as Name is not supported by RIaaS
+		resourceGroup.Name = volumeRequest.VPCVolume.ResourceGroup.Name
+	}
+
+	var iops int64
+	if volumeRequest.Iops != nil {
+		iops = ToInt64(*volumeRequest.Iops)
+	}
+
+	return resourceGroup, iops, volumeRequest.VPCVolume.Bandwidth, nil
+}
+
+// volumeRequestProblems lists every problem of the volume request which can be found without calling the backend,
+// in the order validateVolumeRequest reports them
+func volumeRequestProblems(volumeRequest provider.Volume) []error {
+	problems := []error{}
+
+	// Volume name should not be empty
+	if volumeRequest.Name == nil || len(*volumeRequest.Name) == 0 {
+		problems = append(problems, userError.GetUserError("InvalidVolumeName", nil, nil))
+	}
+
+	if volumeRequest.VPCVolume.Profile == nil {
+		problems = append(problems, userError.GetUserError("VolumeProfileEmpty", nil))
+	}
+
+	// validate and add resource group ID or Name whichever is provided by user
+	if volumeRequest.VPCVolume.ResourceGroup == nil {
+		problems = append(problems, userError.GetUserError("EmptyResourceGroup", nil))
+	} else if len(volumeRequest.VPCVolume.ResourceGroup.ID) == 0 && len(volumeRequest.VPCVolume.ResourceGroup.Name) == 0 {
+		problems = append(problems, userError.GetUserError("EmptyResourceGroupIDandName", nil))
+	}
+
+	if volumeRequest.Capacity == nil {
+		problems = append(problems, userError.GetUserError("VolumeCapacityInvalid", nil, nil))
+	} else if *volumeRequest.Capacity < minSize && (volumeRequest.VPCVolume.Profile == nil || volumeRequest.VPCVolume.Profile.Name != vpcfile.RFSProfile) {
+		// Minimum Capacity validation for non RFS profiles.
+		problems = append(problems, userError.GetUserError("VolumeCapacityInvalid", nil, *volumeRequest.Capacity))
+	}
+
+	return problems
+}
+
+// buildShareTemplate builds the share template sent to the backend for the validated volumeRequest, along with
+// its mount target when the request has the VPC, subnet or reserved IP for it
+func buildShareTemplate(volumeRequest provider.Volume, resourceGroup models.ResourceGroup, iops int64, bandwidth int32) *models.Share {
 	// Set zone if provided
 	var zone *models.Zone
 	if volumeRequest.Az != "" {
@@ -103,10 +323,8 @@ func (vpcs *VPCSession) CreateVolume(volumeRequest provider.Volume) (volumeRespo
 		shareTemplate.ShareTargets = &volumeAccessPointList
 	}
 
-	var encryptionKeyCRN string
 	if volumeRequest.VPCVolume.VolumeEncryptionKey != nil && len(volumeRequest.VPCVolume.VolumeEncryptionKey.CRN) > 0 {
-		encryptionKeyCRN = volumeRequest.VPCVolume.VolumeEncryptionKey.CRN
-		shareTemplate.EncryptionKey = &models.EncryptionKey{CRN: encryptionKeyCRN}
+		shareTemplate.EncryptionKey = &models.EncryptionKey{CRN: volumeRequest.VPCVolume.VolumeEncryptionKey.CRN}
 	}
 
 	// adding snapshot CRN and ID in the request, if it is provided to create the volume from snapshot
@@ -122,94 +340,7 @@ func (vpcs *VPCSession) CreateVolume(volumeRequest provider.Volume) (volumeRespo
 		shareTemplate.AccessControlMode = ""
 	}
 
-	vpcs.Logger.Info("Calling VPC provider for volume creation...")
-	var volume *models.Share
-
-	err = retry(vpcs.Logger, func() error {
-		volume, err = vpcs.Apiclient.FileShareService().CreateFileShare(shareTemplate, vpcs.Logger)
-		return err
-	})
-
-	if err != nil {
-		vpcs.Logger.Debug("Failed to create volume from VPC provider", zap.Reflect("BackendError", err))
-		return nil, userError.GetUserError("FailedToPlaceOrder", err)
-	}
-
-	vpcs.Logger.Info("Successfully created volume from VPC provider...", zap.Reflect("VolumeDetails", volume))
-
-	vpcs.Logger.Info("Waiting for volume to be in valid (stable) state", zap.Reflect("VolumeDetails", volume))
-	err = WaitForValidVolumeState(vpcs, volume.ID)
-	if err != nil {
-		return nil, userError.GetUserError("VolumeNotInValidState", err, volume.ID)
-	}
-
-	vpcs.Logger.Info("Volume got valid (stable) state", zap.Reflect("VolumeDetails", volume))
-
-	// Converting share to lib volume type
-	volumeResponse = FromProviderToLibVolume(volume, vpcs.Logger)
-	// VPC does have region yet . So use requested region in response
-	volumeResponse.Region = volumeRequest.Region
-
-	/* // TBD Return reuested tag as is if not tags returned by backend
-	if len(volumeResponse.Tags) == 0 && len(volumeRequest.Tags) > 0 {
-		volumeResponse.Tags = volumeRequest.Tags
-	} */
-	vpcs.Logger.Info("VolumeResponse", zap.Reflect("volumeResponse", volumeResponse))
-
-	return volumeResponse, err
-}
-
-// validateVolumeRequest validating volume request
-func validateVolumeRequest(volumeRequest provider.Volume) (models.ResourceGroup, int64, int32, error) {
-	resourceGroup := models.ResourceGroup{}
-	var iops int64
-	iops = 0
-	var bandwidth int32
-	bandwidth = 0
-
-	// Volume name should not be empty
-	if volumeRequest.Name == nil || len(*volumeRequest.Name) == 0 {
-		return resourceGroup, iops, bandwidth, userError.GetUserError("InvalidVolumeName", nil, nil)
-	}
-
-	if volumeRequest.VPCVolume.Profile == nil {
-		return resourceGroup, iops, bandwidth, userError.GetUserError("VolumeProfileEmpty", nil)
-	}
-
-	// validate and add resource group ID or Name whichever is provided by user
-	if volumeRequest.VPCVolume.ResourceGroup == nil {
-		return resourceGroup, iops, bandwidth, userError.GetUserError("EmptyResourceGroup", nil)
-	}
-
-	// validate and add resource group ID or Name whichever is provided by user
-	if len(volumeRequest.VPCVolume.ResourceGroup.ID) == 0 && len(volumeRequest.VPCVolume.ResourceGroup.Name) == 0 {
-		return resourceGroup, iops, bandwidth, userError.GetUserError("EmptyResourceGroupIDandName", nil)
-	}
-
-	if len(volumeRequest.VPCVolume.ResourceGroup.ID) > 0 {
-		resourceGroup.ID = volumeRequest.VPCVolume.ResourceGroup.ID
-	}
-	if len(volumeRequest.VPCVolume.ResourceGroup.Name) > 0 {
-		// get the resource group ID from resource group name as Name is not supported by RIaaS
-		resourceGroup.Name = volumeRequest.VPCVolume.ResourceGroup.Name
-	}
-
-	if volumeRequest.Capacity == nil {
-		return resourceGroup, iops, bandwidth, userError.GetUserError("VolumeCapacityInvalid", nil, nil)
-	}
-
-	// Minimum Capacity validation for non RFS profiles.
-	if *volumeRequest.Capacity < minSize && volumeRequest.VPCVolume.Profile.Name != vpcfile.RFSProfile {
-		return resourceGroup, iops, bandwidth, userError.GetUserError("VolumeCapacityInvalid", nil, *volumeRequest.Capacity)
-	}
-
-	if volumeRequest.Iops != nil {
-		iops = ToInt64(*volumeRequest.Iops)
-	}
-
-	bandwidth = volumeRequest.VPCVolume.Bandwidth
-
-	return resourceGroup, iops, bandwidth, nil
+	return shareTemplate
 }
 
 func setENIParameters(shareTarget *models.ShareTarget, volumeRequest provider.Volume) {
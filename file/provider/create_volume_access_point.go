@@ -18,6 +18,7 @@
 package provider
 
 import (
+	"context"
 	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
@@ -31,6 +32,7 @@ import (
 
 // VpcVolumeAccessPoint ...
 const (
+	StatusPending  = "pending"
 	StatusStable   = "stable"
 	StatusDeleting = "deleting"
 	StatusDeleted  = "deleted"
@@ -50,9 +52,9 @@ func (vpcs *VPCSession) CreateVolumeAccessPoint(volumeAccessPointRequest provide
 	var volumeAccessPointResult *models.ShareTarget
 	var varp *provider.VolumeAccessPointResponse
 
-	volumeAccessPoint := models.NewShareTarget(volumeAccessPointRequest)
+	volumeAccessPoint := buildShareTargetTemplate(volumeAccessPointRequest)
 
-	err = vpcs.APIRetry.FlexyRetry(vpcs.Logger, func() (error, bool) {
+	err = vpcs.flexyRetry(func() (error, bool) {
 		/*First , check if volume target is already created
 		Even if we remove this check RIAAS will respond "shares_target_vpc_duplicate" erro code.
 		We need to again do GetVolumeAccessPoint to fetch the already created access point */
@@ -64,31 +66,12 @@ func (vpcs *VPCSession) CreateVolumeAccessPoint(volumeAccessPointRequest provide
 			return nil, true // stop retry volume accessPoint already created
 		}
 
-		// If ENI/VNI is enabled
-		if volumeAccessPointRequest.AccessControlMode == SecurityGroup {
-			volumeAccessPoint.VPC = nil // We can either pass VPC or VNI
-			volumeAccessPoint.VirtualNetworkInterface = &models.VirtualNetworkInterface{
-				SecurityGroups: volumeAccessPointRequest.SecurityGroups,
-				ResourceGroup:  volumeAccessPointRequest.ResourceGroup,
-			}
-
-			if len(volumeAccessPointRequest.SubnetID) != 0 {
-				volumeAccessPoint.VirtualNetworkInterface.Subnet = &models.SubnetRef{
-					ID: volumeAccessPointRequest.SubnetID,
-				}
-			}
-
-			if volumeAccessPointRequest.PrimaryIP != nil {
-				volumeAccessPoint.VirtualNetworkInterface.PrimaryIP = volumeAccessPointRequest.PrimaryIP
-			}
-		}
-
 		//Try creating volume accessPoint if it's not already created or there is error in getting current volume accessPoint
 		vpcs.Logger.Info("Creating volume accessPoint from VPC provider...")
 		volumeAccessPointResult, err = vpcs.Apiclient.FileShareService().CreateFileShareTarget(&volumeAccessPoint, vpcs.Logger)
 		// Keep retry, until we get the proper volumeAccessPointResult object
 		if err != nil && volumeAccessPointResult == nil {
-			return err, skipRetryForObviousErrors(err)
+			return err, vpcs.GetRetryPolicy().SkipRetry(err)
 		}
 		varp = volumeAccessPointResult.ToVolumeAccessPointResponse()
 
@@ -104,22 +87,62 @@ func (vpcs *VPCSession) CreateVolumeAccessPoint(volumeAccessPointRequest provide
 	return varp, nil
 }
 
+// CreateVolumeAccessPointWithContext creates the file share target, giving up on the backend calls once ctx is done
+func (vpcs *VPCSession) CreateVolumeAccessPointWithContext(ctx context.Context, volumeAccessPointRequest provider.VolumeAccessPointRequest) (*provider.VolumeAccessPointResponse, error) {
+	return vpcs.withContext(ctx).CreateVolumeAccessPoint(volumeAccessPointRequest)
+}
+
 // validateVolume validating volume ID and VPC ID
 func (vpcs *VPCSession) validateVolumeAccessPointRequest(volumeAccessPointRequest provider.VolumeAccessPointRequest) error {
-	var err error
+	problems := volumeAccessPointRequestProblems(volumeAccessPointRequest)
+	if len(problems) > 0 {
+		vpcs.Logger.Error("Invalid volumeAccessPointRequest", zap.Error(problems[0]))
+		return problems[0]
+	}
+	return nil
+}
+
+// volumeAccessPointRequestProblems lists every problem of the volume access point request which can be found
+// without calling the backend
+func volumeAccessPointRequestProblems(volumeAccessPointRequest provider.VolumeAccessPointRequest) []error {
+	problems := []error{}
 	// Check for VolumeID - required validation
 	if len(volumeAccessPointRequest.VolumeID) == 0 {
-		err = userError.GetUserError(string(reasoncode.ErrorRequiredFieldMissing), nil, "VolumeID")
-		vpcs.Logger.Error("volumeAccessPointRequest.VolumeID is required", zap.Error(err))
-		return err
+		problems = append(problems, userError.GetUserError(string(reasoncode.ErrorRequiredFieldMissing), nil, "VolumeID"))
 	}
 
 	// Check for VPC ID, SubnetID or AccessPointID  - required validation
 	if len(volumeAccessPointRequest.VPCID) == 0 && len(volumeAccessPointRequest.SubnetID) == 0 && len(volumeAccessPointRequest.AccessPointID) == 0 {
-		err = userError.GetUserError(string(reasoncode.ErrorRequiredFieldMissing), nil, "VPCID or SubnetID or AccessPointID")
-		vpcs.Logger.Error("One of volumeAccessPointRequest.VPCID, volumeAccessPointRequest.SubnetID and volumeAccessPointRequest.AccessPoint is required", zap.Error(err))
-		return err
+		problems = append(problems, userError.GetUserError(string(reasoncode.ErrorRequiredFieldMissing), nil, "VPCID or SubnetID or AccessPointID"))
 	}
 
-	return nil
+	return problems
+}
+
+// buildShareTargetTemplate builds the share target template sent to the backend for volumeAccessPointRequest
+func buildShareTargetTemplate(volumeAccessPointRequest provider.VolumeAccessPointRequest) models.ShareTarget {
+	volumeAccessPoint := models.NewShareTarget(volumeAccessPointRequest)
+
+	// If ENI/VNI is enabled
+	if volumeAccessPointRequest.AccessControlMode == SecurityGroup {
+		// The mount targets of an accessor share, which always is in security group mode, must use one of the transit
+		// encryption modes its origin share allows. Mount targets in VPC mode are sent without it, as they always were
+		volumeAccessPoint.TransitEncryption = volumeAccessPointRequest.TransitEncryption
+		volumeAccessPoint.VPC = nil // We can either pass VPC or VNI
+		volumeAccessPoint.VirtualNetworkInterface = &models.VirtualNetworkInterface{
+			SecurityGroups: volumeAccessPointRequest.SecurityGroups,
+			ResourceGroup:  volumeAccessPointRequest.ResourceGroup,
+		}
+
+		if len(volumeAccessPointRequest.SubnetID) != 0 {
+			volumeAccessPoint.VirtualNetworkInterface.Subnet = &models.SubnetRef{
+				ID: volumeAccessPointRequest.SubnetID,
+			}
+		}
+
+		if volumeAccessPointRequest.PrimaryIP != nil {
+			volumeAccessPoint.VirtualNetworkInterface.PrimaryIP = volumeAccessPointRequest.PrimaryIP
+		}
+	}
+	return volumeAccessPoint
 }
@@ -236,3 +236,12 @@ func TestCreateVolumeAccessPoint(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildShareTargetTemplateTransitEncryption(t *testing.T) {
+	request := provider.VolumeAccessPointRequest{VolumeID: "volume1", VPCID: "vpc1", TransitEncryption: "user_managed"}
+	assert.Empty(t, buildShareTargetTemplate(request).TransitEncryption)
+
+	request.AccessControlMode = SecurityGroup
+	request.SubnetID = "subnet1"
+	assert.Equal(t, "user_managed", buildShareTargetTemplate(request).TransitEncryption)
+}
@@ -20,12 +20,16 @@ package provider
 import (
 	"errors"
 	"testing"
+	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
 	fileShareServiceFakes "github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume/fakes"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -558,3 +562,90 @@ func String(v string) *string {
 func Int(v int) *int {
 	return &v
 }
+
+func TestCreateVolumeAdoptsExistingShare(t *testing.T) {
+	t.Run("share created by an earlier attempt", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		request := fakeVolumeRequest("fake-volume")
+		request.VPCID = "vpc1"
+		first, err := vpcs.StartCreateVolume(request)
+		require.NoError(t, err)
+
+		volume, err := vpcs.CreateVolume(request)
+		require.NoError(t, err)
+		assert.Equal(t, first.VolumeID, volume.VolumeID)
+		assert.Equal(t, 2, fake.CallCount("CreateFileShare"))
+	})
+
+	t.Run("lost creation response", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", OnCall: 1, DropResponse: true})
+
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		shares, err := vpcs.Apiclient.FileShareService().ListFileShares(0, "", nil, vpcs.Logger)
+		require.NoError(t, err)
+		require.Len(t, shares.Shares, 1)
+		assert.Equal(t, shares.Shares[0].ID, volume.VolumeID)
+	})
+
+	t.Run("failed share", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", OnCall: 1, Fail: true})
+		first, err := vpcs.StartCreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			share, err := vpcs.Apiclient.FileShareService().GetFileShare(first.VolumeID, vpcs.Logger)
+			return err == nil && share.GetLifecycleState() == StatusFailed
+		}, 5*time.Second, 10*time.Millisecond)
+
+		_, err = vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		assert.Equal(t, "VolumeNameConflictState", userErrorCode(err))
+		assert.Contains(t, err.(util.Message).Description, "'"+first.VolumeID+"'")
+		assert.Contains(t, err.(util.Message).Description, "'failed' lifecycle state")
+	})
+
+	t.Run("mismatched share", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		_, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+
+		request := fakeVolumeRequest("fake-volume")
+		request.Capacity = Int(20)
+		request.VPCID = "vpc1"
+		request.Az = "us-south-2"
+		_, err = vpcs.CreateVolume(request)
+		assert.Equal(t, "VolumeNameConflict", userErrorCode(err))
+		description := err.(util.Message).Description
+		assert.Contains(t, description, "size (requested 20, found 10)")
+		assert.Contains(t, description, "zone (requested us-south-2, found "+fakevpc.DefaultZone+")")
+		assert.Contains(t, description, "mount target VPC (requested vpc1, found none)")
+		assert.NotContains(t, description, "profile")
+		assert.NotContains(t, description, "resource group")
+	})
+}
+
+func TestShareMismatches(t *testing.T) {
+	existing := &models.Share{
+		Size:          10,
+		Profile:       &models.Profile{Name: "dp2"},
+		Zone:          &models.Zone{Name: "us-south-1"},
+		ResourceGroup: &models.ResourceGroup{ID: "rg1"},
+		ShareTargets:  &[]models.ShareTarget{{VPC: &provider.VPC{ID: "vpc1"}}},
+	}
+	template := &models.Share{
+		Size:          10,
+		Profile:       &models.Profile{Name: "dp2"},
+		Zone:          &models.Zone{Name: "us-south-1"},
+		ResourceGroup: &models.ResourceGroup{ID: "rg1"},
+	}
+	assert.Empty(t, shareMismatches(existing, template, "vpc1"))
+
+	template.Profile = &models.Profile{Name: "rfs"}
+	template.ResourceGroup = &models.ResourceGroup{ID: "rg2"}
+	assert.Equal(t, []string{
+		"profile (requested rfs, found dp2)",
+		"resource group (requested rg2, found rg1)",
+		"mount target VPC (requested vpc2, found vpc1)",
+	}, shareMismatches(existing, template, "vpc2"))
+}
@@ -18,6 +18,8 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
@@ -41,13 +43,14 @@ func (vpcs *VPCSession) DeleteSnapshot(snapshot *provider.Snapshot) error {
 	defer metrics.UpdateDurationFromStart(vpcs.Logger, "DeleteSnapshot", time.Now())
 
 	vpcs.Logger.Info("Deleting snapshot from VPC provider...")
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		err = vpcs.Apiclient.SnapshotService().DeleteSnapshot(snapshot.VolumeID, snapshot.SnapshotID, vpcs.Logger)
 		return err
 	})
 
 	if err != nil {
-		modelError, ok := err.(*models.Error)
+		var modelError *models.Error
+		ok := errors.As(err, &modelError)
 		if ok && len(modelError.Errors) > 0 && (string(modelError.Errors[0].Code) == SnapshotNotFound || string(modelError.Errors[0].Code) == SharesNotFound) {
 			vpcs.Logger.Warn("Share or Snapshot does not exist returning success", zap.Reflect("err", err))
 			return nil
@@ -63,26 +66,39 @@ func (vpcs *VPCSession) DeleteSnapshot(snapshot *provider.Snapshot) error {
 	return err
 }
 
-// WaitForSnapshotDeletion checks the snapshot for valid status
-func WaitForSnapshotDeletion(vpcs *VPCSession, volumeID string, snapshotID string) (err error) {
+// DeleteSnapshotWithContext deletes snapshot, giving up on the backend calls and the wait for deletion once ctx is done
+func (vpcs *VPCSession) DeleteSnapshotWithContext(ctx context.Context, snapshot *provider.Snapshot) error {
+	return vpcs.withContext(ctx).DeleteSnapshot(snapshot)
+}
+
+// WaitForSnapshotDeletion waits for the snapshot to be gone. A deletion still in progress once the wait
+// times out is not an error, the backend completes it on its own
+func WaitForSnapshotDeletion(vpcs *VPCSession, volumeID string, snapshotID string) error {
 	vpcs.Logger.Debug("Entry of WaitForSnapshotDeletion method...")
 	defer vpcs.Logger.Debug("Exit from WaitForSnapshotDeletion method...")
-	var skip = false
 
 	vpcs.Logger.Info("Getting snapshot details from VPC provider...", zap.Reflect("snapshotID", snapshotID))
 
-	err = vpcs.APIRetry.FlexyRetry(vpcs.Logger, func() (error, bool) {
-		_, err = vpcs.Apiclient.SnapshotService().GetSnapshot(volumeID, snapshotID, vpcs.Logger)
-		// Keep retry, until GetSnapshot returns snapshots_not_found
-		if err != nil {
-			skip = skipRetry(err.(*models.Error))
-			return nil, skip
-		}
-		return err, false // continue retry as we are not seeing error which means snapshot is available
+	_, err := pollLifecycle(vpcs, LifecycleWait[*models.Snapshot]{
+		Operation: WaitForSnapshotDeletionOp,
+		ID:        snapshotID,
+		Get: func() (*models.Snapshot, error) {
+			return vpcs.Apiclient.SnapshotService().GetSnapshot(volumeID, snapshotID, vpcs.Logger)
+		},
 	})
 
-	if err == nil && skip {
+	var timeoutErr *PollTimeoutError
+	if errors.As(err, &timeoutErr) {
+		vpcs.Logger.Warn("Snapshot is still being deleted", zap.Reflect("snapshotID", snapshotID), zap.Error(err))
+		return nil
+	}
+	if err == nil {
 		vpcs.Logger.Info("Snapshot got deleted.", zap.Reflect("snapshotID", snapshotID))
 	}
 	return err
 }
+
+// WaitForSnapshotDeletionWithContext is WaitForSnapshotDeletion which stops polling once ctx is done
+func WaitForSnapshotDeletionWithContext(ctx context.Context, vpcs *VPCSession, volumeID string, snapshotID string) error {
+	return WaitForSnapshotDeletion(vpcs.withContext(ctx), volumeID, snapshotID)
+}
@@ -18,6 +18,8 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
@@ -33,17 +35,37 @@ func (vpcs *VPCSession) DeleteVolume(volume *provider.Volume) (err error) {
 	defer vpcs.Logger.Debug("Exit from DeleteVolume method...")
 	defer metrics.UpdateDurationFromStart(vpcs.Logger, "DeleteVolume", time.Now())
 
-	vpcs.Logger.Info("Validating basic inputs for DeleteVolume method...", zap.Reflect("VolumeDetails", volume))
-	err = validateVolume(volume)
+	operation, err := vpcs.StartDeleteVolume(volume)
 	if err != nil {
 		return err
 	}
 
-	existingVol, err := vpcs.GetVolume(volume.VolumeID)
+	err = vpcs.WaitOperation(operation)
 	if err != nil {
 		return err
 	}
 
+	vpcs.Logger.Info("Successfully deleted volume from VPC provider")
+	return nil
+}
+
+// StartDeleteVolume asks for the file share deletion, and returns the operation to check back on for the share to be gone
+func (vpcs *VPCSession) StartDeleteVolume(volume *provider.Volume) (*Operation, error) {
+	vpcs.Logger.Debug("Entry of StartDeleteVolume method...")
+	defer vpcs.Logger.Debug("Exit from StartDeleteVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "StartDeleteVolume", time.Now())
+
+	vpcs.Logger.Info("Validating basic inputs for DeleteVolume method...", zap.Reflect("VolumeDetails", volume))
+	err := validateVolume(volume)
+	if err != nil {
+		return nil, err
+	}
+
+	existingVol, err := vpcs.GetVolume(volume.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+
 	//If there exists any access point for volume we should abort delete
 	if existingVol.VolumeAccessPoints != nil && len(*existingVol.VolumeAccessPoints) != 0 {
 		var vpcIDList = []string{}
@@ -52,26 +74,26 @@ func (vpcs *VPCSession) DeleteVolume(volume *provider.Volume) (err error) {
 				vpcIDList = append(vpcIDList, volAccessPoint.VPC.ID)
 			}
 		}
-		return userError.GetUserError(string(userError.VolumeAccessPointExist), nil, volume.VolumeID, vpcIDList)
+		return nil, userError.GetUserError(string(userError.VolumeAccessPointExist), nil, volume.VolumeID, vpcIDList)
 	}
 
 	vpcs.Logger.Info("Deleting file share from VPC provider...")
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		vpcs.Logger.Info("Calling VPC client for file share deletion...")
 		err = vpcs.Apiclient.FileShareService().DeleteFileShare(volume.VolumeID, vpcs.Logger)
 		return err
 	})
 	if err != nil {
-		return userError.GetUserError("FailedToDeleteVolume", err, volume.VolumeID)
+		return nil, userError.GetUserError("FailedToDeleteVolume", err, volume.VolumeID)
 	}
 
-	err = WaitForVolumeDeletion(vpcs, volume.VolumeID)
-	if err != nil {
-		return userError.GetUserError("FailedToDeleteVolume", err, volume.VolumeID)
-	}
+	vpcs.Logger.Info("Successfully accepted volume deletion request", zap.Reflect("VolumeID", volume.VolumeID))
+	return newOperation(DeleteVolumeOperation, volume.VolumeID, StatusDeleted), nil
+}
 
-	vpcs.Logger.Info("Successfully deleted volume from VPC provider")
-	return err
+// DeleteVolumeWithContext deletes the file share, giving up on the backend calls and the wait for deletion once ctx is done
+func (vpcs *VPCSession) DeleteVolumeWithContext(ctx context.Context, volume *provider.Volume) error {
+	return vpcs.withContext(ctx).DeleteVolume(volume)
 }
 
 // validateVolume validating volume ID
@@ -88,26 +110,39 @@ func validateVolume(volume *provider.Volume) (err error) {
 	return
 }
 
-// WaitForVolumeDeletion checks the volume for valid status
-func WaitForVolumeDeletion(vpcs *VPCSession, volumeID string) (err error) {
+// WaitForVolumeDeletion waits for the volume to be gone. A deletion still in progress once the wait
+// times out is not an error, the backend completes it on its own
+func WaitForVolumeDeletion(vpcs *VPCSession, volumeID string) error {
 	vpcs.Logger.Debug("Entry of WaitForVolumeDeletion method...")
 	defer vpcs.Logger.Debug("Exit from WaitForVolumeDeletion method...")
-	var skip = false
 
 	vpcs.Logger.Info("Getting volume details from VPC provider...", zap.Reflect("VolumeID", volumeID))
 
-	err = vpcs.APIRetry.FlexyRetry(vpcs.Logger, func() (error, bool) {
-		_, err = vpcs.Apiclient.FileShareService().GetFileShare(volumeID, vpcs.Logger)
-		// Keep retry, until GetVolume returns volume not found
-		if err != nil {
-			skip = skipRetry(err.(*models.Error))
-			return nil, skip
-		}
-		return err, false // continue retry as we are not seeing error which means volume is stable
-	})
+	_, err := pollLifecycle(vpcs, volumeDeletionWait(vpcs, volumeID))
 
-	if err == nil && skip {
+	var timeoutErr *PollTimeoutError
+	if errors.As(err, &timeoutErr) {
+		vpcs.Logger.Warn("Volume is still being deleted", zap.Reflect("volumeID", volumeID), zap.Error(err))
+		return nil
+	}
+	if err == nil {
 		vpcs.Logger.Info("Volume got deleted.", zap.Reflect("volumeID", volumeID))
 	}
 	return err
 }
+
+// volumeDeletionWait is the wait for the file share to be gone
+func volumeDeletionWait(vpcs *VPCSession, volumeID string) LifecycleWait[*models.Share] {
+	return LifecycleWait[*models.Share]{
+		Operation: WaitForVolumeDeletionOp,
+		ID:        volumeID,
+		Get: func() (*models.Share, error) {
+			return vpcs.Apiclient.FileShareService().GetFileShare(volumeID, vpcs.Logger)
+		},
+	}
+}
+
+// WaitForVolumeDeletionWithContext is WaitForVolumeDeletion which stops polling once ctx is done
+func WaitForVolumeDeletionWithContext(ctx context.Context, vpcs *VPCSession, volumeID string) error {
+	return WaitForVolumeDeletion(vpcs.withContext(ctx), volumeID)
+}
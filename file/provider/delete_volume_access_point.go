@@ -23,6 +23,7 @@ import (
 	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 
+	"context"
 	"net/http"
 	"time"
 
@@ -43,7 +44,7 @@ func (vpcs *VPCSession) DeleteVolumeAccessPoint(deleteAccessPointRequest provide
 
 	var response *http.Response
 
-	err = vpcs.APIRetry.FlexyRetry(vpcs.Logger, func() (error, bool) {
+	err = vpcs.flexyRetry(func() (error, bool) {
 		// First , check if volume AccessPoint is already deleted to given instance
 		vpcs.Logger.Info("Checking if volume AccessPoint is already deleted ")
 		currentVolumeAccessPoint, err := vpcs.GetVolumeAccessPoint(deleteAccessPointRequest)
@@ -76,3 +77,8 @@ func (vpcs *VPCSession) DeleteVolumeAccessPoint(deleteAccessPointRequest provide
 	vpcs.Logger.Info("Successfully deleted volume AccessPoint from VPC provider", zap.Reflect("resp", response))
 	return response, nil
 }
+
+// DeleteVolumeAccessPointWithContext deletes the file share target, giving up on the backend calls once ctx is done
+func (vpcs *VPCSession) DeleteVolumeAccessPointWithContext(ctx context.Context, deleteAccessPointRequest provider.VolumeAccessPointRequest) (*http.Response, error) {
+	return vpcs.withContext(ctx).DeleteVolumeAccessPoint(deleteAccessPointRequest)
+}
@@ -0,0 +1,145 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// ChildType is the kind of resource deleted along with a file share
+type ChildType string
+
+const (
+	// ChildVolumeAccessPoint is a mount target of the file share
+	ChildVolumeAccessPoint = ChildType("VolumeAccessPoint")
+	// ChildSnapshot is a snapshot of the file share
+	ChildSnapshot = ChildType("Snapshot")
+)
+
+// CascadeDeleteOptions tells what DeleteVolumeCascade deletes along with the file share
+type CascadeDeleteOptions struct {
+	// DeleteSnapshots deletes the snapshots of the file share too. Without it, snapshots are left as they are
+	DeleteSnapshots bool
+}
+
+// ChildDeleteResult is the outcome of the deletion of a resource of the file share
+type ChildDeleteResult struct {
+	Type ChildType
+	ID   string
+	// Err is set when the resource could not be deleted
+	Err error
+}
+
+// CascadeDeleteResult is the outcome of DeleteVolumeCascade, child by child
+type CascadeDeleteResult struct {
+	VolumeID string
+	Children []ChildDeleteResult
+	// VolumeDeleted tells if the file share itself got deleted
+	VolumeDeleted bool
+}
+
+// Failed returns the children which could not be deleted, the ones to retry
+func (result *CascadeDeleteResult) Failed() []ChildDeleteResult {
+	failed := []ChildDeleteResult{}
+	for _, child := range result.Children {
+		if child.Err != nil {
+			failed = append(failed, child)
+		}
+	}
+	return failed
+}
+
+// DeleteVolumeCascade deletes the mount targets of the file share, and its snapshots when asked to, then the
+// file share itself. The file share is left in place if any of them could not be deleted, so calling it again
+// retries the ones which failed. The result is returned even on error
+func (vpcs *VPCSession) DeleteVolumeCascade(volume *provider.Volume, options CascadeDeleteOptions) (*CascadeDeleteResult, error) {
+	vpcs.Logger.Debug("Entry of DeleteVolumeCascade method...")
+	defer vpcs.Logger.Debug("Exit from DeleteVolumeCascade method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "DeleteVolumeCascade", time.Now())
+
+	vpcs.Logger.Info("Validating basic inputs for DeleteVolumeCascade method...", zap.Reflect("VolumeDetails", volume), zap.Reflect("Options", options))
+	err := validateVolume(volume)
+	if err != nil {
+		return nil, err
+	}
+	result := &CascadeDeleteResult{VolumeID: volume.VolumeID}
+
+	accessPoints, err := vpcs.ListAllVolumeAccessPoints(vpcs.requestContext(), volume.VolumeID)
+	if err != nil {
+		return result, err
+	}
+	vpcs.deleteVolumeAccessPoints(result, accessPoints)
+
+	if options.DeleteSnapshots {
+		snapshots, err := vpcs.ListAllSnapshots(vpcs.requestContext(), map[string]string{"source_volume.id": volume.VolumeID})
+		if err != nil {
+			return result, err
+		}
+		for _, snapshot := range snapshots {
+			vpcs.Logger.Info("Deleting snapshot of the file share...", zap.Reflect("SnapshotID", snapshot.SnapshotID))
+			result.Children = append(result.Children, ChildDeleteResult{Type: ChildSnapshot, ID: snapshot.SnapshotID, Err: vpcs.DeleteSnapshot(snapshot)})
+		}
+	}
+
+	failed := result.Failed()
+	if len(failed) > 0 {
+		vpcs.Logger.Error("Failed to delete the resources of the file share", zap.Reflect("VolumeID", volume.VolumeID), zap.Reflect("Failed", failed))
+		return result, userError.GetUserError("VolumeCascadeDeleteFailed", failed[0].Err, volume.VolumeID, len(failed), len(result.Children))
+	}
+
+	err = vpcs.DeleteVolume(volume)
+	if err != nil {
+		return result, err
+	}
+	result.VolumeDeleted = true
+	vpcs.Logger.Info("Successfully deleted volume and its resources", zap.Reflect("VolumeID", volume.VolumeID), zap.Int("children", len(result.Children)))
+	return result, nil
+}
+
+// DeleteVolumeCascadeWithContext is DeleteVolumeCascade which gives up on the backend calls and the waits once ctx is done
+func (vpcs *VPCSession) DeleteVolumeCascadeWithContext(ctx context.Context, volume *provider.Volume, options CascadeDeleteOptions) (*CascadeDeleteResult, error) {
+	return vpcs.withContext(ctx).DeleteVolumeCascade(volume, options)
+}
+
+// deleteVolumeAccessPoints asks for the deletion of all accessPoints before waiting for them, so that they are
+// deleted in parallel by the backend, and adds their results to result
+func (vpcs *VPCSession) deleteVolumeAccessPoints(result *CascadeDeleteResult, accessPoints []*provider.VolumeAccessPointResponse) {
+	requests := make([]provider.VolumeAccessPointRequest, len(accessPoints))
+	errs := make([]error, len(accessPoints))
+	for i, accessPoint := range accessPoints {
+		requests[i] = provider.VolumeAccessPointRequest{
+			VolumeID:      result.VolumeID,
+			AccessPointID: accessPoint.AccessPointID,
+		}
+		vpcs.Logger.Info("Deleting volume access point of the file share...", zap.Reflect("AccessPointID", accessPoint.AccessPointID))
+		_, errs[i] = vpcs.DeleteVolumeAccessPoint(requests[i])
+	}
+
+	for i, request := range requests {
+		if errs[i] == nil {
+			errs[i] = vpcs.WaitForDeleteVolumeAccessPoint(request)
+		}
+		result.Children = append(result.Children, ChildDeleteResult{Type: ChildVolumeAccessPoint, ID: request.AccessPointID, Err: errs[i]})
+	}
+}
@@ -0,0 +1,98 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVolumeWithChildren creates a volume with a mount target in vpc1 and a snapshot
+func fakeVolumeWithChildren(t *testing.T, vpcs *VPCSession) (*provider.Volume, *provider.Snapshot) {
+	request := fakeVolumeRequest("fake-volume")
+	request.VPCID = "vpc1"
+	volume, err := vpcs.CreateVolume(request)
+	require.NoError(t, err)
+	_, err = vpcs.WaitForCreateVolumeAccessPoint(provider.VolumeAccessPointRequest{
+		VolumeID:      volume.VolumeID,
+		AccessPointID: (*volume.VolumeAccessPoints)[0].ID,
+	})
+	require.NoError(t, err)
+
+	snapshot, err := vpcs.CreateSnapshot(volume.VolumeID, provider.SnapshotParameters{Name: "fake-snapshot"})
+	require.NoError(t, err)
+	return volume, snapshot
+}
+
+func TestDeleteVolumeCascade(t *testing.T) {
+	t.Run("invalid volume", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		result, err := vpcs.DeleteVolumeCascade(nil, CascadeDeleteOptions{})
+		assert.Nil(t, result)
+		assert.Equal(t, "InvalidVolumeID", userErrorCode(err))
+	})
+
+	t.Run("mount targets and snapshots", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		volume, snapshot := fakeVolumeWithChildren(t, vpcs)
+
+		result, err := vpcs.DeleteVolumeCascade(volume, CascadeDeleteOptions{DeleteSnapshots: true})
+		require.NoError(t, err)
+		assert.True(t, result.VolumeDeleted)
+		assert.Equal(t, []ChildDeleteResult{
+			{Type: ChildVolumeAccessPoint, ID: (*volume.VolumeAccessPoints)[0].ID},
+			{Type: ChildSnapshot, ID: snapshot.SnapshotID},
+		}, result.Children)
+		_, err = vpcs.GetVolume(volume.VolumeID)
+		assert.Error(t, err)
+	})
+
+	t.Run("snapshots left in place", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, _ := fakeVolumeWithChildren(t, vpcs)
+
+		result, err := vpcs.DeleteVolumeCascade(volume, CascadeDeleteOptions{})
+		require.NoError(t, err)
+		assert.True(t, result.VolumeDeleted)
+		assert.Len(t, result.Children, 1)
+		assert.Equal(t, 0, fake.CallCount("DeleteSnapshot"))
+	})
+
+	t.Run("failed child is retried", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, snapshot := fakeVolumeWithChildren(t, vpcs)
+		fault := fake.InjectFault(fakevpc.Fault{Operation: "DeleteSnapshot", Status: http.StatusBadRequest, Code: fakevpc.ErrorCodeBadRequest})
+
+		result, err := vpcs.DeleteVolumeCascade(volume, CascadeDeleteOptions{DeleteSnapshots: true})
+		assert.Equal(t, "VolumeCascadeDeleteFailed", userErrorCode(err))
+		assert.False(t, result.VolumeDeleted)
+		require.Len(t, result.Failed(), 1)
+		assert.Equal(t, snapshot.SnapshotID, result.Failed()[0].ID)
+		assert.Equal(t, 0, fake.CallCount("DeleteFileShare"))
+
+		fault.Remove()
+		result, err = vpcs.DeleteVolumeCascade(volume, CascadeDeleteOptions{DeleteSnapshots: true})
+		require.NoError(t, err)
+		assert.True(t, result.VolumeDeleted)
+		assert.Equal(t, []ChildDeleteResult{{Type: ChildSnapshot, ID: snapshot.SnapshotID}}, result.Children)
+	})
+}
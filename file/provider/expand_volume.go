@@ -18,6 +18,7 @@
 package provider
 
 import (
+	"context"
 	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
@@ -32,20 +33,43 @@ const (
 	GiB = 1024 * 1024 * 1024
 )
 
+// ExpandVolume expands the file share
 func (vpcs *VPCSession) ExpandVolume(expandVolumeRequest provider.ExpandVolumeRequest) (size int64, err error) {
 	vpcs.Logger.Debug("Entry of ExpandVolume method...")
 	defer vpcs.Logger.Debug("Exit from ExpandVolume method...")
 	defer metrics.UpdateDurationFromStart(vpcs.Logger, "ExpandVolume", time.Now())
 
+	operation, err := vpcs.StartExpandVolume(expandVolumeRequest)
+	if err != nil {
+		return -1, err
+	}
+
+	err = vpcs.WaitOperation(operation)
+	if err != nil {
+		return -1, err
+	}
+	return operation.Capacity, nil
+}
+
+// StartExpandVolume asks for the file share expansion, and returns the operation to check back on for the share
+// to be stable again. The operation is done right away when the share is already large enough
+func (vpcs *VPCSession) StartExpandVolume(expandVolumeRequest provider.ExpandVolumeRequest) (*Operation, error) {
+	vpcs.Logger.Debug("Entry of StartExpandVolume method...")
+	defer vpcs.Logger.Debug("Exit from StartExpandVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "StartExpandVolume", time.Now())
+
 	// Get volume details
 	existingVolume, err := vpcs.GetVolume(expandVolumeRequest.VolumeID)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 	// Return existing Capacity if its greater or equal to expandable size
 	if existingVolume.Capacity != nil && int64(*existingVolume.Capacity) >= expandVolumeRequest.Capacity {
 		vpcs.Logger.Warn("Requested size is less than current size.", zap.Reflect("Current Size: ", existingVolume.VolumeID), zap.Reflect("Requested Size: ", expandVolumeRequest.Capacity))
-		return int64(*existingVolume.Capacity), nil
+		operation := newOperation(ExpandVolumeOperation, expandVolumeRequest.VolumeID, StatusStable)
+		operation.Done = true
+		operation.Capacity = int64(*existingVolume.Capacity)
+		return operation, nil
 	}
 	vpcs.Logger.Info("Successfully validated inputs for ExpandVolume request... ")
 
@@ -58,22 +82,23 @@ func (vpcs *VPCSession) ExpandVolume(expandVolumeRequest provider.ExpandVolumeRe
 
 	vpcs.Logger.Info("Calling VPC provider for volume expand...")
 	var share *models.Share
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		share, err = vpcs.Apiclient.FileShareService().ExpandVolume(expandVolumeRequest.VolumeID, shareTemplate, vpcs.Logger)
 		return err
 	})
 
 	if err != nil {
 		vpcs.Logger.Debug("Failed to expand volume from VPC provider", zap.Reflect("BackendError", err))
-		return -1, userError.GetUserError("FailedToExpandVolume", err, expandVolumeRequest.VolumeID)
+		return nil, userError.GetUserError("FailedToExpandVolume", err, expandVolumeRequest.VolumeID)
 	}
 
-	vpcs.Logger.Info("Successfully accepted volume expansion request, now waiting for volume state equal to stable")
-	err = WaitForValidVolumeState(vpcs, share.ID)
-	if err != nil {
-		return -1, userError.GetUserError("VolumeNotInValidState", err, share.ID)
-	}
+	vpcs.Logger.Info("Successfully accepted volume expansion request", zap.Reflect("VolumeDetails", share))
+	operation := newOperation(ExpandVolumeOperation, share.ID, StatusStable)
+	operation.Capacity = expandVolumeRequest.Capacity
+	return operation, nil
+}
 
-	vpcs.Logger.Info("Volume got valid (stable) state", zap.Reflect("VolumeDetails", share))
-	return expandVolumeRequest.Capacity, nil
+// ExpandVolumeWithContext expands the file share, giving up on the backend calls and the wait for stable state once ctx is done
+func (vpcs *VPCSession) ExpandVolumeWithContext(ctx context.Context, expandVolumeRequest provider.ExpandVolumeRequest) (int64, error) {
+	return vpcs.withContext(ctx).ExpandVolume(expandVolumeRequest)
 }
@@ -0,0 +1,212 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVPCSession returns a session talking to a fake VPC File API, whose resources settle after 10ms.
+// The fake also serves the Global Tagging API of the session
+func fakeVPCSession(t *testing.T) (*VPCSession, *fakevpc.Server) {
+	logger, teardown := GetTestLogger(t)
+	t.Cleanup(teardown)
+	userError.MessagesEn = userError.InitMessages()
+
+	fake := fakevpc.NewServer().WithTransitionDelay(10 * time.Millisecond)
+	t.Cleanup(fake.Close)
+
+	client, err := riaas.New(riaas.Config{BaseURL: fake.URL(), GlobalTaggingURL: fake.URL(), HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+	require.NoError(t, client.Login("token"))
+
+	retryPolicy := testRetryPolicy()
+	retryPolicy.MaxAttempts = 20
+	return &VPCSession{
+		Apiclient:   client,
+		Logger:      logger,
+		RetryPolicy: retryPolicy,
+	}, fake
+}
+
+func fakeVolumeRequest(name string) provider.Volume {
+	return provider.Volume{
+		Name:     String(name),
+		Capacity: Int(10),
+		VPCVolume: provider.VPCVolume{
+			Profile:       &provider.Profile{Name: "dp2"},
+			ResourceGroup: &provider.ResourceGroup{ID: "rg1"},
+		},
+	}
+}
+
+// userErrorCode returns the code of a user error
+func userErrorCode(err error) string {
+	if message, ok := err.(util.Message); ok {
+		return message.Code
+	}
+	return ""
+}
+
+func TestVolumeLifecycleOnFakeVPC(t *testing.T) {
+	vpcs, _ := fakeVPCSession(t)
+
+	volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+	require.NoError(t, err)
+	assert.Equal(t, fakevpc.DefaultZone, volume.Az)
+
+	existing, err := vpcs.GetVolume(volume.VolumeID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, *existing.Capacity)
+	assert.Equal(t, volume.CRN, existing.CRN)
+
+	require.NoError(t, vpcs.DeleteVolume(volume))
+	_, err = vpcs.GetVolume(volume.VolumeID)
+	assert.Error(t, err)
+}
+
+func TestRetryOnFakeVPCFaults(t *testing.T) {
+	t.Run("transient errors are retried", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		internal := fake.InjectFault(fakevpc.Fault{Operation: "GetFileShare", OnCall: 1, Code: fakevpc.ErrorCodeInternalError})
+		pending := fake.InjectFault(fakevpc.Fault{Operation: "GetFileShare", OnCall: 2, Status: http.StatusConflict, Code: fakevpc.ErrorCodeStatusPending})
+
+		_, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, internal.Fired())
+		assert.Equal(t, 1, pending.Fired())
+	})
+
+	t.Run("permanent errors are not retried", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+
+		_, err := vpcs.GetVolume("r006-ffffffff-0000-4000-8000-ffffffffffff")
+		assert.Error(t, err)
+		assert.Equal(t, 1, fake.CallCount("GetFileShare"))
+	})
+
+	t.Run("throttled calls are retried after a capped Retry-After", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		throttle := fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", Times: 2, Status: http.StatusTooManyRequests, RetryAfter: time.Minute})
+
+		start := time.Now()
+		_, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		assert.Equal(t, 2, throttle.Fired())
+		assert.Equal(t, 3, fake.CallCount("CreateFileShare"))
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("dropped connections are retried", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", OnCall: 1, Drop: true})
+
+		_, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		assert.Equal(t, 2, fake.CallCount("CreateFileShare"))
+	})
+
+	t.Run("slow calls still complete", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		fake.InjectFault(fakevpc.Fault{Latency: 5 * time.Millisecond})
+
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		require.NoError(t, vpcs.DeleteVolume(volume))
+	})
+}
+
+func TestWaitLoopsOnFakeVPCFaults(t *testing.T) {
+	t.Run("volume stuck pending", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", Stuck: true})
+
+		_, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		assert.Equal(t, "VolumeNotInValidState", userErrorCode(err))
+		// The wait is bounded in time, the number of polls which fit depends on how long they take
+		assert.InDelta(t, vpcs.RetryPolicy.MaxAttempts, fake.CallCount("GetFileShare"), 2)
+	})
+
+	t.Run("volume stuck deleting", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "DeleteFileShare", Stuck: true})
+		calls := fake.CallCount("GetFileShare")
+
+		// DeleteVolume stops waiting once the polls run out, leaving the share deleting
+		assert.NoError(t, vpcs.DeleteVolume(volume))
+		assert.InDelta(t, calls+1+vpcs.RetryPolicy.MaxAttempts, fake.CallCount("GetFileShare"), 2)
+		share, err := vpcs.Apiclient.FileShareService().GetFileShare(volume.VolumeID, vpcs.Logger)
+		require.NoError(t, err)
+		assert.Equal(t, fakevpc.StateDeleting, string(share.Status))
+	})
+
+	t.Run("access point deletion", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		request := fakeVolumeRequest("fake-volume")
+		request.VPCID = "vpc1"
+		volume, err := vpcs.CreateVolume(request)
+		require.NoError(t, err)
+		require.Len(t, *volume.VolumeAccessPoints, 1)
+
+		accessPointRequest := provider.VolumeAccessPointRequest{
+			VolumeID:      volume.VolumeID,
+			AccessPointID: (*volume.VolumeAccessPoints)[0].ID,
+		}
+		_, err = vpcs.WaitForCreateVolumeAccessPoint(accessPointRequest)
+		require.NoError(t, err)
+
+		// A deletion which never completes times out
+		stuck := fake.InjectFault(fakevpc.Fault{Operation: "DeleteFileShareTarget", OnCall: 1, Stuck: true})
+		_, err = vpcs.DeleteVolumeAccessPoint(accessPointRequest)
+		require.NoError(t, err)
+		err = vpcs.WaitForDeleteVolumeAccessPoint(accessPointRequest)
+		assert.Equal(t, userError.DeleteVolumeAccessPointTimedOut, userErrorCode(err))
+		assert.Equal(t, 1, stuck.Fired())
+	})
+
+	t.Run("access point deletion with transient errors", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		request := fakeVolumeRequest("fake-volume")
+		request.VPCID = "vpc1"
+		volume, err := vpcs.CreateVolume(request)
+		require.NoError(t, err)
+
+		accessPointRequest := provider.VolumeAccessPointRequest{
+			VolumeID:      volume.VolumeID,
+			AccessPointID: (*volume.VolumeAccessPoints)[0].ID,
+		}
+		_, err = vpcs.WaitForCreateVolumeAccessPoint(accessPointRequest)
+		require.NoError(t, err)
+
+		fake.InjectFault(fakevpc.Fault{Operation: "GetFileShareTarget", Probability: 0.3, Code: fakevpc.ErrorCodeServiceError})
+		_, err = vpcs.DeleteVolumeAccessPoint(accessPointRequest)
+		require.NoError(t, err)
+		assert.NoError(t, vpcs.WaitForDeleteVolumeAccessPoint(accessPointRequest))
+	})
+}
@@ -21,9 +21,10 @@ import (
 	"errors"
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/pager"
+	vpcfile "github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	"go.uber.org/zap"
-	"net/url"
 	"strings"
 )
 
@@ -42,58 +43,37 @@ func (vpcs *VPCSession) getSecurityGroupByVPCAndSecurityGroupName(securityGroupR
 	vpcs.Logger.Debug("Entry of getSecurityGroupByVPCAndSecurityGroupName()")
 	defer vpcs.Logger.Debug("Exit from getSecurityGroupByVPCAndSecurityGroupName()")
 	vpcs.Logger.Info("Getting getSecurityGroupByVPCAndSecurityGroupName from VPC provider...")
-	var err error
-	var start = ""
 
 	filters := &models.ListSecurityGroupFilters{
 		ResourceGroupID: securityGroupRequest.ResourceGroup.ID,
 		VPCID:           securityGroupRequest.VPCID,
 	}
 
-	for {
+	securityGroupPager := vpcfile.NewSecurityGroupPager(vpcs.Apiclient.FileShareService(), pageSize, filters, vpcs.Logger)
 
-		securityGroups, err := vpcs.Apiclient.FileShareService().ListSecurityGroups(pageSize, start, filters, vpcs.Logger)
-
-		if err != nil {
-			// API call is failed
-			return "", userError.GetUserError("SecurityGroupsListFailed", err)
+	var securityGroupID string
+	err := securityGroupPager.ForEach(vpcs.requestContext(), func(securityGroupItem models.SecurityGroup) bool {
+		// Check if securityGroup is matching with requested input securityGroup name
+		if strings.EqualFold(securityGroupRequest.Name, securityGroupItem.Name) {
+			vpcs.Logger.Info("Successfully found securityGroup", zap.Reflect("securityGroupItem", securityGroupItem))
+			securityGroupID = securityGroupItem.ID
+			return false
 		}
-
-		// Iterate over the SecurityGroup list for given volume
-		if securityGroups != nil {
-			for _, securityGroupItem := range securityGroups.SecurityGroups {
-				// Check if securityGroup is matching with requested input securityGroup name
-				if strings.EqualFold(securityGroupRequest.Name, securityGroupItem.Name) {
-					vpcs.Logger.Info("Successfully found securityGroup", zap.Reflect("securityGroupItem", securityGroupItem))
-					return securityGroupItem.ID, nil
-				}
-			}
-
-			if securityGroups.Next == nil {
-				break // No more pages, exit the loop
-			}
-
-			// Fetch the start of next page
-			startUrl, err := url.Parse(securityGroups.Next.Href)
-			if err != nil {
-				// API call is failed
-				vpcs.Logger.Warn("The next parameter of the securityGroup list could not be parsed.", zap.Reflect("Next", securityGroups.Next.Href), zap.Error(err))
-				return "", userError.GetUserError(string("SecurityGroupFindFailed"), err, securityGroupRequest.Name)
-			}
-
-			vpcs.Logger.Info("startUrl", zap.Reflect("startUrl", startUrl))
-			start = startUrl.Query().Get("start") //parse query param into map
-			if start == "" {
-				// API call is failed
-				vpcs.Logger.Warn("The start specified in the next parameter of the securityGroup list is empty.", zap.Reflect("startUrl", startUrl))
-				return "", userError.GetUserError(string("SecurityGroupFindFailed"), errors.New("no securityGroup found"), securityGroupRequest.Name)
-			}
-		} else {
-			return "", userError.GetUserError(string("SecurityGroupsListFailed"), errors.New("SecurityGroup list is empty"))
+		return true
+	})
+	if securityGroupID != "" {
+		return securityGroupID, nil
+	}
+	if err != nil {
+		if errors.Is(err, pager.ErrInvalidNext) || errors.Is(err, pager.ErrPageLoop) {
+			vpcs.Logger.Warn("The next parameter of the securityGroup list could not be followed.", zap.Error(err))
+			return "", userError.GetUserError(string("SecurityGroupFindFailed"), err, securityGroupRequest.Name)
 		}
+		// API call is failed
+		return "", userError.GetUserError("SecurityGroupsListFailed", err)
 	}
 
 	// No volume SecurityGroup found in the  list. So return error
-	vpcs.Logger.Error("SecurityGroup not found", zap.Error(err))
+	vpcs.Logger.Error("SecurityGroup not found")
 	return "", userError.GetUserError(string("SecurityGroupFindFailed"), errors.New("no securityGroup found"), securityGroupRequest.Name)
 }
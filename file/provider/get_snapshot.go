@@ -18,6 +18,8 @@
 package provider
 
 import (
+	"context"
+
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
@@ -37,7 +39,7 @@ func (vpcs *VPCSession) GetSnapshot(snapshotID string, sourceVolumeID ...string)
 
 	var snapshot *models.Snapshot
 	var err error
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		snapshot, err = vpcs.Apiclient.SnapshotService().GetSnapshot(sourceVolumeID[0], snapshotID, vpcs.Logger)
 		return err
 	})
@@ -52,6 +54,11 @@ func (vpcs *VPCSession) GetSnapshot(snapshotID string, sourceVolumeID ...string)
 	return snapshotResponse, err
 }
 
+// GetSnapshotWithContext gets snapshot, giving up on the backend calls once ctx is done
+func (vpcs *VPCSession) GetSnapshotWithContext(ctx context.Context, snapshotID string, sourceVolumeID ...string) (*provider.Snapshot, error) {
+	return vpcs.withContext(ctx).GetSnapshot(snapshotID, sourceVolumeID...)
+}
+
 // GetSnapshotByName ...
 func (vpcs *VPCSession) GetSnapshotByName(name string, sourceVolumeID ...string) (respSnap *provider.Snapshot, err error) {
 	vpcs.Logger.Debug("Entry of GetSnapshotByName method...")
@@ -70,7 +77,7 @@ func (vpcs *VPCSession) GetSnapshotByName(name string, sourceVolumeID ...string)
 	vpcs.Logger.Info("Getting snapshot details from VPC provider...", zap.Reflect("SnapshotName", name))
 
 	var snapshot *models.Snapshot
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		snapshot, err = vpcs.Apiclient.SnapshotService().GetSnapshotByName(sourceVolumeID[0], name, vpcs.Logger)
 		return err
 	})
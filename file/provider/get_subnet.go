@@ -21,9 +21,10 @@ import (
 	"errors"
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/pager"
+	vpcfile "github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	"go.uber.org/zap"
-	"net/url"
 	"strings"
 )
 
@@ -42,8 +43,6 @@ func (vpcs *VPCSession) getSubnetByZoneAndSubnetID(subnetRequest provider.Subnet
 	vpcs.Logger.Debug("Entry of getSubnetByVPCIDAndZone()")
 	defer vpcs.Logger.Debug("Exit from getSubnetByVPCIDAndZone()")
 	vpcs.Logger.Info("Getting getSubnetByVPCIDAndZone from VPC provider...")
-	var err error
-	var start = ""
 
 	filters := &models.ListSubnetFilters{
 		ResourceGroupID: subnetRequest.ResourceGroup.ID,
@@ -51,50 +50,31 @@ func (vpcs *VPCSession) getSubnetByZoneAndSubnetID(subnetRequest provider.Subnet
 		ZoneName:        subnetRequest.ZoneName,
 	}
 
-	for {
+	subnetPager := vpcfile.NewSubnetPager(vpcs.Apiclient.FileShareService(), pageSize, filters, vpcs.Logger)
 
-		subnets, err := vpcs.Apiclient.FileShareService().ListSubnets(pageSize, start, filters, vpcs.Logger)
-
-		if err != nil {
-			// API call is failed
-			return "", userError.GetUserError("SubnetsListFailed", err)
+	var subnetID string
+	err := subnetPager.ForEach(vpcs.requestContext(), func(subnetItem models.Subnet) bool {
+		// Check if subnet is matching with requested input subnet-list
+		if strings.Contains(subnetRequest.SubnetIDList, subnetItem.ID) {
+			vpcs.Logger.Info("Successfully found subnet", zap.Reflect("subnetItem", subnetItem))
+			subnetID = subnetItem.ID
+			return false
 		}
-
-		// Iterate over the subnet list for given volume
-		if subnets != nil {
-			for _, subnetItem := range subnets.Subnets {
-				// Check if subnet is matching with requested input subnet-list
-				if strings.Contains(subnetRequest.SubnetIDList, subnetItem.ID) {
-					vpcs.Logger.Info("Successfully found subnet", zap.Reflect("subnetItem", subnetItem))
-					return subnetItem.ID, nil
-				}
-			}
-
-			if subnets.Next == nil {
-				break // No more pages, exit the loop
-			}
-
-			// Fetch the start of next page
-			startUrl, err := url.Parse(subnets.Next.Href)
-			if err != nil {
-				// API call is failed
-				vpcs.Logger.Warn("The next parameter of the subnet list could not be parsed.", zap.Reflect("Next", subnets.Next.Href), zap.Error(err))
-				return "", userError.GetUserError(string("SubnetFindFailed"), err, subnetRequest.ZoneName, subnetRequest.SubnetIDList)
-			}
-
-			vpcs.Logger.Info("startUrl", zap.Reflect("startUrl", startUrl))
-			start = startUrl.Query().Get("start") //parse query param into map
-			if start == "" {
-				// API call is failed
-				vpcs.Logger.Warn("The start specified in the next parameter of the subnet list is empty.", zap.Reflect("start", startUrl))
-				return "", userError.GetUserError(string("SubnetFindFailed"), errors.New("no subnet found"), subnetRequest.ZoneName, subnetRequest.SubnetIDList)
-			}
-		} else {
-			return "", userError.GetUserError(string("SubnetsListFailed"), errors.New("Subnet list is empty"))
+		return true
+	})
+	if subnetID != "" {
+		return subnetID, nil
+	}
+	if err != nil {
+		if errors.Is(err, pager.ErrInvalidNext) || errors.Is(err, pager.ErrPageLoop) {
+			vpcs.Logger.Warn("The next parameter of the subnet list could not be followed.", zap.Error(err))
+			return "", userError.GetUserError(string("SubnetFindFailed"), err, subnetRequest.ZoneName, subnetRequest.SubnetIDList)
 		}
+		// API call is failed
+		return "", userError.GetUserError("SubnetsListFailed", err)
 	}
 
 	// No volume Subnet found in the  list. So return error
-	vpcs.Logger.Error("Subnet not found", zap.Error(err))
+	vpcs.Logger.Error("Subnet not found")
 	return "", userError.GetUserError(string("SubnetFindFailed"), errors.New("no subnet found"), subnetRequest.ZoneName, subnetRequest.SubnetIDList)
 }
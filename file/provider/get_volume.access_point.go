@@ -37,30 +37,38 @@ func (vpcs *VPCSession) GetVolumeAccessPoint(volumeAccessPointRequest provider.V
 		return nil, err
 	}
 	var volumeAccessPointResponse *provider.VolumeAccessPointResponse
+	volumeAccessPointResult, err := vpcs.findVolumeAccessPoint(volumeAccessPointRequest)
+	if err == nil {
+		volumeAccessPointResponse = volumeAccessPointResult.ToVolumeAccessPointResponse()
+		volumeAccessPointResponse.VolumeID = volumeAccessPointRequest.VolumeID
+	}
+	vpcs.Logger.Info("Volume access point response", zap.Reflect("volumeAccessPointResponse", volumeAccessPointResponse), zap.Error(err))
+	return volumeAccessPointResponse, err
+}
+
+// findVolumeAccessPoint gets the file share target of a validated request, by target ID when it is specified, else by VPC ID
+func (vpcs *VPCSession) findVolumeAccessPoint(volumeAccessPointRequest provider.VolumeAccessPointRequest) (*models.ShareTarget, error) {
 	volumeAccessPoint := models.NewShareTarget(volumeAccessPointRequest)
 	if len(volumeAccessPoint.ID) > 0 {
 		//Get volume AccessPoint by target ID if it is specified
-		volumeAccessPointResponse, err = vpcs.getVolumeAccessPointByID(volumeAccessPoint)
-	} else {
-		// Get volume AccessPoint by VPC ID. This is inefficient operation which requires iteration over volume target list
-		volumeAccessPointResponse, err = vpcs.getVolumeAccessPointByVPCID(volumeAccessPoint)
+		return vpcs.getVolumeAccessPointByID(volumeAccessPoint)
 	}
-	vpcs.Logger.Info("Volume access point response", zap.Reflect("volumeAccessPointResponse", volumeAccessPointResponse), zap.Error(err))
-	return volumeAccessPointResponse, err
+	// Get volume AccessPoint by VPC ID. This is inefficient operation which requires iteration over volume target list
+	return vpcs.getVolumeAccessPointByVPCID(volumeAccessPoint)
 }
 
-func (vpcs *VPCSession) getVolumeAccessPointByID(volumeAccessPointRequest models.ShareTarget) (*provider.VolumeAccessPointResponse, error) {
+func (vpcs *VPCSession) getVolumeAccessPointByID(volumeAccessPointRequest models.ShareTarget) (*models.ShareTarget, error) {
 	vpcs.Logger.Debug("Entry of getVolumeAccessPointByID()")
 	defer vpcs.Logger.Debug("Exit from getVolumeAccessPointByID()")
 	vpcs.Logger.Info("Getting VolumeAccessPoint from VPC provider...")
 	var err error
 	var volumeAccessPointResult *models.ShareTarget
 
-	err = vpcs.APIRetry.FlexyRetry(vpcs.Logger, func() (error, bool) {
+	err = vpcs.flexyRetry(func() (error, bool) {
 		volumeAccessPointResult, err = vpcs.Apiclient.FileShareService().GetFileShareTarget(volumeAccessPointRequest.ShareID, volumeAccessPointRequest.ID, vpcs.Logger)
 		// Keep retry, until we get the proper volumeAccessPointResponse object
 		if err != nil && volumeAccessPointResult == nil {
-			return err, skipRetryForObviousErrors(err)
+			return err, vpcs.GetRetryPolicy().SkipRetry(err)
 		}
 		return err, true // stop retry as no error
 	})
@@ -71,24 +79,21 @@ func (vpcs *VPCSession) getVolumeAccessPointByID(volumeAccessPointRequest models
 		return nil, userErr
 	}
 
-	volumeAccessPointResponse := volumeAccessPointResult.ToVolumeAccessPointResponse()
-	volumeAccessPointResponse.VolumeID = volumeAccessPointRequest.ShareID
-
-	vpcs.Logger.Info("Successfully retrieved volume AccessPoint", zap.Reflect("volumeAccessPointResponse", volumeAccessPointResponse))
-	return volumeAccessPointResponse, err
+	vpcs.Logger.Info("Successfully retrieved volume AccessPoint", zap.Reflect("volumeAccessPoint", volumeAccessPointResult))
+	return volumeAccessPointResult, err
 }
 
-func (vpcs *VPCSession) getVolumeAccessPointByVPCID(volumeAccessPointRequest models.ShareTarget) (*provider.VolumeAccessPointResponse, error) {
+func (vpcs *VPCSession) getVolumeAccessPointByVPCID(volumeAccessPointRequest models.ShareTarget) (*models.ShareTarget, error) {
 	vpcs.Logger.Debug("Entry of getVolumeAccessPointByVPCID()")
 	defer vpcs.Logger.Debug("Exit from getVolumeAccessPointByVPCID()")
 	vpcs.Logger.Info("Getting VolumeTargetList from VPC provider...")
 	var volumeAccessPointList *models.ShareTargetList
 	var err error
-	err = vpcs.APIRetry.FlexyRetry(vpcs.Logger, func() (error, bool) {
+	err = vpcs.flexyRetry(func() (error, bool) {
 		volumeAccessPointList, err = vpcs.Apiclient.FileShareService().ListFileShareTargets(volumeAccessPointRequest.ShareID, nil, vpcs.Logger)
 		// Keep retry, until we get the proper volumeAccessPointResponse object
 		if err != nil {
-			return err, skipRetryForObviousErrors(err)
+			return err, vpcs.GetRetryPolicy().SkipRetry(err)
 		}
 		return err, true // stop retry as no error
 	})
@@ -104,11 +109,7 @@ func (vpcs *VPCSession) getVolumeAccessPointByVPCID(volumeAccessPointRequest mod
 			// Check if VPC ID is matching with requested VPC ID in volume target list
 			if volumeAccessPointItem.VPC != nil && volumeAccessPointItem.VPC.ID == volumeAccessPointRequest.VPC.ID {
 				vpcs.Logger.Info("Successfully found volume AccessPoint", zap.Reflect("volumeAccessPoint", volumeAccessPointItem))
-				volumeAccessPointResponse := volumeAccessPointItem.ToVolumeAccessPointResponse()
-				volumeAccessPointResponse.VolumeID = volumeAccessPointRequest.ShareID
-
-				vpcs.Logger.Info("Successfully fetched volume AccessPoint from VPC provider", zap.Reflect("volumeTargetResponse", volumeAccessPointResponse))
-				return volumeAccessPointResponse, nil
+				return volumeAccessPointItem, nil
 			}
 		}
 	}
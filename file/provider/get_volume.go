@@ -39,7 +39,7 @@ func (vpcs *VPCSession) GetVolume(id string) (respVolume *provider.Volume, err e
 	vpcs.Logger.Info("Getting volume details from VPC provider...", zap.Reflect("VolumeID", id))
 
 	var volume *models.Share
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		volume, err = vpcs.Apiclient.FileShareService().GetFileShare(id, vpcs.Logger)
 		return err
 	})
@@ -69,7 +69,7 @@ func (vpcs *VPCSession) GetVolumeByName(name string) (respVolume *provider.Volum
 	vpcs.Logger.Info("Getting volume details from VPC provider...", zap.Reflect("VolumeName", name))
 
 	var volume *models.Share
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		volume, err = vpcs.Apiclient.FileShareService().GetFileShareByName(name, vpcs.Logger)
 		return err
 	})
@@ -0,0 +1,111 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	vpcfile "github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// ListAllVolumes lists every file share matching tags, following the pages of the collection.
+// Supported tags are the same as for ListVolumes
+func (vpcs *VPCSession) ListAllVolumes(ctx context.Context, tags map[string]string) ([]*provider.Volume, error) {
+	vpcs.Logger.Info("Entry ListAllVolumes", zap.Reflect("filters", tags))
+	defer vpcs.Logger.Info("Exit ListAllVolumes", zap.Reflect("filters", tags))
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "ListAllVolumes", time.Now())
+
+	scoped := vpcs.withContext(ctx)
+	filters := &models.ListShareFilters{
+		ResourceGroupID: tags["resource_group.id"],
+		ShareName:       tags["name"],
+	}
+
+	sharePager := vpcfile.NewFileSharePager(scoped.Apiclient.FileShareService(), maxLimit, filters, scoped.Logger).WithRetry(scoped.retry)
+
+	var volumes []*provider.Volume
+	err := sharePager.ForEach(ctx, func(share *models.Share) bool {
+		volumes = append(volumes, FromProviderToLibVolume(share, vpcs.Logger))
+		return true
+	})
+	if err != nil {
+		return nil, userError.GetUserError("ListVolumesFailed", err)
+	}
+
+	vpcs.Logger.Info("Successfully retrieved all volumes", zap.Int("count", len(volumes)))
+	return volumes, nil
+}
+
+// ListAllSnapshots lists every snapshot matching filters, following the pages of the collection.
+// Supported filters are the same as for ListSnapshots
+func (vpcs *VPCSession) ListAllSnapshots(ctx context.Context, filters map[string]string) ([]*provider.Snapshot, error) {
+	vpcs.Logger.Info("Entry ListAllSnapshots", zap.Reflect("filters", filters))
+	defer vpcs.Logger.Info("Exit ListAllSnapshots", zap.Reflect("filters", filters))
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "ListAllSnapshots", time.Now())
+
+	sourceVolumeID := filters["source_volume.id"]
+	shareSnapshots, err := vpcs.withContext(ctx).listAllShareSnapshots(sourceVolumeID, &models.LisSnapshotFilters{Name: filters["name"]})
+	if err != nil {
+		return nil, userError.GetUserError("ListSnapshotsFailed", err, sourceVolumeID)
+	}
+
+	var snapshots []*provider.Snapshot
+	for _, snapshot := range shareSnapshots {
+		snapshots = append(snapshots, FromProviderToLibSnapshot(sourceVolumeID, snapshot, vpcs.Logger))
+	}
+
+	vpcs.Logger.Info("Successfully retrieved all snapshots", zap.Int("count", len(snapshots)))
+	return snapshots, nil
+}
+
+// listAllShareSnapshots lists every snapshot of the file share sourceVolumeID matching filter as the backend
+// returns them, following the pages of the collection. It gives up once the session context is done
+func (vpcs *VPCSession) listAllShareSnapshots(sourceVolumeID string, filter *models.LisSnapshotFilters) ([]*models.Snapshot, error) {
+	snapshotPager := vpcfile.NewSnapshotPager(vpcs.Apiclient.SnapshotService(), sourceVolumeID, maxLimit, filter, vpcs.Logger).WithRetry(vpcs.retry)
+	return snapshotPager.All(vpcs.requestContext())
+}
+
+// ListAllVolumeAccessPoints lists every mount target of the file share volumeID, following the pages of the collection
+func (vpcs *VPCSession) ListAllVolumeAccessPoints(ctx context.Context, volumeID string) ([]*provider.VolumeAccessPointResponse, error) {
+	vpcs.Logger.Info("Entry ListAllVolumeAccessPoints", zap.String("volumeID", volumeID))
+	defer vpcs.Logger.Info("Exit ListAllVolumeAccessPoints", zap.String("volumeID", volumeID))
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "ListAllVolumeAccessPoints", time.Now())
+
+	scoped := vpcs.withContext(ctx)
+	targetPager := vpcfile.NewShareTargetPager(scoped.Apiclient.FileShareService(), volumeID, maxLimit, nil, scoped.Logger).WithRetry(scoped.retry)
+
+	var accessPoints []*provider.VolumeAccessPointResponse
+	err := targetPager.ForEach(ctx, func(target *models.ShareTarget) bool {
+		accessPoint := target.ToVolumeAccessPointResponse()
+		accessPoint.VolumeID = volumeID
+		accessPoints = append(accessPoints, accessPoint)
+		return true
+	})
+	if err != nil {
+		return nil, userError.GetUserError("ListVolumeAccessPointsFailed", err, volumeID)
+	}
+
+	vpcs.Logger.Info("Successfully retrieved all volume access points", zap.Int("count", len(accessPoints)))
+	return accessPoints, nil
+}
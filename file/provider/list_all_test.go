@@ -0,0 +1,149 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	fileShareServiceFakes "github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume/fakes"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestListAllVolumes(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+
+	pages := map[string]*models.ShareList{
+		"": {
+			Shares: []*models.Share{{ID: "share1", Size: 10}, {ID: "share2", Size: 10}},
+			Next:   &models.HReference{Href: "https://eu-gb.iaas.cloud.ibm.com/v1/shares?start=r018-2&limit=100&name=eu-gb-1"},
+		},
+		"r018-2": {
+			Shares: []*models.Share{{ID: "share3", Size: 10}},
+		},
+	}
+
+	testCases := []struct {
+		testCaseName string
+		listErr      error
+		expectedIDs  []string
+		expectedErr  string
+	}{
+		{
+			testCaseName: "All pages are listed",
+			expectedIDs:  []string{"share1", "share2", "share3"},
+		}, {
+			testCaseName: "Backend failure",
+			listErr:      modelError("shares_not_found"),
+			expectedErr:  "Code:shares_not_found",
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.testCaseName, func(t *testing.T) {
+			vpcs, uc, _, err := GetTestOpenSession(t, logger)
+			assert.Nil(t, err)
+
+			volumeService := &fileShareServiceFakes.FileShareService{}
+			uc.FileShareServiceReturns(volumeService)
+			volumeService.ListFileSharesStub = func(limit int, start string, filters *models.ListShareFilters, ctxLogger *zap.Logger) (*models.ShareList, error) {
+				assert.Equal(t, maxLimit, limit)
+				assert.Equal(t, "rg1", filters.ResourceGroupID)
+				if testcase.listErr != nil {
+					return nil, testcase.listErr
+				}
+				return pages[start], nil
+			}
+
+			volumes, err := vpcs.ListAllVolumes(context.Background(), map[string]string{"resource_group.id": "rg1"})
+			if testcase.expectedErr != "" {
+				assert.Nil(t, volumes)
+				if assert.NotNil(t, err) {
+					assert.Contains(t, err.Error(), testcase.expectedErr)
+				}
+				return
+			}
+			assert.Nil(t, err)
+			var ids []string
+			for _, volume := range volumes {
+				ids = append(ids, volume.VolumeID)
+			}
+			assert.Equal(t, testcase.expectedIDs, ids)
+			assert.Equal(t, 2, volumeService.ListFileSharesCallCount())
+		})
+	}
+}
+
+func TestListAllSnapshots(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+
+	vpcs, uc, _, err := GetTestOpenSession(t, logger)
+	assert.Nil(t, err)
+
+	snapshotService := &fileShareServiceFakes.SnapshotManager{}
+	uc.SnapshotServiceReturns(snapshotService)
+	snapshotService.ListSnapshotsStub = func(shareID string, limit int, start string, filters *models.LisSnapshotFilters, ctxLogger *zap.Logger) (*models.SnapshotList, error) {
+		assert.Equal(t, "share1", shareID)
+		if start == "" {
+			return &models.SnapshotList{
+				Snapshots: []*models.Snapshot{{ID: "snap1"}},
+				Next:      &models.HReference{Href: "https://eu-gb.iaas.cloud.ibm.com/v1/shares/share1/snapshots?limit=100&start=r018-2"},
+			}, nil
+		}
+		return &models.SnapshotList{Snapshots: []*models.Snapshot{{ID: "snap2"}}}, nil
+	}
+
+	snapshots, err := vpcs.ListAllSnapshots(context.Background(), map[string]string{"source_volume.id": "share1"})
+	assert.Nil(t, err)
+	if assert.Len(t, snapshots, 2) {
+		assert.Equal(t, "snap1", snapshots[0].SnapshotID)
+		assert.Equal(t, "snap2", snapshots[1].SnapshotID)
+	}
+}
+
+func TestListAllVolumeAccessPoints(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+
+	vpcs, uc, _, err := GetTestOpenSession(t, logger)
+	assert.Nil(t, err)
+
+	volumeService := &fileShareServiceFakes.FileShareService{}
+	uc.FileShareServiceReturns(volumeService)
+	volumeService.ListFileShareTargetsStub = func(shareID string, filters *models.ListShareTargetFilters, ctxLogger *zap.Logger) (*models.ShareTargetList, error) {
+		assert.Equal(t, maxLimit, filters.Limit)
+		if filters.Start == "" {
+			return &models.ShareTargetList{
+				ShareTargets: []*models.ShareTarget{{ID: "target1"}},
+				Next:         &models.HReference{Href: "https://eu-gb.iaas.cloud.ibm.com/v1/shares/share1/mount_targets?start=r018-2"},
+			}, nil
+		}
+		return &models.ShareTargetList{ShareTargets: []*models.ShareTarget{{ID: "target2"}}}, nil
+	}
+
+	accessPoints, err := vpcs.ListAllVolumeAccessPoints(context.Background(), "share1")
+	assert.Nil(t, err)
+	if assert.Len(t, accessPoints, 2) {
+		assert.Equal(t, "target1", accessPoints[0].AccessPointID)
+		assert.Equal(t, "share1", accessPoints[1].VolumeID)
+	}
+}
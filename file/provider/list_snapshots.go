@@ -24,6 +24,7 @@ import (
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/pager"
 	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	"go.uber.org/zap"
@@ -56,7 +57,7 @@ func (vpcs *VPCSession) ListSnapshots(limit int, start string, filters map[strin
 
 	var snapshots *models.SnapshotList
 	var err error
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		snapshots, err = vpcs.Apiclient.SnapshotService().ListSnapshots(sourceVolumeID, limit, start, filter, vpcs.Logger)
 		return err
 	})
@@ -72,15 +73,11 @@ func (vpcs *VPCSession) ListSnapshots(limit int, start string, filters map[strin
 
 	var respSnapshotList = &provider.SnapshotList{}
 	if snapshots != nil {
-		if snapshots.Next != nil {
-			var next string
-			if strings.Contains(snapshots.Next.Href, "start=") {
-				next = strings.Split(strings.Split(snapshots.Next.Href, "start=")[1], "\u0026")[0]
-			} else {
-				vpcs.Logger.Warn("snapshots.Next.Href is not in expected format", zap.Reflect("snapshots.Next.Href", snapshots.Next.Href))
-			}
-			respSnapshotList.Next = next
+		next, nextErr := pager.NextStart(snapshots.Next)
+		if nextErr != nil {
+			vpcs.Logger.Warn("snapshots.Next.Href is not in expected format", zap.Reflect("snapshots.Next", snapshots.Next), zap.Error(nextErr))
 		}
+		respSnapshotList.Next = next
 
 		snapshotslist := snapshots.Snapshots
 		for _, snapItem := range snapshotslist {
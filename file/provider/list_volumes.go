@@ -24,6 +24,7 @@ import (
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/pager"
 	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	"go.uber.org/zap"
@@ -58,7 +59,7 @@ func (vpcs *VPCSession) ListVolumes(limit int, start string, tags map[string]str
 
 	var volumes *models.ShareList
 	var err error
-	err = retry(vpcs.Logger, func() error {
+	err = vpcs.retry(func() error {
 		volumes, err = vpcs.Apiclient.FileShareService().ListFileShares(limit, start, filters, vpcs.Logger)
 		return err
 	})
@@ -74,16 +75,11 @@ func (vpcs *VPCSession) ListVolumes(limit int, start string, tags map[string]str
 
 	var respVolumesList = &provider.VolumeList{}
 	if volumes != nil {
-		if volumes.Next != nil {
-			var next string
-			// "Next":{"href":"https://eu-gb.iaas.cloud.ibm.com/v1/volumes?start=3e898aa7-ac71-4323-952d-a8d741c65a68\u0026limit=1\u0026name=eu-gb-1"}
-			if strings.Contains(volumes.Next.Href, "start=") {
-				next = strings.Split(strings.Split(volumes.Next.Href, "start=")[1], "\u0026")[0]
-			} else {
-				vpcs.Logger.Warn("Volumes.Next.Href is not in expected format", zap.Reflect("volumes.Next.Href", volumes.Next.Href))
-			}
-			respVolumesList.Next = next
+		next, nextErr := pager.NextStart(volumes.Next)
+		if nextErr != nil {
+			vpcs.Logger.Warn("Volumes.Next.Href is not in expected format", zap.Reflect("volumes.Next", volumes.Next), zap.Error(nextErr))
 		}
+		respVolumesList.Next = next
 
 		volumeslist := volumes.Shares
 		if len(volumeslist) > 0 {
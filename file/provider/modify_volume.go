@@ -0,0 +1,196 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"go.uber.org/zap"
+)
+
+// ModifyVolumeRequest is the change of an existing file share. Fields left empty keep their current value
+type ModifyVolumeRequest struct {
+	VolumeID string
+	// Name renames the file share
+	Name string
+	// Profile moves the file share to another share profile, e.g. from dp2 to rfs
+	Profile string
+	// Iops is the new max IOPS of the file share, it must be allowed by the share profile
+	Iops int64
+	// Bandwidth is the new max bandwidth of the file share, in megabits per second
+	Bandwidth int32
+}
+
+// ModifyVolume changes the name, profile, IOPS or bandwidth of the file share, and waits for it to be stable again
+func (vpcs *VPCSession) ModifyVolume(modifyVolumeRequest ModifyVolumeRequest) error {
+	vpcs.Logger.Debug("Entry of ModifyVolume method...")
+	defer vpcs.Logger.Debug("Exit from ModifyVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "ModifyVolume", time.Now())
+
+	operation, err := vpcs.StartModifyVolume(modifyVolumeRequest)
+	if err != nil {
+		return err
+	}
+
+	err = vpcs.WaitOperation(operation)
+	if err != nil {
+		return err
+	}
+
+	vpcs.Logger.Info("Successfully modified volume", zap.Reflect("VolumeID", modifyVolumeRequest.VolumeID))
+	return nil
+}
+
+// StartModifyVolume validates the change against the share profile and asks for it, guarded by the ETag of the
+// share, then returns the operation to check back on for the share to be stable again. The operation is done
+// right away when the share already is as requested
+func (vpcs *VPCSession) StartModifyVolume(modifyVolumeRequest ModifyVolumeRequest) (*Operation, error) {
+	vpcs.Logger.Debug("Entry of StartModifyVolume method...")
+	defer vpcs.Logger.Debug("Exit from StartModifyVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "StartModifyVolume", time.Now())
+
+	vpcs.Logger.Info("Validating basic inputs for ModifyVolume method...", zap.Reflect("ModifyVolumeRequest", modifyVolumeRequest))
+	if !IsValidVolumeIDFormat(modifyVolumeRequest.VolumeID) {
+		return nil, userError.GetUserError("InvalidVolumeID", nil, modifyVolumeRequest.VolumeID)
+	}
+	if modifyVolumeRequest.Iops < 0 || modifyVolumeRequest.Bandwidth < 0 {
+		return nil, userError.GetUserError("InvalidModifyVolumeRequest", nil, modifyVolumeRequest.VolumeID, "IOPS and bandwidth cannot be negative")
+	}
+
+	// The profile is fetched once, ahead of the retries on concurrent updates
+	profile := vpcs.modifyTargetProfile(modifyVolumeRequest)
+
+	var shareTemplate *models.Share
+	var invalidErr error
+	err := vpcs.flexyRetry(func() (error, bool) {
+		existShare, etag, err := vpcs.Apiclient.FileShareService().GetFileShareEtag(modifyVolumeRequest.VolumeID, vpcs.Logger)
+		if err != nil {
+			return err, vpcs.GetRetryPolicy().SkipRetry(err)
+		}
+		if existShare.Status != StatusStable {
+			return userError.GetUserError("VolumeNotInValidState", nil, modifyVolumeRequest.VolumeID), false
+		}
+
+		shareTemplate = modifyTemplate(existShare, modifyVolumeRequest)
+		if shareTemplate == nil {
+			vpcs.Logger.Info("There is no change for volume, skipping the modification... ", zap.Reflect("existShare", existShare))
+			return nil, true
+		}
+
+		invalidErr = validateModifyRequest(existShare, profile, modifyVolumeRequest)
+		if invalidErr != nil {
+			return invalidErr, true
+		}
+
+		// The ETag changes with every update, so a concurrent update fails the call and the next attempt starts over
+		vpcs.Logger.Info("Calling VPC provider for volume modification...", zap.Reflect("ShareTemplate", shareTemplate))
+		err = vpcs.Apiclient.FileShareService().UpdateFileShareWithEtag(modifyVolumeRequest.VolumeID, etag, shareTemplate, vpcs.Logger)
+		if err != nil {
+			return err, vpcs.GetRetryPolicy().SkipRetry(err)
+		}
+		return nil, true
+	})
+
+	if invalidErr != nil {
+		return nil, invalidErr
+	}
+	if err != nil {
+		vpcs.Logger.Error("Failed to modify volume from VPC provider", zap.Reflect("BackendError", err))
+		return nil, userError.GetUserError("FailedToModifyVolume", err, modifyVolumeRequest.VolumeID)
+	}
+
+	operation := newOperation(ModifyVolumeOperation, modifyVolumeRequest.VolumeID, StatusStable)
+	operation.Done = shareTemplate == nil
+	vpcs.Logger.Info("Successfully accepted volume modification request", zap.Reflect("VolumeID", modifyVolumeRequest.VolumeID))
+	return operation, nil
+}
+
+// ModifyVolumeWithContext modifies the file share, giving up on the backend calls and the wait for stable state once ctx is done
+func (vpcs *VPCSession) ModifyVolumeWithContext(ctx context.Context, modifyVolumeRequest ModifyVolumeRequest) error {
+	return vpcs.withContext(ctx).ModifyVolume(modifyVolumeRequest)
+}
+
+// modifyTargetProfile returns the share profile the file share has once modified, to validate the request against.
+// It is nil when the request changes nothing the profile constrains, or when the profile cannot be fetched: as for
+// CreateVolume, the request is not checked then and the backend still rejects it if need be
+func (vpcs *VPCSession) modifyTargetProfile(modifyVolumeRequest ModifyVolumeRequest) *models.ProfileDetails {
+	if modifyVolumeRequest.Profile == "" && modifyVolumeRequest.Iops == 0 && modifyVolumeRequest.Bandwidth == 0 {
+		return nil
+	}
+
+	profileName := modifyVolumeRequest.Profile
+	if profileName == "" {
+		// A single attempt, the update itself reports a share which cannot be fetched
+		existShare, err := vpcs.Apiclient.FileShareService().GetFileShare(modifyVolumeRequest.VolumeID, vpcs.Logger)
+		if err != nil || existShare == nil || existShare.Profile == nil {
+			vpcs.Logger.Warn("Failed to get the share profile of the volume, skipping the validation of the request against it", zap.Reflect("VolumeID", modifyVolumeRequest.VolumeID), zap.Error(err))
+			return nil
+		}
+		profileName = existShare.Profile.Name
+	}
+
+	profile, err := vpcs.GetShareProfileDetails(profileName)
+	if err != nil || profile == nil {
+		vpcs.Logger.Warn("Failed to get the share profile, skipping the validation of the request against it", zap.String("profile", profileName), zap.Error(err))
+		return nil
+	}
+	return profile
+}
+
+// modifyTemplate builds the patch of the fields of existShare which the request changes, nil when there are none
+func modifyTemplate(existShare *models.Share, modifyVolumeRequest ModifyVolumeRequest) *models.Share {
+	shareTemplate := &models.Share{}
+	changed := false
+	if modifyVolumeRequest.Name != "" && modifyVolumeRequest.Name != existShare.Name {
+		shareTemplate.Name = modifyVolumeRequest.Name
+		changed = true
+	}
+	if modifyVolumeRequest.Profile != "" && (existShare.Profile == nil || modifyVolumeRequest.Profile != existShare.Profile.Name) {
+		shareTemplate.Profile = &models.Profile{Name: modifyVolumeRequest.Profile}
+		changed = true
+	}
+	if modifyVolumeRequest.Iops != 0 && modifyVolumeRequest.Iops != existShare.Iops {
+		shareTemplate.Iops = modifyVolumeRequest.Iops
+		changed = true
+	}
+	if modifyVolumeRequest.Bandwidth != 0 && modifyVolumeRequest.Bandwidth != existShare.Bandwidth {
+		shareTemplate.Bandwidth = modifyVolumeRequest.Bandwidth
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return shareTemplate
+}
+
+// validateModifyRequest checks the requested IOPS and bandwidth, and the size of the share when moving it to
+// another profile, against the allowed values of the target profile
+func validateModifyRequest(existShare *models.Share, profile *models.ProfileDetails, modifyVolumeRequest ModifyVolumeRequest) error {
+	if profile == nil {
+		return nil
+	}
+	var capacity int64
+	if modifyVolumeRequest.Profile != "" {
+		capacity = existShare.Size
+	}
+	return validateShareProfileValues(profile, capacity, modifyVolumeRequest.Iops, modifyVolumeRequest.Bandwidth)
+}
@@ -0,0 +1,135 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifyVolume(t *testing.T) {
+	t.Run("iops, bandwidth and name", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+
+		err = vpcs.ModifyVolume(ModifyVolumeRequest{VolumeID: volume.VolumeID, Name: "renamed", Iops: 1000, Bandwidth: 200})
+		require.NoError(t, err)
+		share, err := vpcs.Apiclient.FileShareService().GetFileShare(volume.VolumeID, vpcs.Logger)
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", share.Name)
+		assert.Equal(t, int64(1000), share.Iops)
+		assert.Equal(t, int32(200), share.Bandwidth)
+		assert.Equal(t, StatusStable, string(share.Status))
+	})
+
+	t.Run("profile", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+
+		require.NoError(t, vpcs.ModifyVolume(ModifyVolumeRequest{VolumeID: volume.VolumeID, Profile: "rfs"}))
+		share, err := vpcs.Apiclient.FileShareService().GetFileShare(volume.VolumeID, vpcs.Logger)
+		require.NoError(t, err)
+		assert.Equal(t, "rfs", share.Profile.Name)
+	})
+
+	t.Run("no change", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+
+		operation, err := vpcs.StartModifyVolume(ModifyVolumeRequest{VolumeID: volume.VolumeID, Profile: "dp2"})
+		require.NoError(t, err)
+		assert.True(t, operation.Done)
+		assert.Equal(t, 0, fake.CallCount("UpdateFileShare"))
+	})
+
+	t.Run("iops out of the profile range", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+
+		err = vpcs.ModifyVolume(ModifyVolumeRequest{VolumeID: volume.VolumeID, Iops: 50})
+		assert.Equal(t, "VolumeIopsNotAllowed", userErrorCode(err))
+		assert.Equal(t, 0, fake.CallCount("UpdateFileShare"))
+	})
+
+	t.Run("concurrent update", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "UpdateFileShare", OnCall: 1, Status: http.StatusPreconditionFailed, Code: fakevpc.ErrorCodePreconditionFailed})
+
+		require.NoError(t, vpcs.ModifyVolume(ModifyVolumeRequest{VolumeID: volume.VolumeID, Iops: 2000}))
+		assert.Equal(t, 2, fake.CallCount("UpdateFileShare"))
+	})
+
+	t.Run("profile fetched once", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "UpdateFileShare", Times: 2, Status: http.StatusPreconditionFailed, Code: fakevpc.ErrorCodePreconditionFailed})
+		profileCalls := fake.CallCount("GetShareProfile") + fake.CallCount("ListShareProfiles")
+
+		require.NoError(t, vpcs.ModifyVolume(ModifyVolumeRequest{VolumeID: volume.VolumeID, Profile: "rfs", Bandwidth: 200}))
+		assert.Equal(t, 3, fake.CallCount("UpdateFileShare"))
+		assert.LessOrEqual(t, fake.CallCount("GetShareProfile")+fake.CallCount("ListShareProfiles"), profileCalls+1)
+	})
+
+	t.Run("profile cannot be fetched", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "GetShareProfile", Code: fakevpc.ErrorCodeInternalError})
+		fake.InjectFault(fakevpc.Fault{Operation: "ListShareProfiles", Code: fakevpc.ErrorCodeInternalError})
+
+		// The request is not checked against the profile, as for CreateVolume
+		require.NoError(t, vpcs.ModifyVolume(ModifyVolumeRequest{VolumeID: volume.VolumeID, Iops: 2000}))
+		assert.Equal(t, 1, fake.CallCount("UpdateFileShare"))
+	})
+
+	t.Run("invalid volume ID", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		err := vpcs.ModifyVolume(ModifyVolumeRequest{VolumeID: "invalid", Iops: 2000})
+		assert.Equal(t, "InvalidVolumeID", userErrorCode(err))
+	})
+}
+
+func TestValidateModifyRequest(t *testing.T) {
+	share := &models.Share{Size: 10, Profile: &models.Profile{Name: "dp2"}}
+	stepped := &models.ProfileDetails{
+		Profile:  models.Profile{Name: "stepped"},
+		Capacity: models.CapIops{Type: "range", Min: 20, Max: 100},
+		Iops:     models.CapIops{Type: "range", Min: 100, Max: 1000, Step: 100},
+	}
+
+	assert.NoError(t, validateModifyRequest(share, stepped, ModifyVolumeRequest{Iops: 300}))
+	assert.NoError(t, validateModifyRequest(share, nil, ModifyVolumeRequest{Profile: "stepped"}))
+
+	// The size of the share only matters when moving it to another profile
+	err := validateModifyRequest(share, stepped, ModifyVolumeRequest{Profile: "stepped"})
+	assert.Equal(t, "VolumeCapacityNotAllowed", userErrorCode(err))
+
+	err = validateModifyRequest(share, stepped, ModifyVolumeRequest{Iops: 350})
+	assert.Equal(t, "VolumeIopsNotAllowed", userErrorCode(err))
+}
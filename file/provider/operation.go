@@ -0,0 +1,179 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"go.uber.org/zap"
+)
+
+// OperationType is the kind of volume operation an Operation tracks
+type OperationType string
+
+const (
+	// CreateVolumeOperation is started by StartCreateVolume
+	CreateVolumeOperation = OperationType("CreateVolume")
+	// ExpandVolumeOperation is started by StartExpandVolume
+	ExpandVolumeOperation = OperationType("ExpandVolume")
+	// DeleteVolumeOperation is started by StartDeleteVolume
+	DeleteVolumeOperation = OperationType("DeleteVolume")
+	// ModifyVolumeOperation is started by StartModifyVolume
+	ModifyVolumeOperation = OperationType("ModifyVolume")
+	// CreateReplicaVolumeOperation is started by StartCreateReplicaVolume
+	CreateReplicaVolumeOperation = OperationType("CreateReplicaVolume")
+	// CreateAccessorVolumeOperation is started by StartCreateAccessorVolume
+	CreateAccessorVolumeOperation = OperationType("CreateAccessorVolume")
+)
+
+// Operation is a handle on a volume operation accepted by the backend, which completes once the file share
+// reaches TargetState. It holds no connection or goroutine, so callers can keep it, e.g. in a work queue,
+// and check back on it later through PollOperation, from any session
+type Operation struct {
+	Type OperationType `json:"type"`
+	// VolumeID is the ID of the file share the operation applies to
+	VolumeID string `json:"volume_id"`
+	// TargetState is the lifecycle state which completes the operation, StatusDeleted for deletions
+	TargetState string `json:"target_state"`
+	// StartedAt is when the backend was asked for the operation
+	StartedAt time.Time `json:"started_at"`
+	// Done is set when the operation needed no change, so there is nothing to wait for
+	Done bool `json:"done,omitempty"`
+
+	// Volume is the created volume, for the creation operations
+	Volume *provider.Volume `json:"volume,omitempty"`
+	// Capacity is the capacity of the volume once expanded, for ExpandVolumeOperation
+	Capacity int64 `json:"capacity,omitempty"`
+}
+
+// OperationStatus is the status of an operation as of a PollOperation call
+type OperationStatus struct {
+	Operation *Operation
+	// State is the current lifecycle state of the file share, StatusDeleted once it is gone
+	State string
+	// Reasons are the lifecycle reasons of the file share, they tell why it failed
+	Reasons []models.LifecycleReason
+	// Done tells if the operation completed
+	Done bool
+	// Err is set when the operation failed for good. Either way, the operation is over once Done or Err is set
+	Err error
+	// Elapsed is the time since the operation started
+	Elapsed time.Duration
+}
+
+// newOperation returns the handle on an operation started now
+func newOperation(operationType OperationType, volumeID string, targetState string) *Operation {
+	return &Operation{
+		Type:        operationType,
+		VolumeID:    volumeID,
+		TargetState: targetState,
+		StartedAt:   time.Now(),
+	}
+}
+
+// PollOperation checks the operation once, without waiting. An error means the check itself failed, and
+// is worth trying again later. The failure of the operation is reported through OperationStatus.Err
+func (vpcs *VPCSession) PollOperation(operation *Operation) (*OperationStatus, error) {
+	vpcs.Logger.Debug("Entry of PollOperation method...", zap.Reflect("operation", operation))
+	defer vpcs.Logger.Debug("Exit from PollOperation method...")
+
+	if operation == nil {
+		return nil, userError.GetUserError("ErrorRequiredFieldMissing", nil, "operation")
+	}
+	status := &OperationStatus{Operation: operation, Elapsed: time.Since(operation.StartedAt)}
+	if operation.Done {
+		status.Done = true
+		status.State = operation.TargetState
+		return status, nil
+	}
+
+	wait := vpcs.operationWait(operation)
+	share, err := wait.Get()
+	done, stopErr := checkLifecycle(vpcs, wait, share, err)
+	if err != nil && !done && stopErr == nil {
+		return nil, userError.GetUserError("StorageFindFailedWithVolumeId", err, operation.VolumeID)
+	}
+	if err == nil {
+		status.State = share.GetLifecycleState()
+		status.Reasons = share.GetLifecycleReasons()
+	}
+	switch {
+	case done:
+		status.Done = true
+		status.State = operation.TargetState
+	case stopErr != nil:
+		status.Err = vpcs.operationError(operation, stopErr)
+	}
+	vpcs.Logger.Info("Polled operation", zap.Reflect("operation", operation), zap.String("state", status.State),
+		zap.Bool("done", status.Done), zap.Error(status.Err))
+	return status, nil
+}
+
+// WaitOperation waits for the operation to complete, as the blocking method which started it would have
+func (vpcs *VPCSession) WaitOperation(operation *Operation) error {
+	vpcs.Logger.Debug("Entry of WaitOperation method...", zap.Reflect("operation", operation))
+	defer vpcs.Logger.Debug("Exit from WaitOperation method...")
+
+	if operation == nil {
+		return userError.GetUserError("ErrorRequiredFieldMissing", nil, "operation")
+	}
+	if operation.Done {
+		return nil
+	}
+
+	var err error
+	if operation.Type == DeleteVolumeOperation {
+		err = WaitForVolumeDeletion(vpcs, operation.VolumeID)
+	} else {
+		err = WaitForValidVolumeState(vpcs, operation.VolumeID)
+	}
+	if err != nil {
+		return vpcs.operationError(operation, err)
+	}
+	return nil
+}
+
+// WaitOperationWithContext is WaitOperation which stops polling once ctx is done
+func (vpcs *VPCSession) WaitOperationWithContext(ctx context.Context, operation *Operation) error {
+	return vpcs.withContext(ctx).WaitOperation(operation)
+}
+
+// operationWait is the wait for the file share of operation to reach the target state
+func (vpcs *VPCSession) operationWait(operation *Operation) LifecycleWait[*models.Share] {
+	if operation.Type == DeleteVolumeOperation {
+		return volumeDeletionWait(vpcs, operation.VolumeID)
+	}
+	return validVolumeStateWait(vpcs, operation.VolumeID)
+}
+
+// operationError returns the error of the blocking method which started operation for the failure err. The
+// user errors of the Wait helpers are returned as they are, only the backend errors get wrapped
+func (vpcs *VPCSession) operationError(operation *Operation, err error) error {
+	if _, ok := err.(util.Message); ok {
+		return err
+	}
+	if operation.Type == DeleteVolumeOperation {
+		return userError.GetUserError("FailedToDeleteVolume", err, operation.VolumeID)
+	}
+	return userError.GetUserError("VolumeNotInValidState", err, operation.VolumeID)
+}
@@ -0,0 +1,168 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pollUntilOver polls operation until it is done or failed
+func pollUntilOver(t *testing.T, vpcs *VPCSession, operation *Operation) *OperationStatus {
+	var status *OperationStatus
+	require.Eventually(t, func() bool {
+		var err error
+		status, err = vpcs.PollOperation(operation)
+		require.NoError(t, err)
+		return status.Done || status.Err != nil
+	}, 5*time.Second, 5*time.Millisecond)
+	return status
+}
+
+func TestVolumeOperations(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+
+	operation, err := vpcs.StartCreateVolume(fakeVolumeRequest("fake-volume"))
+	require.NoError(t, err)
+	assert.Equal(t, CreateVolumeOperation, operation.Type)
+	assert.Equal(t, StatusStable, operation.TargetState)
+	assert.Equal(t, operation.VolumeID, operation.Volume.VolumeID)
+	assert.False(t, operation.StartedAt.IsZero())
+	// Starting does not wait for the share to be stable
+	assert.Equal(t, 0, fake.CallCount("GetFileShare"))
+
+	status, err := vpcs.PollOperation(operation)
+	require.NoError(t, err)
+	assert.False(t, status.Done)
+	assert.NoError(t, status.Err)
+	assert.Equal(t, fakevpc.StatePending, status.State)
+
+	// A handle kept aside, e.g. in a work queue, can be checked back on later
+	data, err := json.Marshal(operation)
+	require.NoError(t, err)
+	stored := &Operation{}
+	require.NoError(t, json.Unmarshal(data, stored))
+	status = pollUntilOver(t, vpcs, stored)
+	assert.True(t, status.Done)
+	assert.Equal(t, StatusStable, status.State)
+
+	operation, err = vpcs.StartExpandVolume(provider.ExpandVolumeRequest{VolumeID: operation.VolumeID, Capacity: 20 * GiB})
+	require.NoError(t, err)
+	assert.Equal(t, int64(20*GiB), operation.Capacity)
+	require.NoError(t, vpcs.WaitOperation(operation))
+	volume, err := vpcs.GetVolume(operation.VolumeID)
+	require.NoError(t, err)
+	assert.Equal(t, 20, *volume.Capacity)
+
+	// Nothing to wait for when the share is already large enough
+	operation, err = vpcs.StartExpandVolume(provider.ExpandVolumeRequest{VolumeID: operation.VolumeID, Capacity: 10})
+	require.NoError(t, err)
+	assert.True(t, operation.Done)
+	assert.Equal(t, int64(20), operation.Capacity)
+	calls := fake.CallCount("GetFileShare")
+	status, err = vpcs.PollOperation(operation)
+	require.NoError(t, err)
+	assert.True(t, status.Done)
+	assert.Equal(t, calls, fake.CallCount("GetFileShare"))
+
+	operation, err = vpcs.StartDeleteVolume(volume)
+	require.NoError(t, err)
+	assert.Equal(t, StatusDeleted, operation.TargetState)
+	status = pollUntilOver(t, vpcs, operation)
+	assert.True(t, status.Done)
+	assert.Equal(t, StatusDeleted, status.State)
+}
+
+func TestVolumeOperationFailures(t *testing.T) {
+	t.Run("failed creation", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", Fail: true})
+
+		operation, err := vpcs.StartCreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		status := pollUntilOver(t, vpcs, operation)
+		assert.False(t, status.Done)
+		assert.Equal(t, StatusFailed, status.State)
+		require.Len(t, status.Reasons, 1)
+		assert.Equal(t, fakevpc.ErrorCodeInternalError, status.Reasons[0].Code)
+		assert.Equal(t, "VolumeNotInValidState", userErrorCode(status.Err))
+
+		// Waiting fails the same way, the user error wrapping the backend error once
+		err = vpcs.WaitOperation(operation)
+		assert.Equal(t, "VolumeNotInValidState", userErrorCode(err))
+		assert.NotContains(t, err.(util.Message).BackendError, "did not get valid (stable) status")
+	})
+
+	t.Run("failed deletion", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "DeleteFileShare", Fail: true})
+
+		operation, err := vpcs.StartDeleteVolume(volume)
+		require.NoError(t, err)
+		status := pollUntilOver(t, vpcs, operation)
+		assert.Equal(t, "FailedToDeleteVolume", userErrorCode(status.Err))
+	})
+
+	t.Run("failed poll", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		operation, err := vpcs.StartCreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "GetFileShare", OnCall: 1, Code: fakevpc.ErrorCodeServiceError})
+
+		// A failed poll is worth trying again, the operation goes on
+		_, err = vpcs.PollOperation(operation)
+		assert.Equal(t, "StorageFindFailedWithVolumeId", userErrorCode(err))
+		status := pollUntilOver(t, vpcs, operation)
+		assert.True(t, status.Done)
+	})
+
+	t.Run("start failed", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		request := fakeVolumeRequest("fake-volume")
+		request.Name = nil
+
+		operation, err := vpcs.StartCreateVolume(request)
+		assert.Nil(t, operation)
+		assert.Equal(t, "InvalidVolumeName", userErrorCode(err))
+
+		_, err = vpcs.PollOperation(nil)
+		assert.Equal(t, "ErrorRequiredFieldMissing", userErrorCode(err))
+		assert.IsType(t, util.Message{}, vpcs.WaitOperation(nil))
+	})
+}
+
+func TestWaitOperationWithContext(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+	fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", Stuck: true})
+	operation, err := vpcs.StartCreateVolume(fakeVolumeRequest("fake-volume"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = vpcs.WaitOperationWithContext(ctx, operation)
+	assert.Equal(t, "VolumeNotInValidState", userErrorCode(err))
+}
@@ -0,0 +1,198 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// PlanLookups are the subnet and security group the driver looks up before creating a mount target in
+// security group mode, the plans look them up the same way
+type PlanLookups struct {
+	// SubnetIDList is the comma separated list of subnets of the cluster, the one in ZoneName is used when the request has no subnet
+	SubnetIDList string
+	// ZoneName is the zone of the subnet, the zone of the volume request when empty
+	ZoneName string
+	// SecurityGroupName is the security group looked up in the VPC of the request when it has no security group
+	SecurityGroupName string
+}
+
+// CreateVolumePlan is the outcome of a dry run of CreateVolume
+type CreateVolumePlan struct {
+	// Share is the exact share template CreateVolume would send, nil when the request lacks its name, capacity or profile
+	Share *models.Share
+	// Problems are all the reasons for CreateVolume to fail, in the order they were found
+	Problems []error
+}
+
+// Valid tells if CreateVolume would be accepted as far as can be told without creating the share
+func (plan *CreateVolumePlan) Valid() bool {
+	return len(plan.Problems) == 0
+}
+
+// VolumeAccessPointPlan is the outcome of a dry run of CreateVolumeAccessPoint
+type VolumeAccessPointPlan struct {
+	// ShareTarget is the exact share target template CreateVolumeAccessPoint would send
+	ShareTarget *models.ShareTarget
+	// Problems are all the reasons for CreateVolumeAccessPoint to fail, in the order they were found
+	Problems []error
+}
+
+// Valid tells if CreateVolumeAccessPoint would be accepted as far as can be told without creating the share target
+func (plan *VolumeAccessPointPlan) Valid() bool {
+	return len(plan.Problems) == 0
+}
+
+// PlanCreateVolume runs the checks of CreateVolume without creating anything, and returns the share template it
+// would send along with every problem found instead of stopping at the first one. The subnet and security group
+// of the mount target are resolved as per lookups when the request does not have them
+func (vpcs *VPCSession) PlanCreateVolume(volumeRequest provider.Volume, lookups PlanLookups) *CreateVolumePlan {
+	vpcs.Logger.Debug("Entry of PlanCreateVolume method...")
+	defer vpcs.Logger.Debug("Exit from PlanCreateVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "PlanCreateVolume", time.Now())
+
+	plan := &CreateVolumePlan{Problems: volumeRequestProblems(volumeRequest)}
+
+	// The key CRN format is only checked while planning, CreateVolume leaves it to the backend
+	if volumeRequest.VPCVolume.VolumeEncryptionKey != nil && len(volumeRequest.VPCVolume.VolumeEncryptionKey.CRN) > 0 && !IsValidCRNFormat(volumeRequest.VPCVolume.VolumeEncryptionKey.CRN) {
+		plan.Problems = append(plan.Problems, userError.GetUserError("InvalidEncryptionKeyCRN", nil, volumeRequest.VPCVolume.VolumeEncryptionKey.CRN))
+	}
+
+	var iops int64
+	if volumeRequest.Iops != nil {
+		iops = ToInt64(*volumeRequest.Iops)
+	}
+	if volumeRequest.VPCVolume.Profile != nil {
+		var capacity int64
+		if volumeRequest.Capacity != nil {
+			capacity = int64(*volumeRequest.Capacity)
+		}
+		plan.Problems = append(plan.Problems, vpcs.shareProfilePlanProblems(volumeRequest.VPCVolume.Profile.Name, capacity, iops, volumeRequest.VPCVolume.Bandwidth)...)
+	}
+
+	if volumeRequest.AccessControlMode == SecurityGroup {
+		if lookups.ZoneName == "" {
+			lookups.ZoneName = volumeRequest.Az
+		}
+		subnetID, securityGroups, problems := vpcs.resolveMountTargetNetwork(volumeRequest.SubnetID, volumeRequest.PrimaryIP, volumeRequest.SecurityGroups, volumeRequest.VPCVolume.ResourceGroup, volumeRequest.VPCID, lookups)
+		volumeRequest.SubnetID = subnetID
+		volumeRequest.SecurityGroups = securityGroups
+		plan.Problems = append(plan.Problems, problems...)
+	}
+
+	if volumeRequest.Name != nil && volumeRequest.Capacity != nil && volumeRequest.VPCVolume.Profile != nil {
+		resourceGroup := models.ResourceGroup{}
+		if volumeRequest.VPCVolume.ResourceGroup != nil {
+			resourceGroup.ID = volumeRequest.VPCVolume.ResourceGroup.ID
+			resourceGroup.Name = volumeRequest.VPCVolume.ResourceGroup.Name
+		}
+		plan.Share = buildShareTemplate(volumeRequest, resourceGroup, iops, volumeRequest.VPCVolume.Bandwidth)
+	}
+
+	vpcs.Logger.Info("Planned volume creation", zap.Reflect("ShareTemplate", plan.Share), zap.Int("problems", len(plan.Problems)))
+	return plan
+}
+
+// PlanCreateVolumeWithContext runs the checks of CreateVolume, giving up on the backend calls once ctx is done
+func (vpcs *VPCSession) PlanCreateVolumeWithContext(ctx context.Context, volumeRequest provider.Volume, lookups PlanLookups) *CreateVolumePlan {
+	return vpcs.withContext(ctx).PlanCreateVolume(volumeRequest, lookups)
+}
+
+// PlanCreateVolumeAccessPoint runs the checks of CreateVolumeAccessPoint without creating anything, and returns the
+// share target template it would send along with every problem found. The subnet and security group of the mount
+// target are resolved as per lookups when the request does not have them
+func (vpcs *VPCSession) PlanCreateVolumeAccessPoint(volumeAccessPointRequest provider.VolumeAccessPointRequest, lookups PlanLookups) *VolumeAccessPointPlan {
+	vpcs.Logger.Debug("Entry of PlanCreateVolumeAccessPoint method...")
+	defer vpcs.Logger.Debug("Exit from PlanCreateVolumeAccessPoint method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "PlanCreateVolumeAccessPoint", time.Now())
+
+	plan := &VolumeAccessPointPlan{}
+	if volumeAccessPointRequest.AccessControlMode == SecurityGroup {
+		subnetID, securityGroups, problems := vpcs.resolveMountTargetNetwork(volumeAccessPointRequest.SubnetID, volumeAccessPointRequest.PrimaryIP, volumeAccessPointRequest.SecurityGroups, volumeAccessPointRequest.ResourceGroup, volumeAccessPointRequest.VPCID, lookups)
+		volumeAccessPointRequest.SubnetID = subnetID
+		volumeAccessPointRequest.SecurityGroups = securityGroups
+		plan.Problems = append(plan.Problems, problems...)
+	}
+	plan.Problems = append(volumeAccessPointRequestProblems(volumeAccessPointRequest), plan.Problems...)
+
+	shareTarget := buildShareTargetTemplate(volumeAccessPointRequest)
+	plan.ShareTarget = &shareTarget
+
+	vpcs.Logger.Info("Planned volume accessPoint creation", zap.Reflect("ShareTargetTemplate", plan.ShareTarget), zap.Int("problems", len(plan.Problems)))
+	return plan
+}
+
+// PlanCreateVolumeAccessPointWithContext runs the checks of CreateVolumeAccessPoint, giving up on the backend calls once ctx is done
+func (vpcs *VPCSession) PlanCreateVolumeAccessPointWithContext(ctx context.Context, volumeAccessPointRequest provider.VolumeAccessPointRequest, lookups PlanLookups) *VolumeAccessPointPlan {
+	return vpcs.withContext(ctx).PlanCreateVolumeAccessPoint(volumeAccessPointRequest, lookups)
+}
+
+// shareProfilePlanProblems checks the values of the request against the share profile. Unlike the creation,
+// which leaves it to the backend, a share profile which cannot be fetched is a problem
+func (vpcs *VPCSession) shareProfilePlanProblems(profileName string, capacity int64, iops int64, bandwidth int32) []error {
+	profile, err := vpcs.GetShareProfileDetails(profileName)
+	if err != nil || profile == nil {
+		return []error{userError.GetUserError("ShareProfileLookupFailed", err, profileName)}
+	}
+	return shareProfileProblems(profile, capacity, iops, bandwidth)
+}
+
+// resolveMountTargetNetwork looks up the subnet and security group of a mount target in security group mode, as
+// per lookups, when subnetID and securityGroups are not set. The subnet is not looked up for a reserved IP
+func (vpcs *VPCSession) resolveMountTargetNetwork(subnetID string, primaryIP *provider.PrimaryIP, securityGroups *[]provider.SecurityGroup, resourceGroup *provider.ResourceGroup, vpcID string, lookups PlanLookups) (string, *[]provider.SecurityGroup, []error) {
+	problems := []error{}
+	if resourceGroup == nil {
+		resourceGroup = &provider.ResourceGroup{}
+	}
+
+	if subnetID == "" && primaryIP == nil && lookups.SubnetIDList != "" {
+		subnet, err := vpcs.GetSubnetForVolumeAccessPoint(provider.SubnetRequest{
+			SubnetIDList:  lookups.SubnetIDList,
+			ZoneName:      lookups.ZoneName,
+			ResourceGroup: resourceGroup,
+			VPCID:         vpcID,
+		})
+		if err != nil {
+			problems = append(problems, err)
+		} else {
+			subnetID = subnet
+		}
+	}
+
+	if (securityGroups == nil || len(*securityGroups) == 0) && lookups.SecurityGroupName != "" {
+		securityGroup, err := vpcs.GetSecurityGroupForVolumeAccessPoint(provider.SecurityGroupRequest{
+			Name:          lookups.SecurityGroupName,
+			ResourceGroup: resourceGroup,
+			VPCID:         vpcID,
+		})
+		if err != nil {
+			problems = append(problems, err)
+		} else {
+			securityGroups = &[]provider.SecurityGroup{{ID: securityGroup}}
+		}
+	}
+
+	return subnetID, securityGroups, problems
+}
@@ -0,0 +1,144 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// problemCodes returns the user error codes of problems
+func problemCodes(problems []error) []string {
+	codes := []string{}
+	for _, problem := range problems {
+		codes = append(codes, userErrorCode(problem))
+	}
+	return codes
+}
+
+func TestPlanCreateVolume(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		vpc := &provider.VPC{ID: "vpc1"}
+		subnet := fake.AddSubnet(models.Subnet{ID: "subnet1", VPC: vpc, ResourceGroup: &models.ResourceGroup{ID: "rg1"}, Zone: &models.Zone{Name: fakevpc.DefaultZone}})
+		securityGroup := fake.AddSecurityGroup(models.SecurityGroup{Name: "kube-cluster", VPC: vpc, ResourceGroup: &models.ResourceGroup{ID: "rg1"}})
+
+		request := fakeVolumeRequest("fake-volume")
+		request.Az = fakevpc.DefaultZone
+		request.VPCID = vpc.ID
+		request.AccessControlMode = SecurityGroup
+		request.VPCVolume.VolumeEncryptionKey = &provider.VolumeEncryptionKey{CRN: "crn:v1:bluemix:public:kms:us-south:a/account:instance:key:key1"}
+		plan := vpcs.PlanCreateVolume(request, PlanLookups{SubnetIDList: "subnet0," + subnet.ID, SecurityGroupName: "kube-cluster"})
+
+		require.True(t, plan.Valid(), problemCodes(plan.Problems))
+		require.NotNil(t, plan.Share)
+		assert.Equal(t, "fake-volume", plan.Share.Name)
+		assert.Equal(t, "crn:v1:bluemix:public:kms:us-south:a/account:instance:key:key1", plan.Share.EncryptionKey.CRN)
+		require.NotNil(t, plan.Share.ShareTargets)
+		target := (*plan.Share.ShareTargets)[0]
+		assert.Equal(t, subnet.ID, target.VirtualNetworkInterface.Subnet.ID)
+		assert.Equal(t, []provider.SecurityGroup{{ID: securityGroup.ID}}, *target.VirtualNetworkInterface.SecurityGroups)
+		assert.Equal(t, 0, fake.CallCount("CreateFileShare"))
+	})
+
+	t.Run("every problem", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		vpcs.Profiles = NewProfileCatalog(profileCatalogTTL)
+
+		request := fakeVolumeRequest("fake-volume")
+		request.Capacity = Int(40000)
+		request.Iops = String("50")
+		request.VPCVolume.ResourceGroup = nil
+		request.AccessControlMode = SecurityGroup
+		request.VPCVolume.VolumeEncryptionKey = &provider.VolumeEncryptionKey{CRN: "key1"}
+		plan := vpcs.PlanCreateVolume(request, PlanLookups{SubnetIDList: "subnet1", ZoneName: fakevpc.DefaultZone, SecurityGroupName: "kube-cluster"})
+
+		assert.False(t, plan.Valid())
+		assert.Equal(t, []string{"EmptyResourceGroup", "InvalidEncryptionKeyCRN", "VolumeCapacityNotAllowed", "VolumeIopsNotAllowed", "SubnetFindFailed", "SecurityGroupFindFailed"}, problemCodes(plan.Problems))
+		require.NotNil(t, plan.Share)
+		assert.Equal(t, int64(40000), plan.Share.Size)
+		assert.Equal(t, 0, fake.CallCount("CreateFileShare"))
+	})
+
+	t.Run("encryption key CRN", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		request := fakeVolumeRequest("fake-volume")
+		request.VPCVolume.VolumeEncryptionKey = &provider.VolumeEncryptionKey{CRN: "key1"}
+
+		plan := vpcs.PlanCreateVolume(request, PlanLookups{})
+		assert.Equal(t, []string{"InvalidEncryptionKeyCRN"}, problemCodes(plan.Problems))
+
+		// CreateVolume does not check the key CRN format, the backend does
+		_, err := vpcs.CreateVolume(request)
+		require.NoError(t, err)
+		assert.Equal(t, 1, fake.CallCount("CreateFileShare"))
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		request := fakeVolumeRequest("fake-volume")
+		request.VPCVolume.Profile.Name = "unknown"
+
+		plan := vpcs.PlanCreateVolume(request, PlanLookups{})
+		assert.Equal(t, []string{"ShareProfileLookupFailed"}, problemCodes(plan.Problems))
+	})
+
+	t.Run("no name", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		request := fakeVolumeRequest("fake-volume")
+		request.Name = nil
+
+		plan := vpcs.PlanCreateVolume(request, PlanLookups{})
+		assert.Equal(t, []string{"InvalidVolumeName"}, problemCodes(plan.Problems))
+		assert.Nil(t, plan.Share)
+	})
+}
+
+func TestPlanCreateVolumeAccessPoint(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		subnet := fake.AddSubnet(models.Subnet{ID: "subnet1", Zone: &models.Zone{Name: fakevpc.DefaultZone}})
+
+		plan := vpcs.PlanCreateVolumeAccessPoint(provider.VolumeAccessPointRequest{
+			VolumeID:          "volume1",
+			AccessControlMode: SecurityGroup,
+			SecurityGroups:    &[]provider.SecurityGroup{{ID: "sg1"}},
+		}, PlanLookups{SubnetIDList: subnet.ID, ZoneName: fakevpc.DefaultZone})
+
+		require.True(t, plan.Valid(), problemCodes(plan.Problems))
+		assert.Nil(t, plan.ShareTarget.VPC)
+		assert.Equal(t, subnet.ID, plan.ShareTarget.VirtualNetworkInterface.Subnet.ID)
+		assert.Equal(t, 0, fake.CallCount("ListSecurityGroups"))
+		assert.Equal(t, 0, fake.CallCount("CreateFileShareTarget"))
+	})
+
+	t.Run("every problem", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+
+		plan := vpcs.PlanCreateVolumeAccessPoint(provider.VolumeAccessPointRequest{
+			AccessControlMode: SecurityGroup,
+		}, PlanLookups{SubnetIDList: "subnet1", ZoneName: fakevpc.DefaultZone})
+
+		assert.Equal(t, []string{"ErrorRequiredFieldMissing", "ErrorRequiredFieldMissing", "SubnetFindFailed"}, problemCodes(plan.Problems))
+		require.NotNil(t, plan.ShareTarget)
+	})
+}
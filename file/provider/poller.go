@@ -0,0 +1,394 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"go.uber.org/zap"
+)
+
+// Wait operations, as named in the poll settings of a Poller and in the wait timeouts configuration
+const (
+	// WaitForValidVolumeStateOp waits for a share to be stable
+	WaitForValidVolumeStateOp = "WaitForValidVolumeState"
+	// WaitForVolumeDeletionOp waits for a share to be gone
+	WaitForVolumeDeletionOp = "WaitForVolumeDeletion"
+	// WaitForSnapshotReadyOp waits for a snapshot to be stable
+	WaitForSnapshotReadyOp = "WaitForSnapshotReady"
+	// WaitForSnapshotDeletionOp waits for a snapshot to be gone
+	WaitForSnapshotDeletionOp = "WaitForSnapshotDeletion"
+	// WaitForCreateVolumeAccessPointOp waits for a mount target to be stable
+	WaitForCreateVolumeAccessPointOp = "WaitForCreateVolumeAccessPoint"
+	// WaitForDeleteVolumeAccessPointOp waits for a mount target to be gone
+	WaitForDeleteVolumeAccessPointOp = "WaitForDeleteVolumeAccessPoint"
+	// WaitForVolumeReplicationOp waits for the replication status of a share to settle
+	WaitForVolumeReplicationOp = "WaitForVolumeReplication"
+	// WaitForAccessorBindingDeletionOp waits for an accessor binding to be gone
+	WaitForAccessorBindingDeletionOp = "WaitForAccessorBindingDeletion"
+)
+
+// StatusFailed is the lifecycle state of a resource whose last change failed, waits stop on it right away
+const StatusFailed = models.LifecycleStateFailed
+
+// notFoundErrorCodes are the backend error codes telling that a resource is gone
+var notFoundErrorCodes = map[string]bool{
+	SharesNotFound:            true,
+	"shares_target_not_found": true,
+	SnapshotNotFound:          true,
+	AccessorBindingNotFound:   true,
+}
+
+// PollSettings controls how often and for how long a wait polls
+type PollSettings struct {
+	// Interval is the wait before the second poll, it doubles from the third poll onwards up to MaxInterval
+	Interval time.Duration
+	// MaxInterval caps the interval, polls keep a constant interval when it equals Interval
+	MaxInterval time.Duration
+	// Timeout stops polling once this much time has passed since the first poll
+	Timeout time.Duration
+}
+
+// PollProgress reports the outcome of a poll to the Progress callback of a Poller
+type PollProgress struct {
+	// Operation is the wait operation, e.g. WaitForValidVolumeStateOp
+	Operation string
+	// ID identifies the resource waited for
+	ID string
+	// Attempt counts the polls of the wait, from 1
+	Attempt int
+	// Elapsed is the time since the first poll
+	Elapsed time.Duration
+	// State is the lifecycle state seen by the poll, empty when the poll failed or found the resource gone
+	State string
+	// Err is the error of the poll, if any
+	Err error
+}
+
+// Poller polls VPC resources until they reach a lifecycle state. Each wait operation polls as per its own
+// settings, falling back to Default for the settings it leaves unset. A poller is read-only once built,
+// so sessions can share it
+type Poller struct {
+	// Default applies to the operations without settings of their own
+	Default PollSettings
+	// Operations overrides Default per wait operation, zero fields falling back to Default
+	Operations map[string]PollSettings
+	// Progress, when set, is called after every poll
+	Progress func(PollProgress)
+}
+
+// NewPoller builds a poller which polls as long and as often as the retry loops of retryPolicy did:
+// with exponential backoff over MaxAttempts polls, and with ConstantGap over 4*MaxAttempts polls for
+// access points. timeouts overrides the timeout of the wait operations it names
+func NewPoller(retryPolicy *RetryPolicy, timeouts map[string]time.Duration) *Poller {
+	constantGap := PollSettings{
+		Interval:    retryPolicy.ConstantGap,
+		MaxInterval: retryPolicy.ConstantGap,
+		Timeout:     time.Duration(retryPolicy.MaxAttempts*4-1) * retryPolicy.ConstantGap,
+	}
+	poller := &Poller{
+		Default: PollSettings{
+			Interval:    retryPolicy.InitialGap,
+			MaxInterval: retryPolicy.MaxGap,
+			Timeout:     backoffBudget(retryPolicy),
+		},
+		Operations: map[string]PollSettings{
+			WaitForCreateVolumeAccessPointOp: constantGap,
+			WaitForDeleteVolumeAccessPointOp: constantGap,
+		},
+	}
+	for operation, timeout := range timeouts {
+		settings := poller.Operations[operation]
+		settings.Timeout = timeout
+		poller.Operations[operation] = settings
+	}
+	return poller
+}
+
+// backoffBudget returns the time the backoff of retryPolicy sleeps over MaxAttempts attempts, capped by MaxElapsedTime
+func backoffBudget(retryPolicy *RetryPolicy) time.Duration {
+	var budget time.Duration
+	gap := retryPolicy.InitialGap
+	if retryPolicy.MaxGap > 0 && gap > retryPolicy.MaxGap {
+		gap = retryPolicy.MaxGap
+	}
+	for i := 1; i < retryPolicy.MaxAttempts; i++ {
+		if i >= 2 {
+			gap = 2 * gap
+			if gap > retryPolicy.MaxGap {
+				gap = retryPolicy.MaxGap
+			}
+		}
+		budget += gap
+	}
+	if retryPolicy.MaxElapsedTime > 0 && retryPolicy.MaxElapsedTime < budget {
+		budget = retryPolicy.MaxElapsedTime
+	}
+	return budget
+}
+
+// WithTimeout returns a copy of the poller whose operation times out after timeout
+func (p *Poller) WithTimeout(operation string, timeout time.Duration) *Poller {
+	operations := make(map[string]PollSettings, len(p.Operations)+1)
+	maps.Copy(operations, p.Operations)
+	settings := operations[operation]
+	settings.Timeout = timeout
+	operations[operation] = settings
+	return &Poller{Default: p.Default, Operations: operations, Progress: p.Progress}
+}
+
+// Settings returns the poll settings of operation
+func (p *Poller) Settings(operation string) PollSettings {
+	settings := p.Default
+	if override, ok := p.Operations[operation]; ok {
+		if override.Interval > 0 {
+			settings.Interval = override.Interval
+		}
+		if override.MaxInterval > 0 {
+			settings.MaxInterval = override.MaxInterval
+		}
+		if override.Timeout > 0 {
+			settings.Timeout = override.Timeout
+		}
+	}
+	if settings.MaxInterval < settings.Interval {
+		settings.MaxInterval = settings.Interval
+	}
+	return settings
+}
+
+// LifecycleWait describes what a wait polls for
+type LifecycleWait[T models.LifecycleResource] struct {
+	// Operation names the wait, it selects the poll settings
+	Operation string
+	// ID identifies the resource waited for, in the logs, the progress reports and the errors
+	ID string
+	// Get fetches the resource
+	Get func() (T, error)
+	// Ready tells if the resource reached the state waited for, nil waits for the resource to be gone
+	Ready func(T) bool
+	// Terminal are the lifecycle states the resource never gets ready from, the wait stops on them as on StatusFailed
+	Terminal []string
+	// Gone tells if an error of Get means the resource is gone, backend not found errors do when nil
+	Gone func(error) bool
+	// Retryable tells if an error of Get is worth polling again, the session retry policy decides when nil
+	Retryable func(error) bool
+}
+
+// InLifecycleState returns a Ready predicate matching the resources in one of states
+func InLifecycleState[T models.LifecycleResource](states ...string) func(T) bool {
+	return func(resource T) bool {
+		for _, state := range states {
+			if resource.GetLifecycleState() == state {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// LifecycleStateError is returned by a wait when the resource moved to the failed state, or to another
+// state it never gets ready from
+type LifecycleStateError struct {
+	Operation string
+	ID        string
+	State     string
+	// Reasons are the lifecycle reasons reported by the backend, they tell why the resource failed
+	Reasons []models.LifecycleReason
+}
+
+// Error ...
+func (e *LifecycleStateError) Error() string {
+	message := fmt.Sprintf("%s: %s is in the %s lifecycle state", e.Operation, e.ID, e.State)
+	reasons := []string{}
+	for _, reason := range e.Reasons {
+		reasons = append(reasons, reason.Code+": "+reason.Message)
+	}
+	if len(reasons) > 0 {
+		message += " (" + strings.Join(reasons, "; ") + ")"
+	}
+	return message
+}
+
+// PollTimeoutError is returned by a wait which ran out of time
+type PollTimeoutError struct {
+	Operation string
+	ID        string
+	Timeout   time.Duration
+	// State is the last lifecycle state seen, empty when the last poll failed
+	State string
+	// Err is the error of the last poll, if any
+	Err error
+}
+
+// Error ...
+func (e *PollTimeoutError) Error() string {
+	message := fmt.Sprintf("%s: %s timed out after %s", e.Operation, e.ID, e.Timeout)
+	if e.State != "" {
+		message += " in the " + e.State + " lifecycle state"
+	}
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap returns the error of the last poll
+func (e *PollTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// GetPoller returns the poller of the session, or the one matching its retry policy when it has none
+func (vpcs *VPCSession) GetPoller() *Poller {
+	if vpcs.Poller == nil {
+		var timeouts map[string]time.Duration
+		if vpcs.Config != nil {
+			timeouts = vpcs.Config.WaitTimeouts
+		}
+		return NewPoller(vpcs.GetRetryPolicy(), timeouts)
+	}
+	return vpcs.Poller
+}
+
+// pollLifecycle polls the resource of wait until it is ready, or gone when wait has no Ready predicate.
+// It stops early on StatusFailed and the Terminal states, on errors which are not worth polling again, and
+// once the session context is done. It returns the last resource seen
+func pollLifecycle[T models.LifecycleResource](vpcs *VPCSession, wait LifecycleWait[T]) (T, error) {
+	poller := vpcs.GetPoller()
+	settings := poller.Settings(wait.Operation)
+	retryPolicy := vpcs.GetRetryPolicy()
+
+	var resource T
+	var err error
+	start := time.Now()
+	deadline := start.Add(settings.Timeout)
+	gap := settings.Interval
+	atDeadline := false
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			// Exponential backoff from the third poll onwards
+			if attempt > 2 {
+				gap = 2 * gap
+				if gap > settings.MaxInterval {
+					gap = settings.MaxInterval
+				}
+			}
+			sleep := gap
+			if retryAfter := retryPolicy.retryAfter(err); retryAfter > sleep {
+				// Pace ourselves as asked by the API
+				sleep = retryAfter
+			}
+			if atDeadline {
+				break
+			}
+			if remaining := time.Until(deadline); sleep >= remaining {
+				// Poll one last time at the deadline, even when the polls took the time left
+				sleep = max(remaining, 0)
+				atDeadline = true
+			}
+			if ctxErr := sleepWithContext(vpcs.requestContext(), sleep); ctxErr != nil {
+				vpcs.Logger.Warn("Context done, stopping wait", zap.String("operation", wait.Operation), zap.Error(ctxErr))
+				return resource, ctxErr
+			}
+		}
+
+		var current T
+		current, err = wait.Get()
+		progress := PollProgress{Operation: wait.Operation, ID: wait.ID, Attempt: attempt, Elapsed: time.Since(start), Err: err}
+		if err == nil {
+			resource = current
+			progress.State = current.GetLifecycleState()
+		}
+		if poller.Progress != nil {
+			poller.Progress(progress)
+		}
+		vpcs.Logger.Info("Polled resource", zap.String("operation", wait.Operation), zap.String("id", wait.ID),
+			zap.Int("attempt", attempt), zap.String("state", progress.State), zap.Error(err))
+
+		done, stopErr := checkLifecycle(vpcs, wait, current, err)
+		if done || stopErr != nil {
+			return resource, stopErr
+		}
+	}
+
+	timeoutErr := &PollTimeoutError{Operation: wait.Operation, ID: wait.ID, Timeout: settings.Timeout, Err: err}
+	if err == nil {
+		timeoutErr.State = resource.GetLifecycleState()
+	}
+	vpcs.Logger.Warn("Wait timed out", zap.String("operation", wait.Operation), zap.Error(timeoutErr))
+	return resource, timeoutErr
+}
+
+// checkLifecycle checks the outcome of a poll of wait, which returned resource and err. The wait is done once
+// the resource is ready, or gone when wait has no Ready predicate. It stops on the error returned, if any,
+// and goes on otherwise, err being worth polling again then
+func checkLifecycle[T models.LifecycleResource](vpcs *VPCSession, wait LifecycleWait[T], resource T, err error) (bool, error) {
+	if err != nil {
+		gone := wait.Gone
+		if gone == nil {
+			gone = isNotFound
+		}
+		if gone(err) {
+			if wait.Ready == nil {
+				return true, nil
+			}
+			return false, err
+		}
+		retryable := wait.Retryable
+		if retryable == nil {
+			retryable = func(err error) bool {
+				// Only backend errors are classified, anything else is polled again
+				var modelError *models.Error
+				return !errors.As(err, &modelError) || !vpcs.GetRetryPolicy().SkipRetry(err)
+			}
+		}
+		if !retryable(err) {
+			return false, err
+		}
+		return false, nil
+	}
+
+	state := resource.GetLifecycleState()
+	if wait.Ready != nil && wait.Ready(resource) {
+		return true, nil
+	}
+	if state == StatusFailed || slices.Contains(wait.Terminal, state) {
+		return false, &LifecycleStateError{Operation: wait.Operation, ID: wait.ID, State: state, Reasons: resource.GetLifecycleReasons()}
+	}
+	return false, nil
+}
+
+// isNotFound tells if err is a backend error telling that a resource is gone
+func isNotFound(err error) bool {
+	var modelError *models.Error
+	if !errors.As(err, &modelError) {
+		return false
+	}
+	for _, errorItem := range modelError.Errors {
+		if notFoundErrorCodes[string(errorItem.Code)] {
+			return true
+		}
+	}
+	return false
+}
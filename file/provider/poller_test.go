@@ -0,0 +1,232 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPoller(t *testing.T) {
+	poller := NewPoller(testRetryPolicy(), map[string]time.Duration{
+		WaitForValidVolumeStateOp:        time.Minute,
+		WaitForCreateVolumeAccessPointOp: 2 * time.Minute,
+	})
+
+	// Backoff polls last as long as the retries of the policy: 10ms, then 20ms twice
+	assert.Equal(t, PollSettings{Interval: 10 * time.Millisecond, MaxInterval: 20 * time.Millisecond, Timeout: 50 * time.Millisecond},
+		poller.Settings(WaitForVolumeDeletionOp))
+	assert.Equal(t, PollSettings{Interval: 10 * time.Millisecond, MaxInterval: 20 * time.Millisecond, Timeout: time.Minute},
+		poller.Settings(WaitForValidVolumeStateOp))
+
+	// Access points are polled with a constant gap, four times as many times
+	assert.Equal(t, PollSettings{Interval: 5 * time.Millisecond, MaxInterval: 5 * time.Millisecond, Timeout: 75 * time.Millisecond},
+		poller.Settings(WaitForDeleteVolumeAccessPointOp))
+	assert.Equal(t, PollSettings{Interval: 5 * time.Millisecond, MaxInterval: 5 * time.Millisecond, Timeout: 2 * time.Minute},
+		poller.Settings(WaitForCreateVolumeAccessPointOp))
+
+	retryPolicy := testRetryPolicy()
+	retryPolicy.MaxElapsedTime = 15 * time.Millisecond
+	assert.Equal(t, 15*time.Millisecond, NewPoller(retryPolicy, nil).Default.Timeout)
+}
+
+func TestPollerWithTimeout(t *testing.T) {
+	poller := NewPoller(testRetryPolicy(), nil)
+	scoped := poller.WithTimeout(WaitForSnapshotReadyOp, time.Minute)
+
+	assert.Equal(t, time.Minute, scoped.Settings(WaitForSnapshotReadyOp).Timeout)
+	assert.Equal(t, poller.Settings(WaitForSnapshotReadyOp).Interval, scoped.Settings(WaitForSnapshotReadyOp).Interval)
+	assert.Equal(t, poller.Settings(WaitForCreateVolumeAccessPointOp), scoped.Settings(WaitForCreateVolumeAccessPointOp))
+	// The poller copied from is left as is
+	assert.NotContains(t, poller.Operations, WaitForSnapshotReadyOp)
+}
+
+func fakeShareTemplate() *models.Share {
+	return &models.Share{Name: "share", Size: 10, Profile: &models.Profile{Name: "dp2"}, ResourceGroup: &models.ResourceGroup{ID: "rg1"}}
+}
+
+// waitForShare waits for a share of the fake to be stable
+func waitForShare(vpcs *VPCSession, id string) (*models.Share, error) {
+	return pollLifecycle(vpcs, LifecycleWait[*models.Share]{
+		Operation: WaitForValidVolumeStateOp,
+		ID:        id,
+		Get: func() (*models.Share, error) {
+			return vpcs.Apiclient.FileShareService().GetFileShare(id, vpcs.Logger)
+		},
+		Ready: InLifecycleState[*models.Share](StatusStable),
+	})
+}
+
+func TestPollLifecycle(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		progress := []PollProgress{}
+		vpcs.Poller = NewPoller(vpcs.RetryPolicy, nil)
+		vpcs.Poller.Progress = func(p PollProgress) { progress = append(progress, p) }
+		share, err := vpcs.Apiclient.FileShareService().CreateFileShare(fakeShareTemplate(), vpcs.Logger)
+		require.NoError(t, err)
+
+		share, err = waitForShare(vpcs, share.ID)
+		require.NoError(t, err)
+		assert.Equal(t, StatusStable, share.GetLifecycleState())
+
+		require.NotEmpty(t, progress)
+		last := progress[len(progress)-1]
+		assert.Equal(t, WaitForValidVolumeStateOp, last.Operation)
+		assert.Equal(t, share.ID, last.ID)
+		assert.Equal(t, len(progress), last.Attempt)
+		assert.Equal(t, StatusStable, last.State)
+		assert.Equal(t, fakevpc.StatePending, progress[0].State)
+	})
+
+	t.Run("failed fast with lifecycle reasons", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", Fail: true})
+		share, err := vpcs.Apiclient.FileShareService().CreateFileShare(fakeShareTemplate(), vpcs.Logger)
+		require.NoError(t, err)
+
+		_, err = waitForShare(vpcs, share.ID)
+		var stateErr *LifecycleStateError
+		require.ErrorAs(t, err, &stateErr)
+		assert.Equal(t, StatusFailed, stateErr.State)
+		require.Len(t, stateErr.Reasons, 1)
+		assert.Equal(t, fakevpc.ErrorCodeInternalError, stateErr.Reasons[0].Code)
+		assert.Contains(t, err.Error(), fakevpc.ErrorCodeInternalError)
+		// The wait stopped as soon as the share failed rather than polling on
+		assert.Less(t, fake.CallCount("GetFileShare"), vpcs.RetryPolicy.MaxAttempts)
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		vpcs.Poller = &Poller{Default: PollSettings{Interval: 5 * time.Millisecond, Timeout: 50 * time.Millisecond}}
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", Stuck: true})
+		share, err := vpcs.Apiclient.FileShareService().CreateFileShare(fakeShareTemplate(), vpcs.Logger)
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = waitForShare(vpcs, share.ID)
+		var timeoutErr *PollTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, fakevpc.StatePending, timeoutErr.State)
+		assert.Equal(t, 50*time.Millisecond, timeoutErr.Timeout)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", Stuck: true})
+		share, err := vpcs.Apiclient.FileShareService().CreateFileShare(fakeShareTemplate(), vpcs.Logger)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = waitForShare(vpcs.withContext(ctx), share.ID)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("permanent errors are not polled again", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+
+		_, err := waitForShare(vpcs, "r006-ffffffff-0000-4000-8000-ffffffffffff")
+		assert.True(t, isNotFound(err))
+		assert.Equal(t, 1, fake.CallCount("GetFileShare"))
+	})
+
+	t.Run("transient errors are polled again", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		share, err := vpcs.Apiclient.FileShareService().CreateFileShare(fakeShareTemplate(), vpcs.Logger)
+		require.NoError(t, err)
+		fault := fake.InjectFault(fakevpc.Fault{Operation: "GetFileShare", Times: 2, Code: fakevpc.ErrorCodeServiceError})
+
+		_, err = waitForShare(vpcs, share.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, fault.Fired())
+	})
+}
+
+func TestWaitHelpersOnFailedResources(t *testing.T) {
+	t.Run("volume creation failed", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShare", Fail: true})
+
+		_, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		assert.Equal(t, "VolumeNotInValidState", userErrorCode(err))
+		assert.Contains(t, err.(util.Message).BackendError, "failed lifecycle state")
+	})
+
+	t.Run("volume deletion failed", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "DeleteFileShare", Fail: true})
+
+		err = vpcs.DeleteVolume(volume)
+		assert.Equal(t, "FailedToDeleteVolume", userErrorCode(err))
+		assert.Contains(t, err.(util.Message).BackendError, fakevpc.ErrorCodeInternalError)
+	})
+
+	t.Run("volume deletion timed out", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "DeleteFileShare", Stuck: true})
+		vpcs.Poller = NewPoller(vpcs.RetryPolicy, map[string]time.Duration{WaitForVolumeDeletionOp: 30 * time.Millisecond})
+
+		start := time.Now()
+		assert.NoError(t, vpcs.DeleteVolume(volume))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("access point creation failed", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateFileShareTarget", Fail: true})
+		accessPointRequest := provider.VolumeAccessPointRequest{VolumeID: volume.VolumeID, VPCID: "vpc1"}
+		_, err = vpcs.CreateVolumeAccessPoint(accessPointRequest)
+		require.NoError(t, err)
+
+		_, err = vpcs.WaitForCreateVolumeAccessPoint(accessPointRequest)
+		assert.Equal(t, "CreateVolumeAccessPointTimedOut", userErrorCode(err))
+		assert.Contains(t, err.(util.Message).BackendError, fakevpc.ErrorCodeInternalError)
+	})
+}
+
+func TestLifecycleErrors(t *testing.T) {
+	stateErr := &LifecycleStateError{
+		Operation: WaitForValidVolumeStateOp,
+		ID:        "share1",
+		State:     StatusFailed,
+		Reasons:   []models.LifecycleReason{{Code: "internal_error", Message: "Internal error"}},
+	}
+	assert.Equal(t, "WaitForValidVolumeState: share1 is in the failed lifecycle state (internal_error: Internal error)", stateErr.Error())
+
+	lastErr := errors.New("service error")
+	timeoutErr := &PollTimeoutError{Operation: WaitForVolumeDeletionOp, ID: "share1", Timeout: time.Minute, Err: lastErr}
+	assert.Equal(t, "WaitForVolumeDeletion: share1 timed out after 1m0s: service error", timeoutErr.Error())
+	assert.ErrorIs(t, timeoutErr, lastErr)
+}
@@ -63,6 +63,7 @@ type VPCFileProvider struct {
 	ClientProvider riaas.RegionalAPIClientProvider
 	httpClient     *http.Client
 	APIConfig      riaas.Config
+	profiles       *ProfileCatalog
 }
 
 var _ local.Provider = &VPCFileProvider{}
@@ -93,6 +94,19 @@ func NewProvider(conf *vpcconfig.VPCFileConfig, k8sClient *k8s_utils.KubernetesC
 	//Mark this as enabled/active
 	conf.VPCConfig.VPCTypeEnabled = VPCNextGen
 
+	if conf.WaitTimeouts == nil {
+		waitTimeouts, err := vpcconfig.WaitTimeoutsFromEnv()
+		if err != nil {
+			logger.Error("Failed to parse the wait timeouts", util.ZapError(err))
+			return nil, err
+		}
+		conf.WaitTimeouts = waitTimeouts
+	}
+
+	if conf.GlobalTaggingURL == "" {
+		conf.GlobalTaggingURL = os.Getenv(vpcconfig.GlobalTaggingEndpointEnv)
+	}
+
 	contextCF, err := vpcauth.NewVPCContextCredentialsFactory(conf, k8sClient)
 	if err != nil {
 		return nil, err
@@ -113,8 +127,6 @@ func NewProvider(conf *vpcconfig.VPCFileConfig, k8sClient *k8s_utils.KubernetesC
 		return nil, err
 	}
 
-	// SetRetryParameters sets the retry logic parameters
-	SetRetryParameters(conf.VPCConfig.MaxRetryAttempt, conf.VPCConfig.MaxRetryGap)
 	provider := &VPCFileProvider{
 		timeout:        timeout,
 		Config:         conf,
@@ -122,12 +134,14 @@ func NewProvider(conf *vpcconfig.VPCFileConfig, k8sClient *k8s_utils.KubernetesC
 		ContextCF:      contextCF,
 		httpClient:     httpClient,
 		APIConfig: riaas.Config{
-			BaseURL:       conf.VPCConfig.G2EndpointURL,
-			HTTPClient:    httpClient,
-			APIVersion:    conf.VPCConfig.APIVersion,
-			APIGeneration: conf.VPCConfig.G2VPCAPIGeneration,
-			ResourceGroup: conf.VPCConfig.G2ResourceGroupID,
+			BaseURL:          conf.VPCConfig.G2EndpointURL,
+			HTTPClient:       httpClient,
+			APIVersion:       conf.VPCConfig.APIVersion,
+			APIGeneration:    conf.VPCConfig.G2VPCAPIGeneration,
+			ResourceGroup:    conf.VPCConfig.G2ResourceGroupID,
+			GlobalTaggingURL: conf.GlobalTaggingURL,
 		},
+		profiles: NewProfileCatalog(profileCatalogTTL),
 	}
 	userError.MessagesEn = userError.InitMessages()
 	return provider, nil
@@ -177,20 +191,22 @@ func (vpcp *VPCFileProvider) OpenSession(ctx context.Context, contextCredentials
 	}
 	ctxLogger.Debug("", zap.Reflect("Token", token.Token))
 
-	err = client.Login(token.Token)
+	// Keep the token fresh for long polls and long-lived sessions when the client supports it
+	tokenSourceAPI, ok := client.(riaas.TokenSourceAPI)
+	tokenSource := newIAMTokenSource(token.Token, vpcp.ContextCF, vpcp.Config.VPCConfig.G2APIKey, ctxLogger)
+	if ok && tokenSource != nil {
+		err = tokenSourceAPI.LoginWithTokenSource(tokenSource)
+	} else {
+		err = client.Login(token.Token)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// Update retry logic default values
-	if vpcp.Config.VPCConfig.MaxRetryAttempt > 0 {
-		ctxLogger.Debug("", zap.Reflect("MaxRetryAttempt", vpcp.Config.VPCConfig.MaxRetryAttempt))
-		maxRetryAttempt = vpcp.Config.VPCConfig.MaxRetryAttempt
-	}
-	if vpcp.Config.VPCConfig.MaxRetryGap > 0 {
-		ctxLogger.Debug("", zap.Reflect("MaxRetryGap", vpcp.Config.VPCConfig.MaxRetryGap))
-		maxRetryGap = vpcp.Config.VPCConfig.MaxRetryGap
-	}
+	// Each session gets its own retry policy, so sessions never share backoff state
+	retryPolicy := NewRetryPolicy(vpcp.Config.VPCConfig.MaxRetryAttempt, vpcp.Config.VPCConfig.MaxRetryGap)
+	poller := NewPoller(retryPolicy, vpcp.Config.WaitTimeouts)
+	ctxLogger.Debug("", zap.Reflect("RetryPolicy", retryPolicy), zap.Reflect("Poller", poller))
 
 	vpcSession := &VPCSession{
 		VPCAccountID:       contextCredentials.IAMAccountID,
@@ -200,7 +216,10 @@ func (vpcp *VPCFileProvider) OpenSession(ctx context.Context, contextCredentials
 		Provider:           VPC,
 		Apiclient:          client,
 		Logger:             ctxLogger,
-		APIRetry:           NewFlexyRetryDefault(),
+		APIRetry:           NewFlexyRetry(retryPolicy.MaxAttempts, int(retryPolicy.MaxGap/time.Second)),
+		RetryPolicy:        retryPolicy,
+		Poller:             poller,
+		Profiles:           vpcp.profiles,
 	}
 
 	return vpcSession, nil
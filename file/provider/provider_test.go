@@ -150,6 +150,19 @@ func TestNewProvider(t *testing.T) {
 	assert.NotNil(t, prov)
 	assert.Nil(t, err)
 
+	// The configured retry parameters are used by the sessions without a RetryPolicy, the package defaults stay untouched
+	attempts, gap := retryDefaults()
+	conf.VPCConfig.MaxRetryAttempt = 3
+	conf.VPCConfig.MaxRetryGap = 7
+	prov, err = NewProvider(conf, &kc, logger)
+	assert.NotNil(t, prov)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, (&VPCSession{Config: conf}).GetRetryPolicy().MaxAttempts)
+	assert.Equal(t, 7*time.Second, (&VPCSession{Config: conf}).GetRetryPolicy().MaxGap)
+	defaultAttempts, defaultGap := retryDefaults()
+	assert.Equal(t, attempts, defaultAttempts)
+	assert.Equal(t, gap, defaultGap)
+
 	// gen2 public endpoint related test
 	conf = &vpcconfig.VPCFileConfig{
 		VPCConfig: &config.VPCProviderConfig{
@@ -210,9 +223,6 @@ func GetTestProvider(t *testing.T, logger *zap.Logger) (*VPCFileProvider, error)
 	var cp *fakes.RegionalAPIClientProvider
 	var uc, sc *fakes.RegionalAPI
 
-	// SetRetryParameters sets the retry logic parameters
-	SetRetryParameters(2, 5)
-
 	logger.Info("Getting New test Provider")
 	conf := &vpcconfig.VPCFileConfig{
 		ServerConfig: &config.ServerConfig{
@@ -222,8 +232,8 @@ func GetTestProvider(t *testing.T, logger *zap.Logger) (*VPCFileProvider, error)
 			Enabled:                    true,
 			EndpointURL:                TestEndpointURL,
 			VPCTimeout:                 "30s",
-			MaxRetryAttempt:            5,
-			MaxRetryGap:                10,
+			MaxRetryAttempt:            2,
+			MaxRetryGap:                5,
 			APIVersion:                 TestAPIVersion,
 			G2EndpointPrivateURL:       PrivateRIaaSEndpoint,
 			IKSTokenExchangePrivateURL: PrivateContainerAPIURL,
@@ -290,6 +300,7 @@ func TestOpenSession(t *testing.T) {
 	defer teardown()
 
 	vpcp, _ := GetTestProvider(t, logger)
+	attempts, gap := retryDefaults()
 
 	sessn, err := vpcp.OpenSession(context.Background(), provider.ContextCredentials{
 		AuthType:     provider.IAMAccessToken,
@@ -300,6 +311,15 @@ func TestOpenSession(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, sessn)
 
+	// The session owns a retry policy built from the config, the package defaults stay untouched
+	vpcSession, ok := sessn.(*VPCSession)
+	require.True(t, ok)
+	assert.Equal(t, vpcp.Config.VPCConfig.MaxRetryAttempt, vpcSession.RetryPolicy.MaxAttempts)
+	assert.Equal(t, time.Duration(vpcp.Config.VPCConfig.MaxRetryGap)*time.Second, vpcSession.RetryPolicy.MaxGap)
+	defaultAttempts, defaultGap := retryDefaults()
+	assert.Equal(t, attempts, defaultAttempts)
+	assert.Equal(t, gap, defaultGap)
+
 	sessn, err = vpcp.OpenSession(context.Background(), provider.ContextCredentials{
 		AuthType:     provider.IAMAccessToken,
 		IAMAccountID: TestIKSAccountID,
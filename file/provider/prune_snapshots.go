@@ -0,0 +1,198 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// DefaultRetentionWorkers is the number of snapshots deleted concurrently when RetentionOptions sets no Workers
+const DefaultRetentionWorkers = 4
+
+// RetentionOptions control a run of PruneSnapshots
+type RetentionOptions struct {
+	// Policies are the retention policies, a snapshot is pruned when some policy selects it and none keeps it
+	Policies []RetentionPolicy
+	// DryRun reports what the retention would do without deleting anything
+	DryRun bool
+	// Workers bounds the snapshots deleted concurrently, across all the file shares
+	Workers int
+	// Now is the time the KeepWithin rules count from, the current time when zero
+	Now time.Time
+}
+
+// VolumeRetentionResult is the outcome of the retention for a file share, snapshot by snapshot
+type VolumeRetentionResult struct {
+	VolumeID  string
+	DryRun    bool
+	Snapshots []SnapshotRetentionResult
+	// Err is set when the snapshots of the file share could not be listed, none of them is pruned then
+	Err error
+}
+
+// ToPrune returns the snapshots the retention prunes, or would prune on a dry run
+func (result *VolumeRetentionResult) ToPrune() []SnapshotRetentionResult {
+	toPrune := []SnapshotRetentionResult{}
+	for _, snapshot := range result.Snapshots {
+		if snapshot.Action == RetentionPrune {
+			toPrune = append(toPrune, snapshot)
+		}
+	}
+	return toPrune
+}
+
+// Failed returns the snapshots which could not be deleted, the ones to retry
+func (result *VolumeRetentionResult) Failed() []SnapshotRetentionResult {
+	failed := []SnapshotRetentionResult{}
+	for _, snapshot := range result.Snapshots {
+		if snapshot.Err != nil {
+			failed = append(failed, snapshot)
+		}
+	}
+	return failed
+}
+
+// PruneSnapshots applies the retention policies of options to the snapshots of each file share of volumeIDs, and
+// deletes the snapshots they prune unless it is a dry run. Deletions run concurrently, Workers at a time, and a
+// failed deletion does not stop the other ones. The results are returned even on error, one per file share
+func (vpcs *VPCSession) PruneSnapshots(volumeIDs []string, options RetentionOptions) ([]*VolumeRetentionResult, error) {
+	vpcs.Logger.Debug("Entry of PruneSnapshots method...")
+	defer vpcs.Logger.Debug("Exit from PruneSnapshots method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "PruneSnapshots", time.Now())
+
+	vpcs.Logger.Info("Validating basic inputs for PruneSnapshots method...", zap.Strings("volumeIDs", volumeIDs), zap.Reflect("Options", options))
+	if len(volumeIDs) == 0 {
+		return nil, userError.GetUserError("ErrorRequiredFieldMissing", nil, "volumeIDs")
+	}
+	if len(options.Policies) == 0 {
+		return nil, userError.GetUserError("ErrorRequiredFieldMissing", nil, "Policies")
+	}
+	for _, policy := range options.Policies {
+		if err := policy.validate(); err != nil {
+			return nil, err
+		}
+	}
+	now := options.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	results := make([]*VolumeRetentionResult, len(volumeIDs))
+	for i, volumeID := range volumeIDs {
+		results[i] = &VolumeRetentionResult{VolumeID: volumeID, DryRun: options.DryRun}
+		snapshots, err := vpcs.listAllShareSnapshots(volumeID, nil)
+		if err != nil {
+			vpcs.Logger.Error("Failed to list the snapshots of the file share", zap.String("volumeID", volumeID), zap.Error(err))
+			results[i].Err = userError.GetUserError("ListSnapshotsFailed", err, volumeID)
+			continue
+		}
+		results[i].Snapshots = planRetention(snapshots, options.Policies, now)
+		vpcs.Logger.Info("Planned snapshot retention", zap.String("volumeID", volumeID), zap.Int("snapshots", len(snapshots)), zap.Int("toPrune", len(results[i].ToPrune())))
+	}
+
+	if !options.DryRun {
+		vpcs.pruneSnapshots(results, options.Workers)
+	}
+
+	failed := 0
+	var firstErr error
+	for _, result := range results {
+		err := result.Err
+		if errs := result.Failed(); err == nil && len(errs) > 0 {
+			err = errs[0].Err
+		}
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if failed > 0 {
+		vpcs.Logger.Error("Snapshot retention failed for some file shares", zap.Int("failed", failed), zap.Error(firstErr))
+		return results, userError.GetUserError("SnapshotRetentionFailed", firstErr, failed, len(results))
+	}
+	vpcs.Logger.Info("Successfully applied snapshot retention", zap.Int("volumes", len(results)), zap.Bool("dryRun", options.DryRun))
+	return results, nil
+}
+
+// PruneSnapshotsWithContext is PruneSnapshots which gives up on the backend calls once ctx is done, the snapshots
+// not deleted by then are failed with the error of ctx
+func (vpcs *VPCSession) PruneSnapshotsWithContext(ctx context.Context, volumeIDs []string, options RetentionOptions) ([]*VolumeRetentionResult, error) {
+	return vpcs.withContext(ctx).PruneSnapshots(volumeIDs, options)
+}
+
+// pruneJob is the deletion of a snapshot, outcome being its result
+type pruneJob struct {
+	snapshot *provider.Snapshot
+	outcome  *SnapshotRetentionResult
+}
+
+// pruneSnapshots deletes the snapshots results prune, workers at a time, and records the outcome in results
+func (vpcs *VPCSession) pruneSnapshots(results []*VolumeRetentionResult, workers int) {
+	if workers <= 0 {
+		workers = DefaultRetentionWorkers
+	}
+	ctx := vpcs.requestContext()
+
+	pending := []pruneJob{}
+	for _, result := range results {
+		for i := range result.Snapshots {
+			if result.Snapshots[i].Action == RetentionPrune {
+				snapshot := &provider.Snapshot{VolumeID: result.VolumeID, SnapshotID: result.Snapshots[i].SnapshotID}
+				pending = append(pending, pruneJob{snapshot: snapshot, outcome: &result.Snapshots[i]})
+			}
+		}
+	}
+
+	jobs := make(chan pruneJob)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				vpcs.Logger.Info("Pruning snapshot of the file share...", zap.String("volumeID", job.snapshot.VolumeID), zap.String("snapshotID", job.snapshot.SnapshotID))
+				// Each job has a result of its own, so the workers record outcomes without locking
+				job.outcome.Err = vpcs.DeleteSnapshot(job.snapshot)
+				job.outcome.Deleted = job.outcome.Err == nil
+			}
+		}()
+	}
+
+	for _, job := range pending {
+		if ctx.Err() != nil {
+			job.outcome.Err = ctx.Err()
+			continue
+		}
+		select {
+		case jobs <- job:
+		case <-ctx.Done():
+			job.outcome.Err = ctx.Err()
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
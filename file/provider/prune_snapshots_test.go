@@ -0,0 +1,127 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createStableSnapshots creates count snapshots of the volume named prefix-1 to prefix-count, and waits for them to be stable
+func createStableSnapshots(t *testing.T, vpcs *VPCSession, volumeID string, prefix string, count int) {
+	for i := 1; i <= count; i++ {
+		snapshot, err := vpcs.Apiclient.SnapshotService().CreateSnapshot(volumeID, &models.Snapshot{Name: fmt.Sprintf("%s-%d", prefix, i)}, vpcs.Logger)
+		require.NoError(t, err)
+		_, err = vpcs.WaitForSnapshotReady(volumeID, snapshot.ID, 0)
+		require.NoError(t, err)
+	}
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+	volume1, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume-1"))
+	require.NoError(t, err)
+	volume2, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume-2"))
+	require.NoError(t, err)
+	createStableSnapshots(t, vpcs, volume1.VolumeID, "rolling", 4)
+	createStableSnapshots(t, vpcs, volume1.VolumeID, "manual", 1)
+	createStableSnapshots(t, vpcs, volume2.VolumeID, "rolling", 3)
+	volumeIDs := []string{volume1.VolumeID, volume2.VolumeID}
+	options := RetentionOptions{Policies: []RetentionPolicy{{Name: "rolling", NamePrefix: "rolling-", KeepLast: 2}}, DryRun: true}
+
+	// A dry run reports the snapshots to prune without deleting them
+	results, err := vpcs.PruneSnapshots(volumeIDs, options)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].DryRun)
+	assert.Len(t, results[0].Snapshots, 5)
+	assert.Equal(t, []string{"rolling-2", "rolling-1"}, []string{results[0].ToPrune()[0].Name, results[0].ToPrune()[1].Name})
+	assert.Len(t, results[1].ToPrune(), 1)
+	assert.False(t, results[0].ToPrune()[0].Deleted)
+	assert.Equal(t, 0, fake.CallCount("DeleteSnapshot"))
+
+	options.DryRun = false
+	options.Workers = 2
+	results, err = vpcs.PruneSnapshotsWithContext(context.Background(), volumeIDs, options)
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.Empty(t, result.Failed())
+		for _, snapshot := range result.ToPrune() {
+			assert.True(t, snapshot.Deleted)
+		}
+	}
+	assert.Equal(t, 3, fake.CallCount("DeleteSnapshot"))
+	remaining, err := vpcs.ListAllSnapshots(context.Background(), map[string]string{"source_volume.id": volume1.VolumeID})
+	require.NoError(t, err)
+	assert.Len(t, remaining, 3)
+
+	// Nothing is left to prune
+	results, err = vpcs.PruneSnapshots(volumeIDs, options)
+	require.NoError(t, err)
+	assert.Empty(t, results[0].ToPrune())
+	assert.Empty(t, results[1].ToPrune())
+}
+
+func TestPruneSnapshotsFailures(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+	volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+	require.NoError(t, err)
+	createStableSnapshots(t, vpcs, volume.VolumeID, "rolling", 4)
+	options := RetentionOptions{Policies: []RetentionPolicy{{Name: "rolling", KeepLast: 1}}}
+
+	// A failed deletion does not stop the other ones, and the listing failure of a file share fails that file share only
+	fake.InjectFault(fakevpc.Fault{Operation: "DeleteSnapshot", OnCall: 1, Status: http.StatusBadRequest, Code: fakevpc.ErrorCodeBadRequest})
+	results, err := vpcs.PruneSnapshots([]string{volume.VolumeID, "missing-volume"}, options)
+	assert.Equal(t, "SnapshotRetentionFailed", userErrorCode(err))
+	require.Len(t, results, 2)
+	assert.Len(t, results[0].ToPrune(), 3)
+	require.Len(t, results[0].Failed(), 1)
+	assert.Equal(t, "FailedToDeleteSnapshot", userErrorCode(results[0].Failed()[0].Err))
+	assert.Equal(t, "ListSnapshotsFailed", userErrorCode(results[1].Err))
+	assert.Empty(t, results[1].Snapshots)
+
+	// Calling it again retries the snapshot which failed
+	results, err = vpcs.PruneSnapshots([]string{volume.VolumeID}, options)
+	require.NoError(t, err)
+	require.Len(t, results[0].ToPrune(), 1)
+	assert.True(t, results[0].ToPrune()[0].Deleted)
+
+	// Nothing is pruned once the context is done
+	createStableSnapshots(t, vpcs, volume.VolumeID, "more", 2)
+	deletes := fake.CallCount("DeleteSnapshot")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = vpcs.PruneSnapshotsWithContext(ctx, []string{volume.VolumeID}, options)
+	assert.Equal(t, "SnapshotRetentionFailed", userErrorCode(err))
+	assert.Contains(t, results[0].Err.Error(), context.Canceled.Error())
+	assert.Equal(t, deletes, fake.CallCount("DeleteSnapshot"))
+
+	_, err = vpcs.PruneSnapshots(nil, options)
+	assert.Equal(t, "ErrorRequiredFieldMissing", userErrorCode(err))
+	_, err = vpcs.PruneSnapshots([]string{volume.VolumeID}, RetentionOptions{})
+	assert.Equal(t, "ErrorRequiredFieldMissing", userErrorCode(err))
+	_, err = vpcs.PruneSnapshots([]string{volume.VolumeID}, RetentionOptions{Policies: []RetentionPolicy{{Name: "everything"}}})
+	assert.Equal(t, "InvalidRetentionPolicy", userErrorCode(err))
+}
@@ -0,0 +1,387 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// cronSpecFieldsCount is the number of fields of a replication cron spec: minute, hour, day of month, month and day of week
+const cronSpecFieldsCount = 5
+
+// ReplicaVolumeRequest is the request for a replica of an existing file share, usually in another zone
+type ReplicaVolumeRequest struct {
+	// Name is the name of the replica share
+	Name string
+	// SourceVolumeID is the ID of the file share to replicate, SourceVolumeCRN is used when empty
+	SourceVolumeID string
+	// SourceVolumeCRN is the CRN of the file share to replicate, for a source share in another region
+	SourceVolumeCRN string
+	// Zone is the zone of the replica share
+	Zone string
+	// ReplicationCronSpec is the schedule of the syncs from the source share, in cron format, e.g. "0 */6 * * *"
+	ReplicationCronSpec string
+	// Profile is the share profile of the replica share, the one of the source share when empty
+	Profile string
+	// ResourceGroup is the resource group of the replica share, the default resource group of the account when nil
+	ResourceGroup *provider.ResourceGroup
+}
+
+// VolumeReplication is the replication side of a file share
+type VolumeReplication struct {
+	VolumeID string
+	// Role is the replication role of the file share, one of models.ReplicationRoleNone, Replica or Source
+	Role string
+	// Status is the replication status of the file share, e.g. models.ReplicationStatusActive
+	Status string
+	// StatusReasons tell why the replication is degraded, if it is
+	StatusReasons []models.ReplicationStatusReason
+	// CronSpec is the schedule of the syncs, set for replica shares only
+	CronSpec string
+	// SourceVolume is the source share of a replica share
+	SourceVolume *models.ShareReference
+	// ReplicaVolume is the replica share of a source share
+	ReplicaVolume *models.ShareReference
+	// LatestSync is the latest completed sync of a replica share, nil until the first one completes
+	LatestSync *models.ShareLatestSync
+}
+
+// FailoverOptions control how a replica share takes over from its source share
+type FailoverOptions struct {
+	// FallbackPolicy is models.FailoverFallbackSplit for the replica share to be split from its source share when the
+	// source share cannot be reached, the failover fails then when empty
+	FallbackPolicy string
+	// Timeout is how long the backend tries to reach the source share before falling back, the backend default when zero
+	Timeout time.Duration
+}
+
+// CreateReplicaVolume creates a replica of the source file share, and waits for the replica share to be stable.
+// The replication is initializing by then, WaitForVolumeReplication waits for the first sync
+func (vpcs *VPCSession) CreateReplicaVolume(replicaRequest ReplicaVolumeRequest) (*provider.Volume, error) {
+	vpcs.Logger.Debug("Entry of CreateReplicaVolume method...")
+	defer vpcs.Logger.Debug("Exit from CreateReplicaVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "CreateReplicaVolume", time.Now())
+
+	operation, err := vpcs.StartCreateReplicaVolume(replicaRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	err = vpcs.WaitOperation(operation)
+	if err != nil {
+		return nil, err
+	}
+
+	vpcs.Logger.Info("Replica volume got valid (stable) state", zap.Reflect("VolumeDetails", operation.Volume))
+	return operation.Volume, nil
+}
+
+// StartCreateReplicaVolume asks for a replica of the source file share, and returns the operation to check back
+// on for the replica share to be stable
+func (vpcs *VPCSession) StartCreateReplicaVolume(replicaRequest ReplicaVolumeRequest) (*Operation, error) {
+	vpcs.Logger.Debug("Entry of StartCreateReplicaVolume method...")
+	defer vpcs.Logger.Debug("Exit from StartCreateReplicaVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "StartCreateReplicaVolume", time.Now())
+
+	vpcs.Logger.Info("Basic validation for CreateReplicaVolume request... ", zap.Reflect("ReplicaVolumeRequest", replicaRequest))
+	err := validateReplicaVolumeRequest(replicaRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	shareTemplate := buildReplicaShareTemplate(replicaRequest)
+
+	vpcs.Logger.Info("Calling VPC provider for replica volume creation...", zap.Reflect("ShareTemplate", shareTemplate))
+	var volume *models.Share
+	err = vpcs.retry(func() error {
+		volume, err = vpcs.Apiclient.FileShareService().CreateFileShare(shareTemplate, vpcs.Logger)
+		return err
+	})
+	if err != nil {
+		vpcs.Logger.Error("Failed to create replica volume from VPC provider", zap.Reflect("BackendError", err))
+		return nil, userError.GetUserError("FailedToCreateReplicaVolume", err, replicaRequest.Name, replicaSource(replicaRequest))
+	}
+
+	vpcs.Logger.Info("Successfully created replica volume from VPC provider...", zap.Reflect("VolumeDetails", volume))
+	operation := newOperation(CreateReplicaVolumeOperation, volume.ID, StatusStable)
+	operation.Volume = FromProviderToLibVolume(volume, vpcs.Logger)
+	return operation, nil
+}
+
+// CreateReplicaVolumeWithContext creates a replica of the source file share, giving up on the backend calls and the
+// wait for stable state once ctx is done
+func (vpcs *VPCSession) CreateReplicaVolumeWithContext(ctx context.Context, replicaRequest ReplicaVolumeRequest) (*provider.Volume, error) {
+	return vpcs.withContext(ctx).CreateReplicaVolume(replicaRequest)
+}
+
+// GetVolumeReplication returns the replication role and status of the file share, along with its latest sync
+func (vpcs *VPCSession) GetVolumeReplication(volumeID string) (*VolumeReplication, error) {
+	vpcs.Logger.Debug("Entry of GetVolumeReplication method...")
+	defer vpcs.Logger.Debug("Exit from GetVolumeReplication method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "GetVolumeReplication", time.Now())
+
+	if !IsValidVolumeIDFormat(volumeID) {
+		return nil, userError.GetUserError("InvalidVolumeID", nil, volumeID)
+	}
+
+	var share *models.Share
+	err := vpcs.retry(func() error {
+		var err error
+		share, err = vpcs.Apiclient.FileShareService().GetFileShare(volumeID, vpcs.Logger)
+		return err
+	})
+	if err != nil {
+		return nil, userError.GetUserError("StorageFindFailedWithVolumeId", err, volumeID)
+	}
+
+	replication := volumeReplication(share)
+	vpcs.Logger.Info("Successfully retrieved volume replication", zap.Reflect("VolumeReplication", replication))
+	return replication, nil
+}
+
+// GetVolumeReplicationWithContext returns the replication of the file share, giving up on the backend calls once ctx is done
+func (vpcs *VPCSession) GetVolumeReplicationWithContext(ctx context.Context, volumeID string) (*VolumeReplication, error) {
+	return vpcs.withContext(ctx).GetVolumeReplication(volumeID)
+}
+
+// FailoverVolume makes the replica file share the source of the replication, and the former source share its
+// replica, then waits for the replication to be active again
+func (vpcs *VPCSession) FailoverVolume(volumeID string, options FailoverOptions) error {
+	vpcs.Logger.Debug("Entry of FailoverVolume method...")
+	defer vpcs.Logger.Debug("Exit from FailoverVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "FailoverVolume", time.Now())
+
+	err := vpcs.checkReplicaVolume(volumeID)
+	if err != nil {
+		return err
+	}
+
+	failoverRequest := &models.ShareFailoverRequest{
+		FallbackPolicy: options.FallbackPolicy,
+		Timeout:        int64(options.Timeout / time.Second),
+	}
+	vpcs.Logger.Info("Calling VPC provider for volume failover...", zap.Reflect("FailoverRequest", failoverRequest))
+	err = vpcs.flexyRetry(func() (error, bool) {
+		err := vpcs.Apiclient.FileShareService().FailoverFileShare(volumeID, failoverRequest, vpcs.Logger)
+		if err != nil {
+			return err, vpcs.GetRetryPolicy().SkipRetry(err)
+		}
+		return nil, true
+	})
+	if err != nil {
+		vpcs.Logger.Error("Failed to failover volume from VPC provider", zap.Reflect("BackendError", err))
+		return userError.GetUserError("FailedToFailoverVolume", err, volumeID)
+	}
+
+	replication, err := WaitForVolumeReplication(vpcs, volumeID)
+	if err != nil {
+		return err
+	}
+	// With the split fallback policy, the replica share ends up on its own when the source share was unreachable
+	splitFallback := options.FallbackPolicy == models.FailoverFallbackSplit && replication.Role == models.ReplicationRoleNone
+	if !splitFallback && (replication.Role != models.ReplicationRoleSource || replication.Status != models.ReplicationStatusActive) {
+		return userError.GetUserError("VolumeReplicationNotInValidState", nil, volumeID, "the active source of the replication")
+	}
+
+	vpcs.Logger.Info("Successfully failed over volume", zap.Reflect("VolumeReplication", replication))
+	return nil
+}
+
+// FailoverVolumeWithContext fails the replication over to the replica file share, giving up on the backend calls
+// and the wait for the replication once ctx is done
+func (vpcs *VPCSession) FailoverVolumeWithContext(ctx context.Context, volumeID string, options FailoverOptions) error {
+	return vpcs.withContext(ctx).FailoverVolume(volumeID, options)
+}
+
+// SplitReplicaVolume ends the replication of the replica file share, which becomes independent of its source
+// share, then waits for the split to complete. The data synced so far is kept
+func (vpcs *VPCSession) SplitReplicaVolume(volumeID string) error {
+	vpcs.Logger.Debug("Entry of SplitReplicaVolume method...")
+	defer vpcs.Logger.Debug("Exit from SplitReplicaVolume method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "SplitReplicaVolume", time.Now())
+
+	err := vpcs.checkReplicaVolume(volumeID)
+	if err != nil {
+		return err
+	}
+
+	vpcs.Logger.Info("Calling VPC provider for replica volume split...", zap.Reflect("VolumeID", volumeID))
+	err = vpcs.flexyRetry(func() (error, bool) {
+		err := vpcs.Apiclient.FileShareService().DeleteFileShareSource(volumeID, vpcs.Logger)
+		if err != nil {
+			return err, vpcs.GetRetryPolicy().SkipRetry(err)
+		}
+		return nil, true
+	})
+	if err != nil {
+		vpcs.Logger.Error("Failed to split replica volume from VPC provider", zap.Reflect("BackendError", err))
+		return userError.GetUserError("FailedToSplitReplicaVolume", err, volumeID)
+	}
+
+	replication, err := WaitForVolumeReplication(vpcs, volumeID)
+	if err != nil {
+		return err
+	}
+	if replication.Role != models.ReplicationRoleNone || replication.Status != models.ReplicationStatusNone {
+		return userError.GetUserError("VolumeReplicationNotInValidState", nil, volumeID, "split")
+	}
+
+	vpcs.Logger.Info("Successfully split replica volume", zap.Reflect("VolumeID", volumeID))
+	return nil
+}
+
+// SplitReplicaVolumeWithContext splits the replica file share from its source, giving up on the backend calls and
+// the wait for the split once ctx is done
+func (vpcs *VPCSession) SplitReplicaVolumeWithContext(ctx context.Context, volumeID string) error {
+	return vpcs.withContext(ctx).SplitReplicaVolume(volumeID)
+}
+
+// WaitForVolumeReplication waits for the replication status of the file share to settle, i.e. for it not to be
+// initializing, failing over or splitting, and returns the settled replication
+func WaitForVolumeReplication(vpcs *VPCSession, volumeID string) (*VolumeReplication, error) {
+	vpcs.Logger.Debug("Entry of WaitForVolumeReplication method...")
+	defer vpcs.Logger.Debug("Exit from WaitForVolumeReplication method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "WaitForVolumeReplication", time.Now())
+
+	share, err := pollLifecycle(vpcs, LifecycleWait[*models.Share]{
+		Operation: WaitForVolumeReplicationOp,
+		ID:        volumeID,
+		Get: func() (*models.Share, error) {
+			return vpcs.Apiclient.FileShareService().GetFileShare(volumeID, vpcs.Logger)
+		},
+		Ready:    replicationSettled,
+		Terminal: []string{StatusDeleting, StatusDeleted},
+	})
+	if err != nil {
+		vpcs.Logger.Info("Volume replication did not settle", zap.Reflect("VolumeDetails", share))
+		return nil, userError.GetUserError("VolumeReplicationNotInValidState", err, volumeID, "settled")
+	}
+
+	replication := volumeReplication(share)
+	vpcs.Logger.Info("Volume replication settled", zap.Reflect("VolumeReplication", replication))
+	return replication, nil
+}
+
+// WaitForVolumeReplicationWithContext waits for the replication status of the file share to settle, giving up once ctx is done
+func WaitForVolumeReplicationWithContext(ctx context.Context, vpcs *VPCSession, volumeID string) (*VolumeReplication, error) {
+	return WaitForVolumeReplication(vpcs.withContext(ctx), volumeID)
+}
+
+// checkReplicaVolume returns an error unless the file share is a replica share
+func (vpcs *VPCSession) checkReplicaVolume(volumeID string) error {
+	replication, err := vpcs.GetVolumeReplication(volumeID)
+	if err != nil {
+		return err
+	}
+	if replication.Role != models.ReplicationRoleReplica {
+		return userError.GetUserError("VolumeNotReplica", nil, volumeID, replication.Role)
+	}
+	return nil
+}
+
+// replicationSettled tells if the replication status of the share is not transitional. The share must be stable
+// too, as its replication status is only updated once it is
+func replicationSettled(share *models.Share) bool {
+	if share.GetLifecycleState() != StatusStable {
+		return false
+	}
+	switch share.ReplicationStatus {
+	case models.ReplicationStatusInitializing, models.ReplicationStatusFailoverPending, models.ReplicationStatusSplitPending:
+		return false
+	}
+	return true
+}
+
+// volumeReplication returns the replication side of the share
+func volumeReplication(share *models.Share) *VolumeReplication {
+	role := share.ReplicationRole
+	if role == "" {
+		role = models.ReplicationRoleNone
+	}
+	status := share.ReplicationStatus
+	if status == "" {
+		status = models.ReplicationStatusNone
+	}
+	return &VolumeReplication{
+		VolumeID:      share.ID,
+		Role:          role,
+		Status:        status,
+		StatusReasons: share.ReplicationStatusReasons,
+		CronSpec:      share.ReplicationCronSpec,
+		SourceVolume:  share.SourceShare,
+		ReplicaVolume: share.ReplicaShare,
+		LatestSync:    share.LatestSync,
+	}
+}
+
+// validateReplicaVolumeRequest checks the replica request has what the backend requires for a replica share
+func validateReplicaVolumeRequest(replicaRequest ReplicaVolumeRequest) error {
+	if replicaRequest.Name == "" {
+		return userError.GetUserError("InvalidVolumeName", nil, nil)
+	}
+	if replicaRequest.SourceVolumeID == "" && replicaRequest.SourceVolumeCRN == "" {
+		return userError.GetUserError("InvalidReplicaVolumeRequest", nil, replicaRequest.Name, "the ID or CRN of the source file share is required")
+	}
+	if replicaRequest.SourceVolumeID == "" && !IsValidCRNFormat(replicaRequest.SourceVolumeCRN) {
+		return userError.GetUserError("InvalidReplicaVolumeRequest", nil, replicaRequest.Name, "the CRN of the source file share is not valid")
+	}
+	if replicaRequest.Zone == "" {
+		return userError.GetUserError("InvalidReplicaVolumeRequest", nil, replicaRequest.Name, "the zone of the replica is required")
+	}
+	if len(strings.Fields(replicaRequest.ReplicationCronSpec)) != cronSpecFieldsCount {
+		return userError.GetUserError("InvalidReplicaVolumeRequest", nil, replicaRequest.Name, "the replication cron spec must have 5 fields")
+	}
+	return nil
+}
+
+// buildReplicaShareTemplate builds the share template sent to the backend for the validated replica request. The
+// backend sizes the replica share as its source share
+func buildReplicaShareTemplate(replicaRequest ReplicaVolumeRequest) *models.Share {
+	shareTemplate := &models.Share{
+		Name:                replicaRequest.Name,
+		Zone:                &models.Zone{Name: replicaRequest.Zone},
+		ReplicationCronSpec: replicaRequest.ReplicationCronSpec,
+		SourceShare:         &models.ShareReference{ID: replicaRequest.SourceVolumeID},
+	}
+	if replicaRequest.SourceVolumeID == "" {
+		shareTemplate.SourceShare.CRN = replicaRequest.SourceVolumeCRN
+	}
+	if replicaRequest.Profile != "" {
+		shareTemplate.Profile = &models.Profile{Name: replicaRequest.Profile}
+	}
+	if replicaRequest.ResourceGroup != nil && (replicaRequest.ResourceGroup.ID != "" || replicaRequest.ResourceGroup.Name != "") {
+		shareTemplate.ResourceGroup = &models.ResourceGroup{ID: replicaRequest.ResourceGroup.ID, Name: replicaRequest.ResourceGroup.Name}
+	}
+	return shareTemplate
+}
+
+// replicaSource names the source share of the replica request, for the errors
+func replicaSource(replicaRequest ReplicaVolumeRequest) string {
+	if replicaRequest.SourceVolumeID != "" {
+		return replicaRequest.SourceVolumeID
+	}
+	return replicaRequest.SourceVolumeCRN
+}
@@ -0,0 +1,172 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeReplicaVolume creates a share and its replica in another zone, and waits for the first sync
+func fakeReplicaVolume(t *testing.T, vpcs *VPCSession) (string, string) {
+	source, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+	require.NoError(t, err)
+
+	replica, err := vpcs.CreateReplicaVolume(ReplicaVolumeRequest{
+		Name:                "fake-replica",
+		SourceVolumeID:      source.VolumeID,
+		Zone:                "us-south-2",
+		ReplicationCronSpec: "0 */6 * * *",
+	})
+	require.NoError(t, err)
+
+	replication, err := WaitForVolumeReplication(vpcs, replica.VolumeID)
+	require.NoError(t, err)
+	require.Equal(t, models.ReplicationStatusActive, replication.Status)
+	return source.VolumeID, replica.VolumeID
+}
+
+func TestCreateReplicaVolume(t *testing.T) {
+	t.Run("replica of a share", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		sourceID, replicaID := fakeReplicaVolume(t, vpcs)
+
+		replication, err := vpcs.GetVolumeReplication(replicaID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReplicationRoleReplica, replication.Role)
+		assert.Equal(t, "0 */6 * * *", replication.CronSpec)
+		assert.Equal(t, sourceID, replication.SourceVolume.ID)
+		require.NotNil(t, replication.LatestSync)
+		assert.NotNil(t, replication.LatestSync.CompletedAt)
+
+		replication, err = vpcs.GetVolumeReplication(sourceID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReplicationRoleSource, replication.Role)
+		assert.Equal(t, replicaID, replication.ReplicaVolume.ID)
+
+		replica, err := vpcs.GetVolume(replicaID)
+		require.NoError(t, err)
+		assert.Equal(t, "us-south-2", replica.Az)
+		assert.Equal(t, 10, *replica.Capacity)
+	})
+
+	t.Run("source already replicated", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		sourceID, _ := fakeReplicaVolume(t, vpcs)
+
+		_, err := vpcs.CreateReplicaVolume(ReplicaVolumeRequest{
+			Name:                "fake-replica-2",
+			SourceVolumeID:      sourceID,
+			Zone:                "us-south-3",
+			ReplicationCronSpec: "0 */6 * * *",
+		})
+		assert.Equal(t, "FailedToCreateReplicaVolume", userErrorCode(err))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		valid := ReplicaVolumeRequest{Name: "fake-replica", SourceVolumeID: "r006-source", Zone: "us-south-2", ReplicationCronSpec: "0 */6 * * *"}
+
+		noSource := valid
+		noSource.SourceVolumeID = ""
+		invalidCRN := noSource
+		invalidCRN.SourceVolumeCRN = "source"
+		noZone := valid
+		noZone.Zone = ""
+		invalidCronSpec := valid
+		invalidCronSpec.ReplicationCronSpec = "every 6 hours"
+		noName := valid
+		noName.Name = ""
+
+		for _, request := range []ReplicaVolumeRequest{noSource, invalidCRN, noZone, invalidCronSpec} {
+			_, err := vpcs.CreateReplicaVolume(request)
+			assert.Equal(t, "InvalidReplicaVolumeRequest", userErrorCode(err), request)
+		}
+		_, err := vpcs.CreateReplicaVolume(noName)
+		assert.Equal(t, "InvalidVolumeName", userErrorCode(err))
+		assert.Equal(t, 0, fake.CallCount("CreateFileShare"))
+	})
+}
+
+func TestFailoverVolume(t *testing.T) {
+	t.Run("replica takes over", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		sourceID, replicaID := fakeReplicaVolume(t, vpcs)
+
+		require.NoError(t, vpcs.FailoverVolume(replicaID, FailoverOptions{Timeout: time.Minute}))
+
+		replication, err := vpcs.GetVolumeReplication(replicaID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReplicationRoleSource, replication.Role)
+		assert.Equal(t, models.ReplicationStatusActive, replication.Status)
+		replication, err = vpcs.GetVolumeReplication(sourceID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReplicationRoleReplica, replication.Role)
+		assert.Equal(t, "0 */6 * * *", replication.CronSpec)
+	})
+
+	t.Run("source share", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		sourceID, _ := fakeReplicaVolume(t, vpcs)
+
+		err := vpcs.FailoverVolume(sourceID, FailoverOptions{})
+		assert.Equal(t, "VolumeNotReplica", userErrorCode(err))
+		assert.Equal(t, 0, fake.CallCount("FailoverFileShare"))
+	})
+
+	t.Run("failed replication", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		_, replicaID := fakeReplicaVolume(t, vpcs)
+		fake.InjectFault(fakevpc.Fault{Operation: "FailoverFileShare", Fail: true})
+
+		err := vpcs.FailoverVolume(replicaID, FailoverOptions{})
+		assert.Equal(t, "VolumeReplicationNotInValidState", userErrorCode(err))
+
+		replication, err := vpcs.GetVolumeReplication(replicaID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReplicationStatusDegraded, replication.Status)
+		assert.NotEmpty(t, replication.StatusReasons)
+	})
+}
+
+func TestSplitReplicaVolume(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+	sourceID, replicaID := fakeReplicaVolume(t, vpcs)
+
+	require.NoError(t, vpcs.SplitReplicaVolume(replicaID))
+
+	for _, volumeID := range []string{sourceID, replicaID} {
+		replication, err := vpcs.GetVolumeReplication(volumeID)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReplicationRoleNone, replication.Role)
+		assert.Equal(t, models.ReplicationStatusNone, replication.Status)
+	}
+
+	// The split shares are independent, so either one can be deleted
+	replica, err := vpcs.GetVolume(replicaID)
+	require.NoError(t, err)
+	require.NoError(t, vpcs.DeleteVolume(replica))
+
+	err = vpcs.SplitReplicaVolume(sourceID)
+	assert.Equal(t, "VolumeNotReplica", userErrorCode(err))
+	assert.Equal(t, 1, fake.CallCount("DeleteFileShareSource"))
+}
@@ -0,0 +1,206 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"go.uber.org/zap"
+)
+
+// defaultRetryJitter is the fraction of each retry gap which is randomised
+const defaultRetryJitter = 0.1
+
+// RetryPolicy controls how a VPCSession retries backend calls and polls for state changes.
+// A policy is read-only once built, each call keeps its own backoff state, so concurrent
+// operations sharing a policy never stretch each other's retry gap
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a call is attempted
+	MaxAttempts int
+	// InitialGap is the wait before the second attempt, it doubles from the third attempt onwards up to MaxGap
+	InitialGap time.Duration
+	// MaxGap caps the exponential backoff
+	MaxGap time.Duration
+	// ConstantGap is the wait between polls of FlexyRetryWithConstGap
+	ConstantGap time.Duration
+	// Jitter is the fraction (0 to 1) of each gap which is randomised, to spread out concurrent callers
+	Jitter float64
+	// MaxElapsedTime stops retrying once this much time has passed since the first attempt, 0 means no limit
+	MaxElapsedTime time.Duration
+	// MaxRetryAfter caps the wait requested by a throttled response through Retry-After, 0 means MaxGap
+	MaxRetryAfter time.Duration
+	// SkipErrorCodes overrides skipErrorCodes per error code, true stops retrying and false keeps retrying
+	SkipErrorCodes map[string]bool
+}
+
+// NewRetryPolicy builds a retry policy from the max_retry_attempt and max_retry_gap (seconds)
+// configuration, falling back to the package defaults for values that are not set
+func NewRetryPolicy(maxAttempts int, maxGap int) *RetryPolicy {
+	defaultAttempts, defaultGap := retryDefaults()
+	if maxAttempts <= 0 {
+		maxAttempts = defaultAttempts
+	}
+	if maxGap <= 0 {
+		maxGap = defaultGap
+	}
+	return &RetryPolicy{
+		MaxAttempts: maxAttempts,
+		InitialGap:  time.Duration(retryGap) * time.Second,
+		MaxGap:      time.Duration(maxGap) * time.Second,
+		ConstantGap: ConstantRetryGap * time.Second,
+		Jitter:      defaultRetryJitter,
+	}
+}
+
+// SkipRetry tells if err is permanent as per SkipErrorCodes and skipErrorCodes.
+// Throttled requests are always worth retrying
+func (p *RetryPolicy) SkipRetry(err error) bool {
+	if client.IsThrottled(err) {
+		return false
+	}
+	var modelError *models.Error
+	if !errors.As(err, &modelError) {
+		return false
+	}
+	for _, errorItem := range modelError.Errors {
+		if skipStatus, ok := p.SkipErrorCodes[string(errorItem.Code)]; ok {
+			return skipStatus
+		}
+		if skipStatus, ok := skipErrorCodes[string(errorItem.Code)]; ok {
+			return skipStatus
+		}
+	}
+	return false
+}
+
+// Retry calls retryFunc until it succeeds, fails with an error which is not worth retrying,
+// the attempts or the max elapsed time run out, or ctx is done
+func (p *RetryPolicy) Retry(ctx context.Context, logger *zap.Logger, retryFunc func() error) error {
+	return p.RetryWithAttempts(ctx, logger, p.MaxAttempts, retryFunc)
+}
+
+// RetryWithAttempts is Retry with a caller chosen number of attempts
+func (p *RetryPolicy) RetryWithAttempts(ctx context.Context, logger *zap.Logger, attempts int, retryFunc func() error) error {
+	return p.run(ctx, logger, attempts, true, func() (error, bool) {
+		err := retryFunc()
+		if err == nil {
+			return nil, true
+		}
+		var modelError *models.Error
+		if !errors.As(err, &modelError) {
+			// Only backend errors, throttled ones included, are classified, anything else is retried as is
+			return err, false
+		}
+		return err, p.SkipRetry(err)
+	})
+}
+
+// FlexyRetry calls funcToRetry with exponential backoff until it asks to stop
+func (p *RetryPolicy) FlexyRetry(ctx context.Context, logger *zap.Logger, funcToRetry func() (error, bool)) error {
+	return p.run(ctx, logger, p.MaxAttempts, true, funcToRetry)
+}
+
+// FlexyRetryWithConstGap polls funcToRetry with a constant gap until it asks to stop.
+// Polls get four times as many attempts as regular retries
+func (p *RetryPolicy) FlexyRetryWithConstGap(ctx context.Context, logger *zap.Logger, funcToRetry func() (error, bool)) error {
+	return p.run(ctx, logger, p.MaxAttempts*4, false, funcToRetry)
+}
+
+// run is the retry loop shared by all the policy methods
+func (p *RetryPolicy) run(ctx context.Context, logger *zap.Logger, attempts int, backoff bool, funcToRetry func() (error, bool)) error {
+	var err error
+	var stopRetry bool
+	start := time.Now()
+	gap := p.InitialGap
+	if !backoff {
+		gap = p.ConstantGap
+	} else if p.MaxGap > 0 && gap > p.MaxGap {
+		gap = p.MaxGap
+	}
+
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			// Exponential backoff from the third attempt onwards
+			if backoff && i >= 2 {
+				gap = 2 * gap
+				if gap > p.MaxGap {
+					gap = p.MaxGap
+				}
+			}
+			wait := p.withJitter(gap)
+			if retryAfter := p.retryAfter(err); retryAfter > wait {
+				// Pace ourselves as asked by the API rather than hammering it with our own gap
+				wait = retryAfter
+			}
+			if p.MaxElapsedTime > 0 && time.Since(start)+wait > p.MaxElapsedTime {
+				logger.Warn("Max elapsed time reached, stopping retry", zap.Duration("max-elapsed-time", p.MaxElapsedTime), zap.Error(err))
+				return err
+			}
+			if ctxErr := sleepWithContext(ctx, wait); ctxErr != nil {
+				logger.Warn("Context done, stopping retry", zap.Error(ctxErr), zap.NamedError("lastError", err))
+				return ctxErr
+			}
+		}
+
+		// Call function which required retry, retry is decided by function itself
+		err, stopRetry = funcToRetry()
+		if stopRetry {
+			break
+		}
+
+		if (i + 1) < attempts {
+			logger.Info("Error while executing the function. Re-attempting execution ..", zap.Int("attempt..", i+2),
+				zap.Duration("retry-gap", gap), zap.Int("max-retry-Attempts", attempts), zap.Error(err))
+		}
+	}
+	return err
+}
+
+// retryAfter returns the capped wait requested by the API when err is a throttled response
+func (p *RetryPolicy) retryAfter(err error) time.Duration {
+	retryAfter, ok := client.RetryAfter(err)
+	if !ok {
+		return 0
+	}
+	maxRetryAfter := p.MaxRetryAfter
+	if maxRetryAfter <= 0 {
+		maxRetryAfter = p.MaxGap
+	}
+	if retryAfter > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return retryAfter
+}
+
+// withJitter randomises gap by up to +/- Jitter of its value
+func (p *RetryPolicy) withJitter(gap time.Duration) time.Duration {
+	if p.Jitter <= 0 || gap <= 0 {
+		return gap
+	}
+	jitter := p.Jitter
+	if jitter > 1 {
+		jitter = 1
+	}
+	delta := (rand.Float64()*2 - 1) * jitter * float64(gap) // #nosec G404 jitter does not need a secure source
+	return gap + time.Duration(delta)
+}
@@ -0,0 +1,237 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		InitialGap:  10 * time.Millisecond,
+		MaxGap:      20 * time.Millisecond,
+		ConstantGap: 5 * time.Millisecond,
+	}
+}
+
+func modelError(code string) error {
+	return &models.Error{Errors: []models.ErrorItem{{Code: models.ErrorCode(code)}}}
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	attempts, gap := retryDefaults()
+	t.Cleanup(func() { SetRetryParameters(attempts, gap) })
+	SetRetryParameters(2, 5)
+
+	policy := NewRetryPolicy(0, 0)
+	assert.Equal(t, 2, policy.MaxAttempts)
+	assert.Equal(t, 5*time.Second, policy.MaxGap)
+	assert.Equal(t, time.Duration(retryGap)*time.Second, policy.InitialGap)
+	assert.Equal(t, ConstantRetryGap*time.Second, policy.ConstantGap)
+
+	policy = NewRetryPolicy(7, 30)
+	assert.Equal(t, 7, policy.MaxAttempts)
+	assert.Equal(t, 30*time.Second, policy.MaxGap)
+}
+
+func TestRetryPolicySkipRetry(t *testing.T) {
+	policy := testRetryPolicy()
+	assert.True(t, policy.SkipRetry(modelError("shares_name_duplicate")))
+	assert.False(t, policy.SkipRetry(modelError("internal_error")))
+	assert.False(t, policy.SkipRetry(modelError("unknown_code")))
+	assert.False(t, policy.SkipRetry(errors.New("not a backend error")))
+
+	policy.SkipErrorCodes = map[string]bool{"shares_name_duplicate": false, "internal_error": true}
+	assert.False(t, policy.SkipRetry(modelError("shares_name_duplicate")))
+	assert.True(t, policy.SkipRetry(modelError("internal_error")))
+}
+
+func TestRetryPolicyRetry(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+
+	testCases := []struct {
+		testCaseName     string
+		errs             []error
+		expectedAttempts int
+		expectedErr      error
+	}{
+		{
+			testCaseName:     "Success on first attempt",
+			errs:             []error{nil},
+			expectedAttempts: 1,
+		}, {
+			testCaseName:     "Success after retryable errors",
+			errs:             []error{modelError("internal_error"), errors.New("connection reset"), nil},
+			expectedAttempts: 3,
+		}, {
+			testCaseName:     "Stop on error which is not retryable",
+			errs:             []error{modelError("internal_error"), modelError("shares_not_found")},
+			expectedAttempts: 2,
+			expectedErr:      modelError("shares_not_found"),
+		}, {
+			testCaseName:     "Give up after max attempts",
+			errs:             []error{modelError("internal_error")},
+			expectedAttempts: 4,
+			expectedErr:      modelError("internal_error"),
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.testCaseName, func(t *testing.T) {
+			var attempts int
+			err := testRetryPolicy().Retry(context.Background(), logger, func() error {
+				err := testcase.errs[len(testcase.errs)-1]
+				if attempts < len(testcase.errs) {
+					err = testcase.errs[attempts]
+				}
+				attempts++
+				return err
+			})
+			assert.Equal(t, testcase.expectedAttempts, attempts)
+			assert.Equal(t, testcase.expectedErr, err)
+		})
+	}
+}
+
+func TestRetryPolicyMaxElapsedTime(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+
+	policy := testRetryPolicy()
+	policy.MaxAttempts = 100
+	policy.MaxElapsedTime = 50 * time.Millisecond
+
+	var attempts int
+	start := time.Now()
+	err := policy.FlexyRetry(context.Background(), logger, func() (error, bool) {
+		attempts++
+		return errors.New("not yet"), false
+	})
+	assert.EqualError(t, err, "not yet")
+	assert.True(t, attempts < 100)
+	assert.True(t, time.Since(start) <= policy.MaxElapsedTime)
+}
+
+func TestRetryPolicyConcurrentCallsKeepOwnBackoff(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+
+	// A slow operation exhausting its backoff must not stretch the gap of another operation
+	policy := testRetryPolicy()
+	policy.InitialGap = 20 * time.Millisecond
+	policy.MaxGap = time.Second
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = policy.FlexyRetry(context.Background(), logger, func() (error, bool) {
+			return errors.New("slow"), false
+		})
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	var attempts int
+	start := time.Now()
+	err := policy.FlexyRetry(context.Background(), logger, func() (error, bool) {
+		attempts++
+		return nil, attempts == 2
+	})
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) < 2*policy.InitialGap)
+	wg.Wait()
+}
+
+func TestRetryPolicyConstGap(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+
+	var attempts int
+	err := testRetryPolicy().FlexyRetryWithConstGap(context.Background(), logger, func() (error, bool) {
+		attempts++
+		return errors.New("pending"), false
+	})
+	assert.EqualError(t, err, "pending")
+	assert.Equal(t, 16, attempts)
+}
+
+func TestRetryPolicyThrottled(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+
+	throttled := &client.ThrottledError{
+		StatusCode: 429,
+		RetryAfter: 30 * time.Millisecond,
+		Err:        modelError("share_snapshot_rate_too_high"),
+	}
+
+	policy := testRetryPolicy()
+	policy.InitialGap = time.Millisecond
+	policy.MaxGap = time.Second
+	assert.False(t, policy.SkipRetry(throttled))
+
+	var attempts int
+	start := time.Now()
+	err := policy.Retry(context.Background(), logger, func() error {
+		attempts++
+		if attempts == 1 {
+			return throttled
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.True(t, time.Since(start) >= throttled.RetryAfter)
+
+	// The wait asked by the API is capped
+	throttled.RetryAfter = time.Hour
+	assert.Equal(t, policy.MaxGap, policy.retryAfter(throttled))
+	policy.MaxRetryAfter = time.Minute
+	assert.Equal(t, time.Minute, policy.retryAfter(throttled))
+	assert.Equal(t, time.Duration(0), policy.retryAfter(modelError("internal_error")))
+
+	// The backend error of a throttled request is classified as any other
+	assert.True(t, hasErrorCode(throttled, "share_snapshot_rate_too_high"))
+	assert.True(t, isNotFound(&client.ThrottledError{StatusCode: 503, Err: modelError(SharesNotFound)}))
+	wrapped := fmt.Errorf("wrapped: %w", modelError(SharesNameDuplicate))
+	assert.True(t, policy.SkipRetry(wrapped))
+	attempts = 0
+	err = policy.Retry(context.Background(), logger, func() error {
+		attempts++
+		return wrapped
+	})
+	assert.Equal(t, wrapped, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryPolicyJitter(t *testing.T) {
+	policy := testRetryPolicy()
+	assert.Equal(t, time.Second, policy.withJitter(time.Second))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		gap := policy.withJitter(time.Second)
+		assert.True(t, gap >= 500*time.Millisecond && gap <= 1500*time.Millisecond)
+	}
+}
@@ -18,6 +18,9 @@
 package provider
 
 import (
+	"context"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/globaltagging"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas"
 	vpcconfig "github.com/IBM/ibmcloud-volume-file-vpc/file/vpcconfig"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
@@ -38,6 +41,16 @@ type VPCSession struct {
 	Logger             *zap.Logger
 	APIRetry           FlexyRetry
 	SessionError       error
+
+	// RetryPolicy is used by every retry and wait path of the session, one built from Config is used when nil
+	RetryPolicy *RetryPolicy
+	// Poller is used by every Wait* helper of the session, one matching RetryPolicy is used when nil
+	Poller *Poller
+	// Profiles caches the share profiles across the sessions of a provider, they are fetched on each use when nil
+	Profiles *ProfileCatalog
+
+	// ctx is the per-call context bound through withContext, nil means context.Background()
+	ctx context.Context
 }
 
 const (
@@ -67,3 +80,60 @@ func (vpcs *VPCSession) ProviderName() provider.VolumeProvider {
 func (vpcs *VPCSession) Type() provider.VolumeType {
 	return VolumeType
 }
+
+// requestContext returns the context bound to this session
+func (vpcs *VPCSession) requestContext() context.Context {
+	if vpcs.ctx == nil {
+		return context.Background()
+	}
+	return vpcs.ctx
+}
+
+// RequestContext returns the context bound to this session, so sessions built on top of it in other packages
+// can bind their own backend calls to it too
+func (vpcs *VPCSession) RequestContext() context.Context {
+	return vpcs.requestContext()
+}
+
+// withContext returns a shallow copy of the session whose backend calls and retry waits are bound to ctx.
+// The original session is left untouched so it can keep serving other calls
+func (vpcs *VPCSession) withContext(ctx context.Context) *VPCSession {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	scoped := *vpcs
+	scoped.ctx = ctx
+	if api, ok := vpcs.Apiclient.(riaas.ContextAwareAPI); ok {
+		scoped.Apiclient = api.WithContext(ctx)
+	}
+	return &scoped
+}
+
+// tagService returns the Global Tagging service of the session, nil when the API client has none
+func (vpcs *VPCSession) tagService() globaltagging.TagManager {
+	if api, ok := vpcs.Apiclient.(riaas.TaggingAPI); ok {
+		return api.TagService()
+	}
+	return nil
+}
+
+// GetRetryPolicy returns the retry policy of the session
+func (vpcs *VPCSession) GetRetryPolicy() *RetryPolicy {
+	if vpcs.RetryPolicy == nil {
+		if vpcs.Config != nil && vpcs.Config.VPCConfig != nil {
+			return NewRetryPolicy(vpcs.Config.VPCConfig.MaxRetryAttempt, vpcs.Config.VPCConfig.MaxRetryGap)
+		}
+		return NewRetryPolicy(0, 0)
+	}
+	return vpcs.RetryPolicy
+}
+
+// retry runs retryFunc under the session retry policy and context
+func (vpcs *VPCSession) retry(retryFunc func() error) error {
+	return vpcs.GetRetryPolicy().Retry(vpcs.requestContext(), vpcs.Logger, retryFunc)
+}
+
+// flexyRetry runs funcToRetry under the session retry policy and context
+func (vpcs *VPCSession) flexyRetry(funcToRetry func() (error, bool)) error {
+	return vpcs.GetRetryPolicy().FlexyRetry(vpcs.requestContext(), vpcs.Logger, funcToRetry)
+}
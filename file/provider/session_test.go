@@ -18,8 +18,10 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas/fakes"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
@@ -58,3 +60,22 @@ func TestGetProviderDisplayName(t *testing.T) {
 
 	assert.Equal(t, provider.VolumeProvider("VPC-SHARE"), ccf.GetProviderDisplayName())
 }
+
+func TestSessionWithContext(t *testing.T) {
+	apiClient := &fakes.RegionalAPI{}
+	vpcs := &VPCSession{
+		Apiclient: apiClient,
+		Logger:    logger,
+	}
+	assert.Equal(t, context.Background(), vpcs.requestContext())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scoped := vpcs.withContext(ctx)
+	assert.Equal(t, ctx, scoped.requestContext())
+	assert.Equal(t, ctx, scoped.RequestContext())
+	assert.Equal(t, apiClient, scoped.Apiclient)
+
+	// the original session must not pick up the per-call context
+	assert.Equal(t, context.Background(), vpcs.requestContext())
+}
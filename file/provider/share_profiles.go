@@ -0,0 +1,270 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	vpcfile "github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/vpcfilevolume"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"go.uber.org/zap"
+)
+
+// profileCatalogTTL is how long the provider uses the share profiles before fetching them again
+const profileCatalogTTL = 30 * time.Minute
+
+// profileFailureTTL is how long the catalog remembers that the share profiles, or a profile missing from them,
+// could not be fetched, so that the requests in the meantime do not wait on the backend again
+const profileFailureTTL = time.Minute
+
+// ProfileCatalog caches the share profiles of the region, which rarely change, so that requests can be
+// validated without a round trip. It is safe for use by concurrent sessions, which share a single fetch
+type ProfileCatalog struct {
+	ttl        time.Duration
+	failureTTL time.Duration
+
+	mu        sync.Mutex
+	profiles  map[string]models.ProfileDetails
+	fetchedAt time.Time
+	// fetching is closed once the fetch in flight ends, nil when there is none
+	fetching chan struct{}
+	fetchErr error
+	failedAt time.Time
+	// missing are the errors of the profiles which could not be fetched on their own, by name
+	missing map[string]profileFailure
+}
+
+// profileFailure is an error fetching share profiles, and when it happened
+type profileFailure struct {
+	err      error
+	failedAt time.Time
+}
+
+// NewProfileCatalog returns an empty catalog, whose profiles are fetched again once older than ttl
+func NewProfileCatalog(ttl time.Duration) *ProfileCatalog {
+	return &ProfileCatalog{ttl: ttl, failureTTL: profileFailureTTL}
+}
+
+// Invalidate drops the cached profiles and failures, the next lookup fetches the profiles again
+func (c *ProfileCatalog) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.profiles = nil
+	c.fetchErr = nil
+	c.missing = nil
+}
+
+// lookup returns the profile name, calling fetch first when the profiles are missing or stale. Concurrent lookups
+// wait for the fetch in flight rather than calling fetch again, and a failed fetch is returned without calling
+// fetch for failureTTL. found is false when the catalog has no such profile
+func (c *ProfileCatalog) lookup(name string, fetch func() ([]models.ProfileDetails, error)) (profile models.ProfileDetails, found bool, err error) {
+	for {
+		c.mu.Lock()
+		if c.profiles != nil && time.Since(c.fetchedAt) <= c.ttl {
+			profile, found = c.profiles[name]
+			c.mu.Unlock()
+			return profile, found, nil
+		}
+		if c.fetchErr != nil && time.Since(c.failedAt) <= c.failureTTL {
+			err = c.fetchErr
+			c.mu.Unlock()
+			return models.ProfileDetails{}, false, err
+		}
+		if fetching := c.fetching; fetching != nil {
+			c.mu.Unlock()
+			<-fetching
+			continue
+		}
+		c.fetching = make(chan struct{})
+		c.mu.Unlock()
+
+		c.refresh(fetch)
+	}
+}
+
+// refresh calls fetch without holding the lock, then records the profiles or the failure and wakes up the
+// lookups waiting for it. The errors of a session context being done are not recorded, they are not the backend's
+func (c *ProfileCatalog) refresh(fetch func() ([]models.ProfileDetails, error)) {
+	profiles, err := fetch()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch {
+	case err == nil:
+		c.profiles = make(map[string]models.ProfileDetails, len(profiles))
+		for _, profile := range profiles {
+			c.profiles[profile.Name] = profile
+		}
+		c.fetchedAt = time.Now()
+		c.fetchErr = nil
+	case !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded):
+		c.fetchErr = err
+		c.failedAt = time.Now()
+	}
+	close(c.fetching)
+	c.fetching = nil
+}
+
+// missingError returns the error of the profile name when it could not be fetched on its own within failureTTL
+func (c *ProfileCatalog) missingError(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if failure, ok := c.missing[name]; ok && time.Since(failure.failedAt) <= c.failureTTL {
+		return failure.err
+	}
+	return nil
+}
+
+// setMissing records that the profile name could not be fetched on its own
+func (c *ProfileCatalog) setMissing(name string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.missing == nil {
+		c.missing = map[string]profileFailure{}
+	}
+	c.missing[name] = profileFailure{err: err, failedAt: time.Now()}
+}
+
+// ListShareProfiles lists every share profile of the region, following the pages of the collection
+func (vpcs *VPCSession) ListShareProfiles(ctx context.Context) ([]models.ProfileDetails, error) {
+	vpcs.Logger.Info("Entry ListShareProfiles")
+	defer vpcs.Logger.Info("Exit ListShareProfiles")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "ListShareProfiles", time.Now())
+
+	scoped := vpcs.withContext(ctx)
+	profilePager := vpcfile.NewShareProfilePager(scoped.Apiclient.FileShareService(), maxLimit, scoped.Logger).WithRetry(scoped.retry)
+
+	profiles, err := profilePager.All(ctx)
+	if err != nil {
+		return nil, userError.GetUserError("ListShareProfilesFailed", err)
+	}
+
+	vpcs.Logger.Info("Successfully retrieved all share profiles", zap.Int("count", len(profiles)))
+	return profiles, nil
+}
+
+// GetShareProfileDetails returns the share profile name with its allowed values, from the profile catalog of the
+// session when it has one. Profiles missing from the catalog are fetched on their own, e.g. when just released.
+// The profiles are fetched in a single attempt, without retries, as they only serve to validate requests
+func (vpcs *VPCSession) GetShareProfileDetails(name string) (*models.ProfileDetails, error) {
+	if vpcs.Profiles != nil {
+		profile, found, err := vpcs.Profiles.lookup(name, func() ([]models.ProfileDetails, error) {
+			return vpcfile.NewShareProfilePager(vpcs.Apiclient.FileShareService(), maxLimit, vpcs.Logger).All(vpcs.requestContext())
+		})
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return &profile, nil
+		}
+		if err := vpcs.Profiles.missingError(name); err != nil {
+			return nil, err
+		}
+	}
+
+	profile, err := vpcs.Apiclient.FileShareService().GetShareProfile(name, vpcs.Logger)
+	if err != nil {
+		if vpcs.Profiles != nil && vpcs.requestContext().Err() == nil {
+			vpcs.Profiles.setMissing(name, err)
+		}
+		return nil, err
+	}
+	return profile, nil
+}
+
+// validateAgainstShareProfile checks the capacity, IOPS and bandwidth of a request against the share profile,
+// zero IOPS and bandwidth being left to the profile defaults. The request is not checked when the profile cannot
+// be fetched, the backend still rejects it if need be
+func (vpcs *VPCSession) validateAgainstShareProfile(profileName string, capacity int64, iops int64, bandwidth int32) error {
+	profile, err := vpcs.GetShareProfileDetails(profileName)
+	if err != nil || profile == nil {
+		vpcs.Logger.Warn("Failed to get the share profile, skipping the validation of the request against it", zap.String("profile", profileName), zap.Error(err))
+		return nil
+	}
+	return validateShareProfileValues(profile, capacity, iops, bandwidth)
+}
+
+// validateShareProfileValues checks capacity, and iops and bandwidth when set, against the allowed values of profile
+func validateShareProfileValues(profile *models.ProfileDetails, capacity int64, iops int64, bandwidth int32) error {
+	problems := shareProfileProblems(profile, capacity, iops, bandwidth)
+	if len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// shareProfileProblems lists every value among capacity, iops and bandwidth which profile does not allow
+func shareProfileProblems(profile *models.ProfileDetails, capacity int64, iops int64, bandwidth int32) []error {
+	problems := []error{}
+	if capacity != 0 && !allowedByProfile(profile.Capacity, capacity) {
+		problems = append(problems, userError.GetUserError("VolumeCapacityNotAllowed", nil, capacity, profile.Name, describeAllowed(profile.Capacity)))
+	}
+	if iops != 0 && !allowedByProfile(profile.Iops, iops) {
+		problems = append(problems, userError.GetUserError("VolumeIopsNotAllowed", nil, iops, profile.Name, describeAllowed(profile.Iops)))
+	}
+	if bandwidth != 0 && !allowedByProfile(profile.Bandwidth, int64(bandwidth)) {
+		problems = append(problems, userError.GetUserError("VolumeBandwidthNotAllowed", nil, bandwidth, profile.Name, describeAllowed(profile.Bandwidth)))
+	}
+	return problems
+}
+
+// allowedByProfile tells if value is allowed as per the type of allowed. Dependent values are left to the backend,
+// as they depend on the other properties of the share
+func allowedByProfile(allowed models.CapIops, value int64) bool {
+	switch allowed.Type {
+	case models.CapIopsFixed:
+		return value == int64(allowed.Value)
+	case models.CapIopsEnum:
+		return int64(int32(value)) == value && slices.Contains(allowed.Values, int32(value))
+	case models.CapIopsRange, models.CapIopsDependentRange:
+		if (allowed.Min > 0 && value < int64(allowed.Min)) || (allowed.Max > 0 && value > int64(allowed.Max)) {
+			return false
+		}
+		return allowed.Step <= 1 || (value-int64(allowed.Min))%int64(allowed.Step) == 0
+	}
+	return true
+}
+
+// describeAllowed lists the values allowed, for the user errors
+func describeAllowed(allowed models.CapIops) string {
+	switch allowed.Type {
+	case models.CapIopsFixed:
+		return fmt.Sprint(allowed.Value)
+	case models.CapIopsEnum:
+		values := make([]string, len(allowed.Values))
+		for i, value := range allowed.Values {
+			values[i] = fmt.Sprint(value)
+		}
+		return "one of " + strings.Join(values, ", ")
+	case models.CapIopsRange, models.CapIopsDependentRange:
+		description := fmt.Sprintf("%d to %d", allowed.Min, allowed.Max)
+		if allowed.Step > 1 {
+			description += fmt.Sprintf(" in steps of %d", allowed.Step)
+		}
+		return description
+	}
+	return "set by the other properties of the file share"
+}
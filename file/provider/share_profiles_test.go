@@ -0,0 +1,203 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListShareProfiles(t *testing.T) {
+	vpcs, _ := fakeVPCSession(t)
+
+	profiles, err := vpcs.ListShareProfiles(context.Background())
+	require.NoError(t, err)
+	names := []string{}
+	for _, profile := range profiles {
+		names = append(names, profile.Name)
+	}
+	assert.Equal(t, []string{"dp2", "rfs", "tier-10iops"}, names)
+}
+
+func TestGetShareProfileDetails(t *testing.T) {
+	t.Run("cached", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		vpcs.Profiles = NewProfileCatalog(time.Hour)
+
+		for i := 0; i < 2; i++ {
+			profile, err := vpcs.GetShareProfileDetails("dp2")
+			require.NoError(t, err)
+			assert.Equal(t, int32(32000), profile.Capacity.Max)
+		}
+		assert.Equal(t, 1, fake.CallCount("ListShareProfiles"))
+		assert.Equal(t, 0, fake.CallCount("GetShareProfile"))
+
+		vpcs.Profiles.Invalidate()
+		_, err := vpcs.GetShareProfileDetails("dp2")
+		require.NoError(t, err)
+		assert.Equal(t, 2, fake.CallCount("ListShareProfiles"))
+	})
+
+	t.Run("missing from the catalog", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		vpcs.Profiles = NewProfileCatalog(time.Hour)
+		_, err := vpcs.GetShareProfileDetails("dp2")
+		require.NoError(t, err)
+
+		fake.AddProfile(models.ProfileDetails{Profile: models.Profile{Name: "new"}, Capacity: models.CapIops{Type: "range", Min: 10, Max: 100}})
+		profile, err := vpcs.GetShareProfileDetails("new")
+		require.NoError(t, err)
+		assert.Equal(t, "new", profile.Name)
+		assert.Equal(t, 1, fake.CallCount("GetShareProfile"))
+	})
+
+	t.Run("failures are remembered", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		vpcs.Profiles = NewProfileCatalog(time.Hour)
+		fake.InjectFault(fakevpc.Fault{Operation: "ListShareProfiles", Times: 1, Code: fakevpc.ErrorCodeInternalError})
+
+		// A single attempt, whose failure is returned until it expires
+		for i := 0; i < 2; i++ {
+			_, err := vpcs.GetShareProfileDetails("dp2")
+			assert.Error(t, err)
+		}
+		assert.Equal(t, 1, fake.CallCount("ListShareProfiles"))
+		vpcs.Profiles.failureTTL = 0
+		_, err := vpcs.GetShareProfileDetails("dp2")
+		require.NoError(t, err)
+		assert.Equal(t, 2, fake.CallCount("ListShareProfiles"))
+
+		// So is a profile which cannot be found
+		vpcs.Profiles.failureTTL = time.Hour
+		for i := 0; i < 2; i++ {
+			_, err = vpcs.GetShareProfileDetails("unknown")
+			assert.Error(t, err)
+		}
+		assert.Equal(t, 1, fake.CallCount("GetShareProfile"))
+	})
+
+	t.Run("without catalog", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		_, err := vpcs.GetShareProfileDetails("dp2")
+		require.NoError(t, err)
+		assert.Equal(t, 0, fake.CallCount("ListShareProfiles"))
+		assert.Equal(t, 1, fake.CallCount("GetShareProfile"))
+	})
+}
+
+func TestProfileCatalogSharedFetch(t *testing.T) {
+	catalog := NewProfileCatalog(time.Hour)
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	fetch := func() ([]models.ProfileDetails, error) {
+		fetches.Add(1)
+		<-release
+		return []models.ProfileDetails{{Profile: models.Profile{Name: "dp2"}}}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, found, err := catalog.lookup("dp2", fetch)
+			assert.NoError(t, err)
+			assert.True(t, found)
+		}()
+	}
+	// The catalog is not locked during the fetch
+	assert.Eventually(t, func() bool { return fetches.Load() == 1 }, time.Second, time.Millisecond)
+	catalog.Invalidate()
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), fetches.Load())
+}
+
+func TestCreateVolumeValidatesAgainstShareProfile(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+	vpcs.Profiles = NewProfileCatalog(time.Hour)
+
+	request := fakeVolumeRequest("fake-volume")
+	request.Capacity = Int(40000)
+	_, err := vpcs.CreateVolume(request)
+	assert.Equal(t, "VolumeCapacityNotAllowed", userErrorCode(err))
+	assert.Contains(t, err.(util.Message).Description, "which allows 10 to 32000 GB")
+
+	request = fakeVolumeRequest("fake-volume")
+	request.Iops = String("50")
+	_, err = vpcs.CreateVolume(request)
+	assert.Equal(t, "VolumeIopsNotAllowed", userErrorCode(err))
+	assert.Equal(t, 0, fake.CallCount("CreateFileShare"))
+
+	// The request is left to the backend when the profile cannot be fetched
+	request = fakeVolumeRequest("fake-volume")
+	request.VPCVolume.Profile.Name = "unknown"
+	_, err = vpcs.CreateVolume(request)
+	assert.Equal(t, "FailedToPlaceOrder", userErrorCode(err))
+	assert.Positive(t, fake.CallCount("CreateFileShare"))
+}
+
+func TestValidateShareProfileValues(t *testing.T) {
+	profile := &models.ProfileDetails{
+		Profile:   models.Profile{Name: "custom"},
+		Capacity:  models.CapIops{Type: models.CapIopsRange, Min: 10, Max: 100, Step: 10},
+		Iops:      models.CapIops{Type: models.CapIopsEnum, Values: []int32{1000, 2000}},
+		Bandwidth: models.CapIops{Type: models.CapIopsFixed, Value: 400},
+	}
+
+	testCases := []struct {
+		name        string
+		capacity    int64
+		iops        int64
+		bandwidth   int32
+		expectedErr string
+		description string
+	}{
+		{name: "allowed", capacity: 30, iops: 2000, bandwidth: 400},
+		{name: "defaults", capacity: 10},
+		{name: "capacity out of range", capacity: 200, expectedErr: "VolumeCapacityNotAllowed", description: "which allows 10 to 100 in steps of 10 GB"},
+		{name: "capacity off step", capacity: 25, expectedErr: "VolumeCapacityNotAllowed"},
+		{name: "iops not in enum", capacity: 10, iops: 1500, expectedErr: "VolumeIopsNotAllowed", description: "which allows one of 1000, 2000 IOPS"},
+		{name: "bandwidth not fixed value", capacity: 10, bandwidth: 200, expectedErr: "VolumeBandwidthNotAllowed", description: "which allows 400 Mbps"},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			err := validateShareProfileValues(profile, testcase.capacity, testcase.iops, testcase.bandwidth)
+			if testcase.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, testcase.expectedErr, userErrorCode(err))
+			if testcase.description != "" {
+				assert.Contains(t, err.(util.Message).Description, testcase.description)
+			}
+		})
+	}
+
+	dependent := &models.ProfileDetails{Iops: models.CapIops{Type: models.CapIopsDependent}}
+	assert.NoError(t, validateShareProfileValues(dependent, 0, 123456, 0))
+}
@@ -0,0 +1,213 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+)
+
+// Retention rules, as named in the reasons of the snapshots a policy keeps
+const (
+	// KeepLastRule keeps the most recent snapshots
+	KeepLastRule = "last"
+	// KeepWithinRule keeps the snapshots captured recently
+	KeepWithinRule = "within"
+	// KeepDailyRule keeps the most recent snapshot of each day
+	KeepDailyRule = "daily"
+	// KeepWeeklyRule keeps the most recent snapshot of each ISO week
+	KeepWeeklyRule = "weekly"
+	// KeepMonthlyRule keeps the most recent snapshot of each month
+	KeepMonthlyRule = "monthly"
+)
+
+// RetentionPolicy tells which of the snapshots it selects to keep, the other ones it selects are pruned. A snapshot
+// is selected when its name has NamePrefix and it has all of Tags, a policy without either selects every snapshot.
+// The keep rules add up: a snapshot is kept when any of them keeps it. Days, weeks and months are counted in UTC,
+// on the capture time of the snapshots
+type RetentionPolicy struct {
+	// Name identifies the policy in the reasons of the snapshots it keeps
+	Name string
+	// NamePrefix selects the snapshots whose name starts with it
+	NamePrefix string
+	// Tags selects the snapshots having all of these user tags, e.g. "schedule:hourly"
+	Tags []string
+
+	// KeepLast keeps the KeepLast most recent snapshots
+	KeepLast int
+	// KeepWithin keeps the snapshots captured within KeepWithin of the retention time
+	KeepWithin time.Duration
+	// KeepDaily keeps the most recent snapshot of each of the KeepDaily most recent days having snapshots
+	KeepDaily int
+	// KeepWeekly keeps the most recent snapshot of each of the KeepWeekly most recent weeks having snapshots
+	KeepWeekly int
+	// KeepMonthly keeps the most recent snapshot of each of the KeepMonthly most recent months having snapshots
+	KeepMonthly int
+}
+
+// Selects tells if the policy applies to snapshot
+func (policy RetentionPolicy) Selects(snapshot *models.Snapshot) bool {
+	if !strings.HasPrefix(snapshot.Name, policy.NamePrefix) {
+		return false
+	}
+	for _, tag := range policy.Tags {
+		if !containsTag(snapshot.UserTags, strings.TrimSpace(tag)) {
+			return false
+		}
+	}
+	return true
+}
+
+// validate checks that the policy keeps some snapshots, so that a mistake never prunes all the snapshots it selects
+func (policy RetentionPolicy) validate() error {
+	if policy.KeepLast < 0 || policy.KeepWithin < 0 || policy.KeepDaily < 0 || policy.KeepWeekly < 0 || policy.KeepMonthly < 0 {
+		return userError.GetUserError("InvalidRetentionPolicy", nil, policy.Name, "keep rules cannot be negative")
+	}
+	if policy.KeepLast == 0 && policy.KeepWithin == 0 && policy.KeepDaily == 0 && policy.KeepWeekly == 0 && policy.KeepMonthly == 0 {
+		return userError.GetUserError("InvalidRetentionPolicy", nil, policy.Name, "no keep rule is set")
+	}
+	return nil
+}
+
+// RetentionAction is what the retention does with a snapshot
+type RetentionAction string
+
+const (
+	// RetentionKeep leaves the snapshot in place
+	RetentionKeep = RetentionAction("keep")
+	// RetentionPrune deletes the snapshot
+	RetentionPrune = RetentionAction("prune")
+)
+
+// SnapshotRetentionResult is the outcome of the retention for a snapshot of a file share
+type SnapshotRetentionResult struct {
+	SnapshotID string
+	Name       string
+	// CapturedAt is the time the retention rules apply to, the creation time when the backend reports no capture time
+	CapturedAt time.Time
+	Action     RetentionAction
+	// Reasons tell why the snapshot is kept, e.g. "daily-backups: daily"
+	Reasons []string
+	// Deleted tells if the snapshot got deleted, it never is on dry runs
+	Deleted bool
+	// Err is set when the snapshot could not be deleted
+	Err error
+}
+
+// retentionTime returns the time the retention rules apply to for snapshot, false when the backend reports none
+func retentionTime(snapshot *models.Snapshot) (time.Time, bool) {
+	switch {
+	case snapshot.CapturedAt != nil:
+		return *snapshot.CapturedAt, true
+	case snapshot.CreatedAt != nil:
+		return *snapshot.CreatedAt, true
+	}
+	return time.Time{}, false
+}
+
+// planRetention decides for each snapshot of a file share whether policies keep or prune it, as of now. Only the
+// stable snapshots which some policy selects are pruned, and only when no policy keeps them. Snapshots of a backup
+// policy plan are left to their backup policy. The results are sorted from the most recent snapshot
+func planRetention(snapshots []*models.Snapshot, policies []RetentionPolicy, now time.Time) []SnapshotRetentionResult {
+	sorted := append([]*models.Snapshot(nil), snapshots...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, _ := retentionTime(sorted[i])
+		tj, _ := retentionTime(sorted[j])
+		return ti.After(tj)
+	})
+
+	results := make([]SnapshotRetentionResult, len(sorted))
+	candidates := map[int]bool{}
+	for i, snapshot := range sorted {
+		capturedAt, ok := retentionTime(snapshot)
+		results[i] = SnapshotRetentionResult{SnapshotID: snapshot.ID, Name: snapshot.Name, CapturedAt: capturedAt, Action: RetentionKeep}
+		switch {
+		case snapshot.LifecycleState != snapshotReadyState:
+			results[i].Reasons = []string{"lifecycle state is " + snapshot.LifecycleState}
+		case snapshot.BackupPolicyPlan != nil:
+			results[i].Reasons = []string{"managed by backup policy plan " + snapshot.BackupPolicyPlan.ID}
+		case !ok:
+			results[i].Reasons = []string{"no capture time"}
+		default:
+			candidates[i] = true
+		}
+	}
+
+	selected := map[int]bool{}
+	for _, policy := range policies {
+		// The selected candidates, from the most recent one
+		indexes := []int{}
+		for i, snapshot := range sorted {
+			if candidates[i] && policy.Selects(snapshot) {
+				indexes = append(indexes, i)
+				selected[i] = true
+			}
+		}
+		keep := func(i int, rule string) {
+			results[i].Reasons = append(results[i].Reasons, policy.Name+": "+rule)
+		}
+
+		for n, i := range indexes {
+			if n < policy.KeepLast {
+				keep(i, KeepLastRule)
+			}
+			if policy.KeepWithin > 0 && now.Sub(results[i].CapturedAt) <= policy.KeepWithin {
+				keep(i, KeepWithinRule)
+			}
+		}
+		keepPerPeriod(indexes, results, policy.KeepDaily, func(t time.Time) string { return t.Format("2006-01-02") }, func(i int) { keep(i, KeepDailyRule) })
+		keepPerPeriod(indexes, results, policy.KeepWeekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}, func(i int) { keep(i, KeepWeeklyRule) })
+		keepPerPeriod(indexes, results, policy.KeepMonthly, func(t time.Time) string { return t.Format("2006-01") }, func(i int) { keep(i, KeepMonthlyRule) })
+	}
+
+	for i := range results {
+		if !candidates[i] {
+			continue
+		}
+		if !selected[i] {
+			results[i].Reasons = []string{"not selected by any policy"}
+		} else if len(results[i].Reasons) == 0 {
+			results[i].Action = RetentionPrune
+		}
+	}
+	return results
+}
+
+// keepPerPeriod keeps the most recent snapshot of each of the count most recent periods having snapshots.
+// indexes are the snapshots the policy selects from the most recent one, period names the period of a time
+func keepPerPeriod(indexes []int, results []SnapshotRetentionResult, count int, period func(time.Time) string, keep func(int)) {
+	seen := map[string]bool{}
+	for _, i := range indexes {
+		if len(seen) >= count {
+			return
+		}
+		name := period(results[i].CapturedAt.UTC())
+		if !seen[name] {
+			seen[name] = true
+			keep(i)
+		}
+	}
+}
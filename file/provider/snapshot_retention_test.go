@@ -0,0 +1,162 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"testing"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// retentionSnapshot is a stable snapshot captured at capturedAt
+func retentionSnapshot(name string, capturedAt time.Time, tags ...string) *models.Snapshot {
+	return &models.Snapshot{ID: name + "-id", Name: name, CapturedAt: &capturedAt, LifecycleState: snapshotReadyState, UserTags: tags}
+}
+
+// retentionActions returns the action for each snapshot of results, by name
+func retentionActions(results []SnapshotRetentionResult) map[string]RetentionAction {
+	actions := map[string]RetentionAction{}
+	for _, result := range results {
+		actions[result.Name] = result.Action
+	}
+	return actions
+}
+
+func TestPlanRetention(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	t.Run("keep last and keep within", func(t *testing.T) {
+		snapshots := []*models.Snapshot{
+			retentionSnapshot("s1", now.Add(-5*day)),
+			retentionSnapshot("s4", now.Add(-1*time.Hour)),
+			retentionSnapshot("s2", now.Add(-3*day)),
+			retentionSnapshot("s3", now.Add(-2*day)),
+		}
+		results := planRetention(snapshots, []RetentionPolicy{{Name: "p", KeepLast: 1, KeepWithin: 3 * day}}, now)
+
+		// The results are sorted from the most recent snapshot
+		assert.Equal(t, []string{"s4", "s3", "s2", "s1"}, []string{results[0].Name, results[1].Name, results[2].Name, results[3].Name})
+		assert.Equal(t, []string{"p: last", "p: within"}, results[0].Reasons)
+		assert.Equal(t, []string{"p: within"}, results[2].Reasons)
+		assert.Equal(t, map[string]RetentionAction{"s1": RetentionPrune, "s2": RetentionKeep, "s3": RetentionKeep, "s4": RetentionKeep}, retentionActions(results))
+	})
+
+	t.Run("keep daily, weekly and monthly", func(t *testing.T) {
+		snapshots := []*models.Snapshot{
+			// Two snapshots on the same day, the most recent one counts for the day
+			retentionSnapshot("oct17-late", now),
+			retentionSnapshot("oct17-early", now.Add(-6*time.Hour)),
+			retentionSnapshot("oct16", now.Add(-1*day)),
+			retentionSnapshot("oct11", now.Add(-6*day)),
+			retentionSnapshot("sep20", now.Add(-27*day)),
+			retentionSnapshot("aug01", now.Add(-77*day)),
+		}
+		results := planRetention(snapshots, []RetentionPolicy{{Name: "p", KeepDaily: 2, KeepWeekly: 2, KeepMonthly: 3}}, now)
+
+		assert.Equal(t, map[string]RetentionAction{
+			"oct17-late":  RetentionKeep,
+			"oct17-early": RetentionPrune,
+			"oct16":       RetentionKeep,
+			"oct11":       RetentionKeep,
+			"sep20":       RetentionKeep,
+			"aug01":       RetentionKeep,
+		}, retentionActions(results))
+		assert.Equal(t, []string{"p: daily", "p: weekly", "p: monthly"}, results[0].Reasons)
+		assert.Equal(t, []string{"p: daily"}, results[2].Reasons)
+		// October 11th is the Sunday of the week before
+		assert.Equal(t, []string{"p: weekly"}, results[3].Reasons)
+		assert.Equal(t, []string{"p: monthly"}, results[4].Reasons)
+		assert.Equal(t, []string{"p: monthly"}, results[5].Reasons)
+	})
+
+	t.Run("selection by name prefix and tags", func(t *testing.T) {
+		snapshots := []*models.Snapshot{
+			retentionSnapshot("hourly-2", now, "schedule:hourly"),
+			retentionSnapshot("hourly-1", now.Add(-time.Hour), "schedule:hourly"),
+			retentionSnapshot("nightly-2", now.Add(-2*time.Hour), "Schedule:Nightly"),
+			retentionSnapshot("nightly-1", now.Add(-day), "schedule:nightly"),
+			retentionSnapshot("manual", now.Add(-2*day)),
+		}
+		results := planRetention(snapshots, []RetentionPolicy{
+			{Name: "hourly", NamePrefix: "hourly-", KeepLast: 1},
+			{Name: "nightly", Tags: []string{"schedule:nightly"}, KeepLast: 1},
+		}, now)
+
+		assert.Equal(t, map[string]RetentionAction{
+			"hourly-2":  RetentionKeep,
+			"hourly-1":  RetentionPrune,
+			"nightly-2": RetentionKeep,
+			"nightly-1": RetentionPrune,
+			"manual":    RetentionKeep,
+		}, retentionActions(results))
+		assert.Equal(t, []string{"not selected by any policy"}, results[4].Reasons)
+	})
+
+	t.Run("any policy keeps a snapshot", func(t *testing.T) {
+		snapshots := []*models.Snapshot{
+			retentionSnapshot("s2", now),
+			retentionSnapshot("s1", now.Add(-10*day)),
+		}
+		results := planRetention(snapshots, []RetentionPolicy{{Name: "short", KeepLast: 1}, {Name: "long", KeepMonthly: 1, NamePrefix: "s1"}}, now)
+		assert.Equal(t, map[string]RetentionAction{"s2": RetentionKeep, "s1": RetentionKeep}, retentionActions(results))
+		assert.Equal(t, []string{"long: monthly"}, results[1].Reasons)
+	})
+
+	t.Run("snapshots which are never pruned", func(t *testing.T) {
+		pending := retentionSnapshot("pending", now.Add(-3*day))
+		pending.LifecycleState = fakevpc.StatePending
+		planned := retentionSnapshot("planned", now.Add(-4*day))
+		planned.BackupPolicyPlan = &models.BackupPolicyPlan{ID: "plan-id"}
+		untimed := &models.Snapshot{ID: "untimed-id", Name: "untimed", LifecycleState: snapshotReadyState}
+		created := retentionSnapshot("created", now.Add(-5*day))
+		created.CreatedAt, created.CapturedAt = created.CapturedAt, nil
+
+		results := planRetention([]*models.Snapshot{retentionSnapshot("latest", now), pending, planned, untimed, created}, []RetentionPolicy{{Name: "p", KeepLast: 1}}, now)
+		assert.Equal(t, map[string]RetentionAction{
+			"latest":  RetentionKeep,
+			"pending": RetentionKeep,
+			"planned": RetentionKeep,
+			"untimed": RetentionKeep,
+			// The creation time stands in for the capture time
+			"created": RetentionPrune,
+		}, retentionActions(results))
+		for _, result := range results {
+			switch result.Name {
+			case "pending":
+				assert.Equal(t, []string{"lifecycle state is pending"}, result.Reasons)
+			case "planned":
+				assert.Equal(t, []string{"managed by backup policy plan plan-id"}, result.Reasons)
+			case "untimed":
+				assert.Equal(t, []string{"no capture time"}, result.Reasons)
+			}
+		}
+	})
+}
+
+func TestRetentionPolicyValidate(t *testing.T) {
+	userError.MessagesEn = userError.InitMessages()
+
+	assert.NoError(t, RetentionPolicy{Name: "p", KeepWithin: time.Hour}.validate())
+	assert.Equal(t, "InvalidRetentionPolicy", userErrorCode(RetentionPolicy{Name: "p", NamePrefix: "daily-"}.validate()))
+	assert.Equal(t, "InvalidRetentionPolicy", userErrorCode(RetentionPolicy{Name: "p", KeepLast: 1, KeepDaily: -1}.validate()))
+}
@@ -0,0 +1,137 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package provider ...
+package provider
+
+import (
+	"strings"
+)
+
+// ManagedTagKeys are the keys of the key:value tags the library owns on the shares and their snapshots, the ones
+// the PV and snapshot watchers set. A requested tag with one of these keys replaces the tags of the share with the
+// same key, keys are compared regardless of case
+var ManagedTagKeys = []string{"clusterid", "reclaimpolicy", "storageclass", "namespace", "pvc", "pv", "provisioner", "volumesnapshot"}
+
+// TagDiff tells the tags added to and removed from a resource
+type TagDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// Empty tells if the tags are left as they are
+func (diff TagDiff) Empty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0
+}
+
+// tagKey returns the lower cased key of a key:value tag, an empty string when tag has no key
+func tagKey(tag string) string {
+	key, _, found := strings.Cut(strings.TrimSpace(tag), ":")
+	if !found {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+// isManagedTagKey tells if key is one of ManagedTagKeys
+func isManagedTagKey(key string) bool {
+	for _, managed := range ManagedTagKeys {
+		if key == managed {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileManagedTags returns the tags a resource should have once the requested tags are applied to its existing
+// ones, along with the diff. The requested managed tags replace the existing tags with the same key. The existing
+// tags whose key is one of ownedKeys are removed unless they are requested. All other existing tags are kept. Tags
+// are compared regardless of case and the existing spelling of a tag is kept
+func reconcileManagedTags(existing []string, requested []string, ownedKeys []string) ([]string, TagDiff) {
+	requested = uniqueTags(requested)
+	requestedSet := map[string]bool{}
+	requestedKeys := map[string]bool{}
+	for _, tag := range requested {
+		requestedSet[strings.ToLower(tag)] = true
+		if key := tagKey(tag); isManagedTagKey(key) {
+			requestedKeys[key] = true
+		}
+	}
+	for _, key := range ownedKeys {
+		requestedKeys[strings.ToLower(strings.TrimSpace(key))] = true
+	}
+
+	diff := TagDiff{}
+	tags := []string{}
+	existingSet := map[string]bool{}
+	for _, tag := range existing {
+		if key := tagKey(tag); key != "" && requestedKeys[key] && !requestedSet[strings.ToLower(strings.TrimSpace(tag))] {
+			diff.Removed = append(diff.Removed, tag)
+			continue
+		}
+		existingSet[strings.ToLower(strings.TrimSpace(tag))] = true
+		tags = append(tags, tag)
+	}
+	for _, tag := range requested {
+		if !existingSet[strings.ToLower(tag)] {
+			diff.Added = append(diff.Added, tag)
+			tags = append(tags, tag)
+		}
+	}
+	return tags, diff
+}
+
+// diffTags returns the diff which makes the attached tags exactly the wanted ones, regardless of case
+func diffTags(attached []string, wanted []string) TagDiff {
+	diff := TagDiff{}
+	wantedSet := map[string]bool{}
+	for _, tag := range uniqueTags(wanted) {
+		wantedSet[strings.ToLower(tag)] = true
+		if !containsTag(attached, tag) {
+			diff.Added = append(diff.Added, tag)
+		}
+	}
+	for _, tag := range attached {
+		if !wantedSet[strings.ToLower(strings.TrimSpace(tag))] {
+			diff.Removed = append(diff.Removed, tag)
+		}
+	}
+	return diff
+}
+
+// containsTag tells if tags holds tag, regardless of case
+func containsTag(tags []string, tag string) bool {
+	for _, existing := range tags {
+		if strings.EqualFold(strings.TrimSpace(existing), tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueTags returns the non empty tags, trimmed and without the duplicates which only differ by case
+func uniqueTags(tags []string) []string {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		unique = append(unique, tag)
+	}
+	return unique
+}
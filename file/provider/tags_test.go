@@ -0,0 +1,217 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas/fakes"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileManagedTags(t *testing.T) {
+	testCases := []struct {
+		name         string
+		existing     []string
+		requested    []string
+		ownedKeys    []string
+		expectedTags []string
+		expectedDiff TagDiff
+	}{
+		{
+			name:         "managed tags are replaced and user tags are kept",
+			existing:     []string{"team-a", "pvc:old-claim", "namespace:default", "clusterid:c1"},
+			requested:    []string{"pvc:new-claim", "namespace:default", "env:dev"},
+			expectedTags: []string{"team-a", "namespace:default", "clusterid:c1", "pvc:new-claim", "env:dev"},
+			expectedDiff: TagDiff{Added: []string{"pvc:new-claim", "env:dev"}, Removed: []string{"pvc:old-claim"}},
+		}, {
+			name:         "similar tag names are told apart",
+			existing:     []string{"pvc:claim10", "pv:pv-1", "team-ab"},
+			requested:    []string{"pvc:claim1", "pv:pv-1", "team-a"},
+			expectedTags: []string{"pv:pv-1", "team-ab", "pvc:claim1", "team-a"},
+			expectedDiff: TagDiff{Added: []string{"pvc:claim1", "team-a"}, Removed: []string{"pvc:claim10"}},
+		}, {
+			name:         "tags are compared regardless of case",
+			existing:     []string{"clusterID:c1", "Team-A"},
+			requested:    []string{"clusterid:C1", "team-a", " team-a "},
+			expectedTags: []string{"clusterID:c1", "Team-A"},
+			expectedDiff: TagDiff{},
+		}, {
+			name:         "every tag of a replaced key is removed",
+			existing:     []string{"namespace:a", "namespace:b", "storageclass:gold"},
+			requested:    []string{"namespace:c"},
+			expectedTags: []string{"storageclass:gold", "namespace:c"},
+			expectedDiff: TagDiff{Added: []string{"namespace:c"}, Removed: []string{"namespace:a", "namespace:b"}},
+		}, {
+			name:         "owned tags which are not requested are removed",
+			existing:     []string{"cost-center:a1", "Team:dev", "team-a", "pvc:claim1"},
+			requested:    []string{"cost-center:b2", "pvc:claim1"},
+			ownedKeys:    []string{"Cost-Center", "team"},
+			expectedTags: []string{"team-a", "pvc:claim1", "cost-center:b2"},
+			expectedDiff: TagDiff{Added: []string{"cost-center:b2"}, Removed: []string{"cost-center:a1", "Team:dev"}},
+		}, {
+			name:         "no requested tags",
+			existing:     []string{"pvc:claim1"},
+			expectedTags: []string{"pvc:claim1"},
+			expectedDiff: TagDiff{},
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			tags, diff := reconcileManagedTags(testcase.existing, testcase.requested, testcase.ownedKeys)
+			assert.Equal(t, testcase.expectedTags, tags)
+			assert.Equal(t, testcase.expectedDiff, diff)
+			assert.Equal(t, testcase.expectedDiff.Added == nil && testcase.expectedDiff.Removed == nil, diff.Empty())
+		})
+	}
+}
+
+func TestUpdateVolumeTags(t *testing.T) {
+	t.Run("through Global Tagging", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		require.NoError(t, vpcs.tagService().AttachTags(volume.CRN, models.TagTypeUser, []string{"team-a", "pvc:old-claim", "namespace:default"}, vpcs.Logger))
+
+		update := provider.Volume{VolumeID: volume.VolumeID, VPCVolume: provider.VPCVolume{Tags: []string{"Team-A", "pvc:claim1", "namespace:default"}}}
+		diff, err := vpcs.UpdateVolumeTags(update)
+		require.NoError(t, err)
+		assert.Equal(t, &TagDiff{Added: []string{"pvc:claim1"}, Removed: []string{"pvc:old-claim"}}, diff)
+		tags, err := vpcs.listTags(vpcs.tagService(), volume.CRN, models.TagTypeUser)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-a", "namespace:default", "pvc:claim1"}, tags)
+		assert.Equal(t, 0, fake.CallCount("UpdateFileShare"))
+
+		// Updating again changes nothing, and the CRN saves fetching the share
+		getCalls := fake.CallCount("GetFileShare")
+		update.CRN = volume.CRN
+		diff, err = vpcs.UpdateVolumeTagsWithContext(context.Background(), update)
+		require.NoError(t, err)
+		assert.True(t, diff.Empty())
+		assert.Equal(t, 2, fake.CallCount("AttachTags"))
+		assert.Equal(t, 1, fake.CallCount("DetachTags"))
+		assert.Equal(t, getCalls, fake.CallCount("GetFileShare"))
+
+		// A resource the Global Tagging API reports in error fails the update, without retrying
+		attachCalls := fake.CallCount("AttachTags")
+		update.CRN = "crn:v1:bluemix:public:is:us-south-1:a/account::share:missing"
+		update.VPCVolume.Tags = []string{"team-b"}
+		_, err = vpcs.UpdateVolumeTags(update)
+		assert.Equal(t, "FailedToUpdateVolume", userErrorCode(err))
+		assert.Contains(t, err.(util.Message).BackendError, string(models.ErrorCodeTagsNotUpdated))
+		assert.Equal(t, attachCalls+1, fake.CallCount("AttachTags"))
+
+		// No requested tags leaves the share alone
+		listCalls := fake.CallCount("ListTags")
+		require.NoError(t, vpcs.UpdateVolume(provider.Volume{VolumeID: volume.VolumeID}))
+		assert.Equal(t, listCalls, fake.CallCount("ListTags"))
+	})
+
+	t.Run("through the share", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		vpcs.Apiclient = withoutTagging{vpcs.Apiclient}
+		request := fakeVolumeRequest("fake-volume")
+		volume, err := vpcs.CreateVolume(request)
+		require.NoError(t, err)
+
+		diff, err := vpcs.UpdateVolumeTags(provider.Volume{VolumeID: volume.VolumeID, VPCVolume: provider.VPCVolume{Tags: []string{"team-a", "pvc:claim1"}}})
+		require.NoError(t, err)
+		assert.Equal(t, &TagDiff{Added: []string{"team-a", "pvc:claim1"}}, diff)
+
+		diff, err = vpcs.UpdateVolumeTags(provider.Volume{VolumeID: volume.VolumeID, VPCVolume: provider.VPCVolume{Tags: []string{"pvc:claim2"}}})
+		require.NoError(t, err)
+		assert.Equal(t, &TagDiff{Added: []string{"pvc:claim2"}, Removed: []string{"pvc:claim1"}}, diff)
+		share, err := vpcs.Apiclient.FileShareService().GetFileShare(volume.VolumeID, vpcs.Logger)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team-a", "pvc:claim2"}, share.UserTags)
+
+		// Owned tags which are not requested anymore are removed
+		diff, err = vpcs.UpdateVolumeTags(provider.Volume{VolumeID: volume.VolumeID, VPCVolume: provider.VPCVolume{Tags: []string{"pvc:claim2"}}}, "team-a", "cost-center")
+		require.NoError(t, err)
+		assert.Equal(t, &TagDiff{}, diff)
+		diff, err = vpcs.UpdateVolumeTags(provider.Volume{VolumeID: volume.VolumeID, VPCVolume: provider.VPCVolume{Tags: []string{"cost-center:a1"}}}, "cost-center")
+		require.NoError(t, err)
+		assert.Equal(t, &TagDiff{Added: []string{"cost-center:a1"}}, diff)
+		diff, err = vpcs.UpdateVolumeTags(provider.Volume{VolumeID: volume.VolumeID, VPCVolume: provider.VPCVolume{Tags: []string{"pvc:claim2"}}}, "cost-center")
+		require.NoError(t, err)
+		assert.Equal(t, &TagDiff{Removed: []string{"cost-center:a1"}}, diff)
+		assert.Equal(t, 4, fake.CallCount("UpdateFileShare"))
+		assert.Equal(t, 0, fake.CallCount("ListTags"))
+	})
+}
+
+// withoutTagging hides the Global Tagging service of a RegionalAPI
+type withoutTagging struct {
+	riaas.RegionalAPI
+}
+
+func TestReconcileVolumeTags(t *testing.T) {
+	t.Run("access tags", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+
+		require.NoError(t, vpcs.ReconcileVolumeTagsWithContext(context.Background(), volume.VolumeID, models.TagTypeAccess, []string{"project:storage", "env:dev"}))
+		require.NoError(t, vpcs.ReconcileVolumeTags(volume.VolumeID, models.TagTypeAccess, []string{"env:prod"}))
+		tags, err := vpcs.listTags(vpcs.tagService(), volume.CRN, models.TagTypeAccess)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"env:prod"}, tags)
+
+		err = vpcs.ReconcileVolumeTags(volume.VolumeID, models.TagTypeAccess, []string{"storage"})
+		assert.Equal(t, "FailedToReconcileVolumeTags", userErrorCode(err))
+
+		require.NoError(t, vpcs.ReconcileVolumeTags(volume.VolumeID, models.TagTypeAccess, nil))
+		tags, err = vpcs.listTags(vpcs.tagService(), volume.CRN, models.TagTypeAccess)
+		require.NoError(t, err)
+		assert.Empty(t, tags)
+	})
+
+	t.Run("tags are listed page by page", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+
+		tags := []string{}
+		for i := 0; i < tagPageLimit+5; i++ {
+			tags = append(tags, fmt.Sprintf("tag-%d", i))
+		}
+		require.NoError(t, vpcs.ReconcileVolumeTags(volume.VolumeID, models.TagTypeUser, tags))
+		require.NoError(t, vpcs.ReconcileVolumeTags(volume.VolumeID, models.TagTypeUser, tags))
+		assert.Equal(t, 1, fake.CallCount("AttachTags"))
+		assert.Equal(t, 3, fake.CallCount("ListTags"))
+	})
+
+	t.Run("a missing share", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		err := vpcs.ReconcileVolumeTags("r006-ffffffff-0000-4000-8000-ffffffffffff", models.TagTypeUser, []string{"team-a"})
+		assert.Equal(t, "FailedToReconcileVolumeTags", userErrorCode(err))
+	})
+
+	t.Run("without a Global Tagging endpoint", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		vpcs.Apiclient = &fakes.RegionalAPI{}
+		err := vpcs.ReconcileVolumeTags("volume1", models.TagTypeUser, []string{"team-a"})
+		assert.Equal(t, "TagServiceNotConfigured", userErrorCode(err))
+	})
+}
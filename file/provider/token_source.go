@@ -0,0 +1,65 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/client"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local"
+	"github.com/golang-jwt/jwt/v4"
+	"go.uber.org/zap"
+)
+
+// tokenRefreshWindow is how long before its expiry an IAM token is replaced
+const tokenRefreshWindow = 5 * time.Minute
+
+// newIAMTokenSource returns a token source which starts with token and fetches new IAM access tokens
+// for apiKey from contextCF. It returns nil when the session has no means to refresh its token
+func newIAMTokenSource(token string, contextCF local.ContextCredentialsFactory, apiKey string, logger *zap.Logger) client.TokenSource {
+	if contextCF == nil || apiKey == "" {
+		return nil
+	}
+
+	return client.NewRefreshingTokenSource(token, tokenExpiry(token), tokenRefreshWindow, func() (string, time.Time, error) {
+		logger.Info("Refreshing IAM access token")
+		contextCredentials, err := contextCF.ForIAMAccessToken(apiKey, logger)
+		if err != nil {
+			logger.Error("Failed to refresh IAM access token", zap.Error(err))
+			return "", time.Time{}, err
+		}
+		if contextCredentials.AuthType != provider.IAMAccessToken {
+			return "", time.Time{}, client.ErrAuthenticationRequired
+		}
+		return contextCredentials.Credential, tokenExpiry(contextCredentials.Credential), nil
+	})
+}
+
+// tokenExpiry reads the expiry of a JWT access token, the zero time if the token does not tell
+func tokenExpiry(token string) time.Time {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return time.Time{}
+	}
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(exp), 0)
+}
@@ -0,0 +1,69 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/IBM/ibmcloud-volume-interface/provider/local/fakes"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func testJWT(t *testing.T, exp time.Time) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": exp.Unix()}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+	return token
+}
+
+func TestTokenExpiry(t *testing.T) {
+	exp := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	assert.Equal(t, exp, tokenExpiry(testJWT(t, exp)))
+	assert.True(t, tokenExpiry("not-a-jwt").IsZero())
+}
+
+func TestNewIAMTokenSource(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+
+	contextCF := &fakes.ContextCredentialsFactory{}
+	assert.Nil(t, newIAMTokenSource("token", nil, "api-key", logger))
+	assert.Nil(t, newIAMTokenSource("token", contextCF, "", logger))
+
+	// An expired token is replaced straight away by a fresh one from the factory
+	fresh := testJWT(t, time.Now().Add(time.Hour))
+	contextCF.ForIAMAccessTokenReturns(provider.ContextCredentials{AuthType: provider.IAMAccessToken, Credential: fresh}, nil)
+	source := newIAMTokenSource(testJWT(t, time.Now().Add(-time.Minute)), contextCF, "api-key", logger)
+	token, err := source.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, fresh, token)
+	apiKey, _ := contextCF.ForIAMAccessTokenArgsForCall(0)
+	assert.Equal(t, "api-key", apiKey)
+
+	// The fresh token is kept until the API rejects it
+	token, err = source.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, fresh, token)
+	assert.Equal(t, 1, contextCF.ForIAMAccessTokenCallCount())
+
+	contextCF.ForIAMAccessTokenReturns(provider.ContextCredentials{}, errors.New("iam unavailable"))
+	_, err = source.Refresh(fresh)
+	assert.Error(t, err)
+}
@@ -0,0 +1,120 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"go.uber.org/zap"
+)
+
+// UpdateSnapshotRequest is the change of an existing snapshot of a file share
+type UpdateSnapshotRequest struct {
+	VolumeID   string
+	SnapshotID string
+	// Name renames the snapshot, empty keeps its name
+	Name string
+	// Tags are the requested user tags of the snapshot, reconciled the way UpdateVolumeTags does for shares
+	Tags []string
+	// OwnedKeys are the keys of the tags owned by the caller, the ones which are not requested are removed, e.g.
+	// requesting retention:long-term with the owned key retention relabels a snapshot promoted to a long-term backup
+	OwnedKeys []string
+}
+
+// UpdateSnapshot renames the snapshot of the share and reconciles its user tags, then returns the tags added and
+// removed. The snapshot is updated with its ETag, so that concurrent updates are retried on its latest state.
+// Nothing is updated when the snapshot already is as requested
+func (vpcs *VPCSession) UpdateSnapshot(updateSnapshotRequest UpdateSnapshotRequest) (*TagDiff, error) {
+	vpcs.Logger.Debug("Entry of UpdateSnapshot method...")
+	defer vpcs.Logger.Debug("Exit from UpdateSnapshot method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "UpdateSnapshot", time.Now())
+
+	shareID, snapshotID := updateSnapshotRequest.VolumeID, updateSnapshotRequest.SnapshotID
+	if shareID == "" || snapshotID == "" {
+		return nil, userError.GetUserError("ErrorRequiredFieldMissing", nil, "shareID and snapshotID")
+	}
+
+	var diff TagDiff
+	err := vpcs.GetRetryPolicy().RetryWithAttempts(vpcs.requestContext(), vpcs.Logger, minRetryAttempt, func() error {
+		snapshot, etag, err := vpcs.Apiclient.SnapshotService().GetSnapshotEtag(shareID, snapshotID, vpcs.Logger)
+		if err != nil {
+			return err
+		}
+		if snapshot.LifecycleState != StatusStable {
+			return errors.New("snapshot is " + snapshot.LifecycleState)
+		}
+
+		var snapshotTemplate *models.Snapshot
+		snapshotTemplate, diff = updateSnapshotTemplate(snapshot, updateSnapshotRequest)
+		if snapshotTemplate == nil {
+			vpcs.Logger.Info("There is no change for snapshot, skipping the update", zap.Reflect("snapshot", snapshot), zap.Reflect("updateSnapshotRequest", updateSnapshotRequest))
+			return nil
+		}
+
+		// The ETag changes with every update, so a concurrent update fails the call and the next attempt starts over
+		vpcs.Logger.Info("Calling VPC provider for snapshot update...", zap.String("snapshotID", snapshotID), zap.Reflect("snapshotTemplate", snapshotTemplate), zap.Reflect("tagDiff", diff))
+		return vpcs.Apiclient.SnapshotService().UpdateSnapshotWithEtag(shareID, snapshotID, etag, snapshotTemplate, vpcs.Logger)
+	})
+	if err != nil {
+		vpcs.Logger.Error("Failed to update snapshot from VPC provider", zap.Reflect("BackendError", err))
+		return nil, userError.GetUserError("FailedToUpdateSnapshot", err, snapshotID, shareID)
+	}
+	vpcs.Logger.Info("Successfully updated snapshot", zap.String("snapshotID", snapshotID), zap.Reflect("tagDiff", diff))
+	return &diff, nil
+}
+
+// UpdateSnapshotWithContext updates the snapshot as UpdateSnapshot does, giving up once ctx is done
+func (vpcs *VPCSession) UpdateSnapshotWithContext(ctx context.Context, updateSnapshotRequest UpdateSnapshotRequest) (*TagDiff, error) {
+	return vpcs.withContext(ctx).UpdateSnapshot(updateSnapshotRequest)
+}
+
+// UpdateSnapshotTags updates the user tags of the snapshot of the share as UpdateSnapshot does, keeping its name
+func (vpcs *VPCSession) UpdateSnapshotTags(shareID string, snapshotID string, tags []string, ownedKeys ...string) (*TagDiff, error) {
+	return vpcs.UpdateSnapshot(UpdateSnapshotRequest{VolumeID: shareID, SnapshotID: snapshotID, Tags: tags, OwnedKeys: ownedKeys})
+}
+
+// UpdateSnapshotTagsWithContext updates the user tags of the snapshot as UpdateSnapshotTags does, giving up once ctx is done
+func (vpcs *VPCSession) UpdateSnapshotTagsWithContext(ctx context.Context, shareID string, snapshotID string, tags []string, ownedKeys ...string) (*TagDiff, error) {
+	return vpcs.withContext(ctx).UpdateSnapshotTags(shareID, snapshotID, tags, ownedKeys...)
+}
+
+// updateSnapshotTemplate returns the PATCH body which makes the snapshot as requested along with the tags it adds
+// and removes, a nil template when the snapshot already is as requested
+func updateSnapshotTemplate(snapshot *models.Snapshot, updateSnapshotRequest UpdateSnapshotRequest) (*models.Snapshot, TagDiff) {
+	userTags, diff := reconcileManagedTags(snapshot.UserTags, updateSnapshotRequest.Tags, updateSnapshotRequest.OwnedKeys)
+
+	template := &models.Snapshot{}
+	changed := false
+	if updateSnapshotRequest.Name != "" && updateSnapshotRequest.Name != snapshot.Name {
+		template.Name = updateSnapshotRequest.Name
+		changed = true
+	}
+	if !diff.Empty() {
+		template.UserTags = userTags
+		changed = true
+	}
+	if !changed {
+		return nil, diff
+	}
+	return template, diff
+}
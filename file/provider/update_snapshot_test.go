@@ -0,0 +1,107 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSnapshotTags(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+	volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+	require.NoError(t, err)
+	snapshot, err := vpcs.Apiclient.SnapshotService().CreateSnapshot(volume.VolumeID, &models.Snapshot{Name: "snap1", UserTags: []string{"team-a", "namespace:old"}}, vpcs.Logger)
+	require.NoError(t, err)
+
+	// The pending snapshot is retried until it is stable
+	diff, err := vpcs.UpdateSnapshotTags(volume.VolumeID, snapshot.ID, []string{"namespace:default", "volumesnapshot:snap1"})
+	require.NoError(t, err)
+	assert.Equal(t, &TagDiff{Added: []string{"namespace:default", "volumesnapshot:snap1"}, Removed: []string{"namespace:old"}}, diff)
+	snapshot, err = vpcs.Apiclient.SnapshotService().GetSnapshot(volume.VolumeID, snapshot.ID, vpcs.Logger)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "namespace:default", "volumesnapshot:snap1"}, snapshot.UserTags)
+
+	// Updating again changes nothing
+	diff, err = vpcs.UpdateSnapshotTagsWithContext(context.Background(), volume.VolumeID, snapshot.ID, []string{"namespace:default", "volumesnapshot:snap1"})
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+	assert.Equal(t, 1, fake.CallCount("UpdateSnapshot"))
+
+	// A concurrent update is retried on the latest tags, owned tags which are not requested are removed
+	fake.InjectFault(fakevpc.Fault{Operation: "UpdateSnapshot", OnCall: 1, Status: http.StatusPreconditionFailed, Code: fakevpc.ErrorCodePreconditionFailed})
+	diff, err = vpcs.UpdateSnapshotTags(volume.VolumeID, snapshot.ID, []string{"namespace:default"}, "volumesnapshot")
+	require.NoError(t, err)
+	assert.Equal(t, &TagDiff{Removed: []string{"volumesnapshot:snap1"}}, diff)
+	assert.Equal(t, 3, fake.CallCount("UpdateSnapshot"))
+
+	_, err = vpcs.UpdateSnapshotTags(volume.VolumeID, "missing", []string{"namespace:default"})
+	assert.Equal(t, "FailedToUpdateSnapshot", userErrorCode(err))
+	_, err = vpcs.UpdateSnapshotTags("", snapshot.ID, nil)
+	assert.Equal(t, "ErrorRequiredFieldMissing", userErrorCode(err))
+}
+
+func TestUpdateSnapshot(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+	volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+	require.NoError(t, err)
+	snapshots := vpcs.Apiclient.SnapshotService()
+	snapshot, err := snapshots.CreateSnapshot(volume.VolumeID, &models.Snapshot{Name: "snap1", UserTags: []string{"team-a", "retention:daily"}}, vpcs.Logger)
+	require.NoError(t, err)
+	_, err = snapshots.CreateSnapshot(volume.VolumeID, &models.Snapshot{Name: "snap2"}, vpcs.Logger)
+	require.NoError(t, err)
+
+	// The snapshot is renamed and relabeled in one update
+	diff, err := vpcs.UpdateSnapshot(UpdateSnapshotRequest{
+		VolumeID:   volume.VolumeID,
+		SnapshotID: snapshot.ID,
+		Name:       "snap1-long-term",
+		Tags:       []string{"retention:long-term"},
+		OwnedKeys:  []string{"retention"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &TagDiff{Added: []string{"retention:long-term"}, Removed: []string{"retention:daily"}}, diff)
+	snapshot, err = snapshots.GetSnapshot(volume.VolumeID, snapshot.ID, vpcs.Logger)
+	require.NoError(t, err)
+	assert.Equal(t, "snap1-long-term", snapshot.Name)
+	assert.Equal(t, []string{"team-a", "retention:long-term"}, snapshot.UserTags)
+	assert.Equal(t, 1, fake.CallCount("UpdateSnapshot"))
+
+	// A rename alone keeps the tags, and a snapshot which already is as requested is left alone
+	diff, err = vpcs.UpdateSnapshotWithContext(context.Background(), UpdateSnapshotRequest{VolumeID: volume.VolumeID, SnapshotID: snapshot.ID, Name: "snap1"})
+	require.NoError(t, err)
+	assert.True(t, diff.Empty())
+	_, err = vpcs.UpdateSnapshot(UpdateSnapshotRequest{VolumeID: volume.VolumeID, SnapshotID: snapshot.ID, Name: "snap1", Tags: []string{"team-a"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, fake.CallCount("UpdateSnapshot"))
+	snapshot, err = snapshots.GetSnapshot(volume.VolumeID, snapshot.ID, vpcs.Logger)
+	require.NoError(t, err)
+	assert.Equal(t, "snap1", snapshot.Name)
+	assert.Equal(t, []string{"team-a", "retention:long-term"}, snapshot.UserTags)
+
+	// Duplicate names are not retried
+	_, err = vpcs.UpdateSnapshot(UpdateSnapshotRequest{VolumeID: volume.VolumeID, SnapshotID: snapshot.ID, Name: "snap2"})
+	assert.Equal(t, "FailedToUpdateSnapshot", userErrorCode(err))
+	assert.Equal(t, 3, fake.CallCount("UpdateSnapshot"))
+}
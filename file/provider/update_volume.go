@@ -18,22 +18,54 @@
 package provider
 
 import (
-	"strings"
+	"context"
+	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/globaltagging"
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	"go.uber.org/zap"
 )
 
-// UpdateVolume PATCH to /volumes
+// tagPageLimit is the page size used to list the tags of a share
+const tagPageLimit = 100
+
+// UpdateVolume PATCH to /volumes, it updates the user tags of the share as UpdateVolumeTags does
 func (vpcs *VPCSession) UpdateVolume(volumeTemplate provider.Volume) error {
+	_, err := vpcs.UpdateVolumeTags(volumeTemplate)
+	return err
+}
+
+// UpdateVolumeTags updates the user tags of the share with the requested ones and returns the tags added and
+// removed. The tags whose key is one of ManagedTagKeys are owned by the library, a requested managed tag replaces
+// the existing tags with the same key. The tags whose key is one of ownedKeys are owned by the caller, the ones
+// which are not requested are removed. Any other tag of the share is kept. The tags are updated through the
+// Global Tagging API when the session has an endpoint for it, through the share otherwise
+func (vpcs *VPCSession) UpdateVolumeTags(volumeTemplate provider.Volume, ownedKeys ...string) (*TagDiff, error) {
+	if tagService := vpcs.tagService(); tagService != nil {
+		if len(volumeTemplate.VPCVolume.Tags) == 0 && len(ownedKeys) == 0 {
+			return &TagDiff{}, nil
+		}
+		diff, err := vpcs.updateTags(tagService, volumeTemplate.VolumeID, volumeTemplate.CRN, models.TagTypeUser, func(attached []string) TagDiff {
+			_, diff := reconcileManagedTags(attached, volumeTemplate.VPCVolume.Tags, ownedKeys)
+			return diff
+		})
+		if err != nil {
+			vpcs.Logger.Error("Failed to update volume tags from Global Tagging", zap.Reflect("BackendError", err))
+			return nil, userError.GetUserError("FailedToUpdateVolume", err, volumeTemplate.VolumeID)
+		}
+		return diff, nil
+	}
+
 	var existShare *models.Share
 	var err error
 	var etag string
+	var diff TagDiff
 
 	//Fetch existing volume Tags
-	err = retryWithMinRetries(vpcs.Logger, func() error {
+	err = vpcs.GetRetryPolicy().RetryWithAttempts(vpcs.requestContext(), vpcs.Logger, minRetryAttempt, func() error {
 		// Get volume details
 		existShare, etag, err = vpcs.Apiclient.FileShareService().GetFileShareEtag(volumeTemplate.VolumeID, vpcs.Logger)
 
@@ -47,20 +79,20 @@ func (vpcs *VPCSession) UpdateVolume(volumeTemplate provider.Volume) error {
 			return userError.GetUserError("VolumeNotInValidState", err, volumeTemplate.VolumeID)
 		}
 
+		var userTags []string
+		userTags, diff = reconcileManagedTags(existShare.UserTags, volumeTemplate.VPCVolume.Tags, ownedKeys)
+
 		//If tags are equal then skip the UpdateFileShare RIAAS API call
-		if ifTagsEqual(existShare.UserTags, volumeTemplate.VPCVolume.Tags) {
+		if diff.Empty() {
 			vpcs.Logger.Info("There is no change in user tags for volume, skipping the updateVolume for VPC IaaS... ", zap.Reflect("existShare", existShare.UserTags), zap.Reflect("volumeRequest", volumeTemplate.VPCVolume.Tags))
 			return nil
 		}
 
-		//Append the existing tags with the requested input tags
-		existShare.UserTags = append(existShare.UserTags, volumeTemplate.VPCVolume.Tags...)
-
 		volume := &models.Share{
-			UserTags: existShare.UserTags,
+			UserTags: userTags,
 		}
 
-		vpcs.Logger.Info("Calling VPC provider for volume UpdateVolumeWithTags...")
+		vpcs.Logger.Info("Calling VPC provider for volume UpdateVolumeWithTags...", zap.Reflect("tagDiff", diff))
 
 		err = vpcs.Apiclient.FileShareService().UpdateFileShareWithEtag(volumeTemplate.VolumeID, etag, volume, vpcs.Logger)
 		return err
@@ -68,22 +100,108 @@ func (vpcs *VPCSession) UpdateVolume(volumeTemplate provider.Volume) error {
 
 	if err != nil {
 		vpcs.Logger.Error("Failed to update volume tags from VPC provider", zap.Reflect("BackendError", err))
-		return userError.GetUserError("FailedToUpdateVolume", err, volumeTemplate.VolumeID)
+		return nil, userError.GetUserError("FailedToUpdateVolume", err, volumeTemplate.VolumeID)
 	}
 
-	return err
+	return &diff, nil
+}
+
+// UpdateVolumeTagsWithContext updates the user tags of the share as UpdateVolumeTags does, giving up once ctx is done
+func (vpcs *VPCSession) UpdateVolumeTagsWithContext(ctx context.Context, volumeTemplate provider.Volume, ownedKeys ...string) (*TagDiff, error) {
+	return vpcs.withContext(ctx).UpdateVolumeTags(volumeTemplate, ownedKeys...)
+}
+
+// ReconcileVolumeTags makes the tags of tagType, models.TagTypeUser or models.TagTypeAccess, attached to the
+// share exactly tags, tags are compared regardless of case. An empty tags detaches all the tags of tagType.
+// It needs a Global Tagging endpoint, which the session has when IBMCLOUD_GT_API_ENDPOINT is set
+func (vpcs *VPCSession) ReconcileVolumeTags(volumeID string, tagType string, tags []string) error {
+	vpcs.Logger.Debug("Entry of ReconcileVolumeTags method...")
+	defer vpcs.Logger.Debug("Exit from ReconcileVolumeTags method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "ReconcileVolumeTags", time.Now())
+
+	tagService := vpcs.tagService()
+	if tagService == nil {
+		return userError.GetUserError("TagServiceNotConfigured", nil, volumeID)
+	}
+
+	_, err := vpcs.updateTags(tagService, volumeID, "", tagType, func(attached []string) TagDiff {
+		return diffTags(attached, tags)
+	})
+	if err != nil {
+		vpcs.Logger.Error("Failed to reconcile volume tags", zap.String("tagType", tagType), zap.Reflect("BackendError", err))
+		return userError.GetUserError("FailedToReconcileVolumeTags", err, tagType, volumeID)
+	}
+	return nil
+}
+
+// ReconcileVolumeTagsWithContext makes the tags of tagType attached to the share exactly tags, giving up once ctx is done
+func (vpcs *VPCSession) ReconcileVolumeTagsWithContext(ctx context.Context, volumeID string, tagType string, tags []string) error {
+	return vpcs.withContext(ctx).ReconcileVolumeTags(volumeID, tagType, tags)
 }
 
-// ifTagsEqual will check if there is change to existing tags
-func ifTagsEqual(existingTags []string, newTags []string) bool {
-	//Join slice into a string
-	tags := strings.ToLower(strings.Join(existingTags, ","))
-	for _, v := range newTags {
-		if !strings.Contains(tags, strings.ToLower(v)) {
-			//Tags are different
-			return false
+// updateTags attaches and detaches the tags of tagType the share needs as per the diff computed from its
+// attached tags. The share is fetched for its CRN when shareCRN is empty
+func (vpcs *VPCSession) updateTags(tagService globaltagging.TagManager, volumeID string, shareCRN string, tagType string, diffFunc func(attached []string) TagDiff) (*TagDiff, error) {
+	if shareCRN == "" {
+		err := vpcs.retry(func() error {
+			share, err := vpcs.Apiclient.FileShareService().GetFileShare(volumeID, vpcs.Logger)
+			if err != nil {
+				return err
+			}
+			shareCRN = share.CRN
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attached, err := vpcs.listTags(tagService, shareCRN, tagType)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffFunc(attached)
+	vpcs.Logger.Info("Updating volume tags", zap.String("tagType", tagType), zap.Reflect("attached", attached), zap.Reflect("tagDiff", diff))
+
+	if len(diff.Added) > 0 {
+		err = vpcs.retry(func() error {
+			return tagService.AttachTags(shareCRN, tagType, diff.Added, vpcs.Logger)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(diff.Removed) > 0 {
+		err = vpcs.retry(func() error {
+			return tagService.DetachTags(shareCRN, tagType, diff.Removed, vpcs.Logger)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &diff, nil
+}
+
+// listTags returns the names of all the tags of tagType attached to the resource
+func (vpcs *VPCSession) listTags(tagService globaltagging.TagManager, resourceCRN string, tagType string) ([]string, error) {
+	names := []string{}
+	for {
+		var tags *models.TagList
+		err := vpcs.retry(func() error {
+			var err error
+			tags, err = tagService.ListTags(resourceCRN, tagType, len(names), tagPageLimit, vpcs.Logger)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags.Items {
+			names = append(names, tag.Name)
+		}
+		if len(tags.Items) == 0 || len(names) >= tags.TotalCount {
+			return names, nil
 		}
 	}
-	//Tags are equal
-	return true
 }
@@ -18,8 +18,11 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
@@ -28,15 +31,19 @@ import (
 )
 
 // maxRetryAttempt ...
-var maxRetryAttempt = 10
+const maxRetryAttempt = 10
 
 // minRetryAttempt ...
 var minRetryAttempt = 5
 
 // maxRetryGap ...
-var maxRetryGap = 60
+const maxRetryGap = 60
 
-// retryGap ...
+// defaultRetryAttempt and defaultRetryGap override maxRetryAttempt and maxRetryGap once set through SetRetryParameters.
+// They are atomic as SetRetryParameters can run while sessions build their retry policies
+var defaultRetryAttempt, defaultRetryGap atomic.Int64
+
+// retryGap is the initial gap in seconds, it is never modified by the retry loops
 var retryGap = 10
 
 // ConstantRetryGap ...
@@ -46,10 +53,17 @@ const (
 	pageSize         = 50
 	SnapshotNotFound = "shares_snapshot_not_found"
 	SharesNotFound   = "shares_not_found"
+	// SharesNameDuplicate is returned when creating a share whose name is taken
+	SharesNameDuplicate = "shares_name_duplicate"
+	// AccessorBindingNotFound is returned for an accessor binding which is gone
+	AccessorBindingNotFound = "shares_accessor_binding_not_found"
 )
 
 var volumeIDPartsCount = 5
 
+// crnPartsCount is the number of colon separated parts of a CRN
+const crnPartsCount = 10
+
 // TODO need to introduce file share related to error codes
 var skipErrorCodes = map[string]bool{
 	"shares_profile_iops_not_allowed":           true,
@@ -88,75 +102,34 @@ var skipErrorCodes = map[string]bool{
 	"shares_profile_bandwidth_not_allowed":      true,
 	"shares_bandwidth_invalid":                  true,
 	"shares_not_implemented":                    true,
+	AccessorBindingNotFound:                     true,
+	string(models.ErrorCodeTagsNotUpdated):      true,
 }
 
-// retry ...
-func retry(logger *zap.Logger, retryfunc func() error) error {
-	var err error
-
-	for i := 0; i < maxRetryAttempt; i++ {
-		if i > 0 {
-			time.Sleep(time.Duration(retryGap) * time.Second)
-		}
-		err = retryfunc()
-		if err != nil {
-			//Skip retry for the below type of Errors
-			modelError, ok := err.(*models.Error)
-			if !ok {
-				continue
-			}
-			if skipRetry(modelError) {
-				break
-			}
-			if i >= 1 {
-				retryGap = 2 * retryGap
-				if retryGap > maxRetryGap {
-					retryGap = maxRetryGap
-				}
-			}
-			if (i + 1) < maxRetryAttempt {
-				logger.Info("Error while executing the function. Re-attempting execution ..", zap.Int("attempt..", i+2), zap.Int("retry-gap", retryGap), zap.Int("max-retry-Attempts", maxRetryAttempt), zap.Error(err))
-			}
-			continue
+// hasErrorCode tells if err is a backend error with the given code
+func hasErrorCode(err error, code string) bool {
+	var modelError *models.Error
+	if !errors.As(err, &modelError) {
+		return false
+	}
+	for _, errorItem := range modelError.Errors {
+		if string(errorItem.Code) == code {
+			return true
 		}
-		return err
 	}
-	return err
+	return false
 }
 
-// retry ...
-func retryWithMinRetries(logger *zap.Logger, retryfunc func() error) error {
-	var err error
-	retryGap := 10
-	for i := 0; i < minRetryAttempt; i++ {
-		if i > 0 {
-			time.Sleep(time.Duration(retryGap) * time.Second)
-		}
-		err = retryfunc()
-		if err != nil {
-			logger.Info("err object is not nil", zap.Reflect("ERR", err))
-			//Skip retry for the below type of Errors
-			modelError, ok := err.(*models.Error)
-			if !ok {
-				continue
-			}
-			if skipRetry(modelError) {
-				break
-			}
-			if i >= 1 {
-				retryGap = 2 * retryGap
-				if retryGap > maxRetryGap {
-					retryGap = maxRetryGap
-				}
-			}
-			if (i + 1) < minRetryAttempt {
-				logger.Info("Error while executing the function. Re-attempting execution ..", zap.Int("attempt..", i+2), zap.Int("retry-gap", retryGap), zap.Int("max-retry-Attempts", minRetryAttempt), zap.Error(err))
-			}
-			continue
-		}
-		return err
+// sleepWithContext waits for the given duration, returning the context error early if ctx is done
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-	return err
 }
 
 // skipRetry skip retry as per listed error codes
@@ -170,18 +143,9 @@ func skipRetry(err *models.Error) bool {
 	return false
 }
 
-// skipRetryForObviousErrors skip retry as per listed error codes
-func skipRetryForObviousErrors(err error) bool {
-
-	// Only for RIaaS attachment related calls error
-	riaasError, ok := err.(*models.Error)
-	if ok {
-		return skipRetry(riaasError)
-	}
-	return false
-}
-
 // FlexyRetry ...
+//
+// Deprecated: use RetryPolicy, which VPCSession methods use through GetRetryPolicy
 type FlexyRetry struct {
 	maxRetryAttempt int
 	maxRetryGap     int
@@ -189,10 +153,11 @@ type FlexyRetry struct {
 
 // NewFlexyRetryDefault ...
 func NewFlexyRetryDefault() FlexyRetry {
+	attempts, gap := retryDefaults()
 	return FlexyRetry{
 		// Default values as we configuration
-		maxRetryAttempt: maxRetryAttempt,
-		maxRetryGap:     maxRetryGap,
+		maxRetryAttempt: attempts,
+		maxRetryGap:     gap,
 	}
 }
 
@@ -206,57 +171,27 @@ func NewFlexyRetry(maxRtyAtmpt int, maxrRtyGap int) FlexyRetry {
 
 // FlexyRetry ...
 func (fRetry *FlexyRetry) FlexyRetry(logger *zap.Logger, funcToRetry func() (error, bool)) error {
-	var err error
-	var stopRetry bool
-	for i := 0; i < fRetry.maxRetryAttempt; i++ {
-		if i > 0 {
-			time.Sleep(time.Duration(retryGap) * time.Second)
-		}
-		// Call function which required retry, retry is decided by function itself
-		err, stopRetry = funcToRetry()
-		if stopRetry {
-			break
-		}
+	return fRetry.FlexyRetryWithContext(context.Background(), logger, funcToRetry)
+}
 
-		// Update retry gap as per exponentioal
-		if i >= 1 {
-			retryGap = 2 * retryGap
-			if retryGap > fRetry.maxRetryGap {
-				retryGap = fRetry.maxRetryGap
-			}
-		}
-		if (i + 1) < fRetry.maxRetryAttempt {
-			logger.Info("UNEXPECTED RESULT, Re-attempting execution ..", zap.Int("attempt..", i+2),
-				zap.Int("retry-gap", retryGap), zap.Int("max-retry-Attempts", fRetry.maxRetryAttempt),
-				zap.Bool("stopRetry", stopRetry), zap.Error(err))
-		}
-	}
-	return err
+// FlexyRetryWithContext is FlexyRetry which gives up as soon as ctx is done
+func (fRetry *FlexyRetry) FlexyRetryWithContext(ctx context.Context, logger *zap.Logger, funcToRetry func() (error, bool)) error {
+	return fRetry.policy().FlexyRetry(ctx, logger, funcToRetry)
 }
 
 // FlexyRetryWithConstGap ...
 func (fRetry *FlexyRetry) FlexyRetryWithConstGap(logger *zap.Logger, funcToRetry func() (error, bool)) error {
-	var err error
-	var stopRetry bool
-	// lets have more number of try for wait for attach and detach specially
-	totalAttempt := fRetry.maxRetryAttempt * 4 // 40 time as per default values i.e 400 seconds
-	for i := 0; i < totalAttempt; i++ {
-		if i > 0 {
-			time.Sleep(time.Duration(ConstantRetryGap) * time.Second)
-		}
-		// Call function which required retry, retry is decided by function itself
-		err, stopRetry = funcToRetry()
-		if stopRetry {
-			break
-		}
+	return fRetry.FlexyRetryWithConstGapWithContext(context.Background(), logger, funcToRetry)
+}
 
-		if (i + 1) < totalAttempt {
-			logger.Info("UNEXPECTED RESULT from FlexyRetryWithConstGap, Re-attempting execution ..", zap.Int("attempt..", i+2),
-				zap.Int("retry-gap", ConstantRetryGap), zap.Int("max-retry-Attempts", totalAttempt),
-				zap.Bool("stopRetry", stopRetry), zap.Error(err))
-		}
-	}
-	return err
+// FlexyRetryWithConstGapWithContext is FlexyRetryWithConstGap which gives up as soon as ctx is done
+func (fRetry *FlexyRetry) FlexyRetryWithConstGapWithContext(ctx context.Context, logger *zap.Logger, funcToRetry func() (error, bool)) error {
+	return fRetry.policy().FlexyRetryWithConstGap(ctx, logger, funcToRetry)
+}
+
+// policy converts the FlexyRetry settings to a RetryPolicy
+func (fRetry *FlexyRetry) policy() *RetryPolicy {
+	return NewRetryPolicy(fRetry.maxRetryAttempt, fRetry.maxRetryGap)
 }
 
 // ToInt ...
@@ -361,15 +296,27 @@ func FromLibToProviderProfile(vpcProfile *models.ProfileDetails, logger *zap.Log
 	profile := &provider.Profile{
 		Name:         vpcProfile.Name,
 		Href:         vpcProfile.Href,
-		Capacity:     (provider.CapIops)(vpcProfile.Capacity),
+		Capacity:     fromProviderToLibCapIops(vpcProfile.Capacity),
 		Family:       vpcProfile.Family,
-		Iops:         (provider.CapIops)(vpcProfile.Iops),
+		Iops:         fromProviderToLibCapIops(vpcProfile.Iops),
 		ResourceType: vpcProfile.ResourceType,
 	}
 
 	return profile
 }
 
+// fromProviderToLibCapIops converting vpc provider CapIops to generic lib CapIops, which has no enum values
+func fromProviderToLibCapIops(capIops models.CapIops) provider.CapIops {
+	return provider.CapIops{
+		Default: capIops.Default,
+		Max:     capIops.Max,
+		Min:     capIops.Min,
+		Step:    capIops.Step,
+		Type:    capIops.Type,
+		Value:   capIops.Value,
+	}
+}
+
 // FromProviderToLibVolumeAccessPoint converting vpc provider share target type to generic lib volume accessPoint Type
 func FromProviderToLibVolumeAccessPoint(vpcShareTarget *models.ShareTarget, logger *zap.Logger) (libVolumeAccessPoint *provider.VolumeAccessPoint) {
 	logger.Info("Entry of FromProviderToLibVolumeAccessPoint method...")
@@ -441,15 +388,36 @@ func IsValidVolumeIDFormat(volID string) bool {
 	return len(parts) >= volumeIDPartsCount
 }
 
-// SetRetryParameters sets the retry logic parameters
+// IsValidCRNFormat validating the CRN has the 10 parts of crn:v1:<cname>:<ctype>:<service-name>:<location>:<scope>:<service-instance>:<resource-type>:<resource>,
+// with the cloud, service name and resource set
+func IsValidCRNFormat(crn string) bool {
+	parts := strings.Split(crn, ":")
+	return len(parts) == crnPartsCount && parts[0] == "crn" && parts[1] == "v1" && parts[2] != "" && parts[4] != "" && parts[9] != ""
+}
+
+// SetRetryParameters sets the package default retry parameters, used by sessions without a RetryPolicy and config
+//
+// Deprecated: the provider passes the max_retry_attempt and max_retry_gap configuration to the RetryPolicy of each session
 func SetRetryParameters(maxAttempts int, maxGap int) {
 	if maxAttempts > 0 {
-		maxRetryAttempt = maxAttempts
+		defaultRetryAttempt.Store(int64(maxAttempts))
 	}
 
 	if maxGap > 0 {
-		maxRetryGap = maxGap
+		defaultRetryGap.Store(int64(maxGap))
+	}
+}
+
+// retryDefaults returns the package default max retry attempts and max retry gap (seconds)
+func retryDefaults() (int, int) {
+	attempts, gap := int(defaultRetryAttempt.Load()), int(defaultRetryGap.Load())
+	if attempts <= 0 {
+		attempts = maxRetryAttempt
+	}
+	if gap <= 0 {
+		gap = maxRetryGap
 	}
+	return attempts, gap
 }
 
 func roundUpSize(volumeSizeBytes int64, allocationUnitBytes int64) int64 {
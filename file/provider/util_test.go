@@ -18,8 +18,10 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -30,9 +32,38 @@ import (
 )
 
 func TestSetRetryParameters(t *testing.T) {
+	attempts, gap := retryDefaults()
+	t.Cleanup(func() { SetRetryParameters(attempts, gap) })
 	SetRetryParameters(2, 5)
-	assert.Equal(t, maxRetryAttempt, 2)
-	assert.Equal(t, maxRetryGap, 5)
+	attempts, gap = retryDefaults()
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 5, gap)
+
+	// Values which are not set keep the previous defaults
+	SetRetryParameters(0, -1)
+	attempts, gap = retryDefaults()
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 5, gap)
+}
+
+func TestSetRetryParametersConcurrent(t *testing.T) {
+	attempts, gap := retryDefaults()
+	t.Cleanup(func() { SetRetryParameters(attempts, gap) })
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			SetRetryParameters(i, i)
+		}(i)
+		go func() {
+			defer wg.Done()
+			policy := (&VPCSession{}).GetRetryPolicy()
+			assert.Positive(t, policy.MaxAttempts)
+		}()
+	}
+	wg.Wait()
 }
 
 func GetTestContextLogger() (*zap.Logger, zap.AtomicLevel) {
@@ -58,10 +89,10 @@ func GetTestContextLogger() (*zap.Logger, zap.AtomicLevel) {
 func TestRetry(t *testing.T) {
 	// Setup new style zap logger
 	logger, _ := GetTestContextLogger()
-	SetRetryParameters(2, 5)
+	policy := NewRetryPolicy(2, 5)
 	var err error
 	var attempt int
-	err = retry(logger, func() error {
+	err = policy.Retry(context.Background(), logger, func() error {
 		logger.Info("Testing retry with successful attempt")
 		if attempt == 2 {
 			err = nil
@@ -78,7 +109,7 @@ func TestRetry(t *testing.T) {
 		return err
 	})
 
-	err = retry(logger, func() error {
+	err = policy.Retry(context.Background(), logger, func() error {
 		logger.Info("Testing retry with unsuccessful attempt")
 		errCode := models.ErrorCode("wrong_code")
 		errItem := models.ErrorItem{
@@ -119,19 +150,56 @@ func TestSkipRetry(t *testing.T) {
 }
 
 func TestRetryWithError(t *testing.T) {
-	maxRetryAttempt = 2
-	maxRetryGap = 20
-
 	// Setup new style zap logger
 	logger, _ := GetTestContextLogger()
 	var err error
-	err = retry(logger, func() error {
+	err = NewRetryPolicy(2, 20).Retry(context.Background(), logger, func() error {
 		logger.Info("Testing retry with error")
 		err = errors.New("trace Code:, testerr Please check ")
 		return err
 	})
 }
 
+func TestRetryWithContextCancelled(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+	SetRetryParameters(5, 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var attempt int
+	start := time.Now()
+	err := NewRetryPolicy(5, 5).Retry(ctx, logger, func() error {
+		attempt++
+		cancel()
+		return &models.Error{Errors: []models.ErrorItem{{Code: "internal_error"}}}
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, attempt)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestFlexyRetryWithContextCancelled(t *testing.T) {
+	logger, _ := GetTestContextLogger()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	fRetry := NewFlexyRetry(5, 5)
+	var attempt int
+	err := fRetry.FlexyRetryWithContext(ctx, logger, func() (error, bool) {
+		attempt++
+		return errors.New("not yet"), false
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, attempt)
+
+	attempt = 0
+	err = fRetry.FlexyRetryWithConstGapWithContext(ctx, logger, func() (error, bool) {
+		attempt++
+		return errors.New("not yet"), false
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 1, attempt)
+}
+
 func TestFromProviderToLibVolume(t *testing.T) {
 	// Setup new style zap logger
 	logger, _ := GetTestContextLogger()
@@ -171,3 +239,10 @@ func TestIsValidVolumeIDFormat(t *testing.T) {
 	returnValue = IsValidVolumeIDFormat("34c3ad36-34d9-4d3a-8463-5a176c75801c")
 	assert.Equal(t, returnValue, true)
 }
+
+func TestIsValidCRNFormat(t *testing.T) {
+	assert.True(t, IsValidCRNFormat("crn:v1:bluemix:public:kms:us-south:a/account:instance:key:key1"))
+	assert.False(t, IsValidCRNFormat("key1"))
+	assert.False(t, IsValidCRNFormat("crn:v2:bluemix:public:kms:us-south:a/account:instance:key:key1"))
+	assert.False(t, IsValidCRNFormat("crn:v1:bluemix:public:kms:us-south:a/account:instance:key:"))
+}
@@ -18,9 +18,11 @@
 package provider
 
 import (
+	"context"
 	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
 	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	"go.uber.org/zap"
@@ -40,25 +42,30 @@ func (vpcs *VPCSession) WaitForCreateVolumeAccessPoint(AccessPointRequest provid
 		return nil, err
 	}
 
-	var currentVolAccessPoint *provider.VolumeAccessPointResponse
-	err = vpcs.APIRetry.FlexyRetryWithConstGap(vpcs.Logger, func() (error, bool) {
-		currentVolAccessPoint, err = vpcs.GetVolumeAccessPoint(AccessPointRequest)
-		if err != nil {
-			// Need to stop retry as there is an error while getting volume target
-			// considering that vpcs.GetVolumeAccessPoint already re-tried
-			return err, true
-		}
-		// Stop retry in case of volume target is stable
-		return err, currentVolAccessPoint != nil && currentVolAccessPoint.Status == StatusStable
+	volumeAccessPoint, err := pollLifecycle(vpcs, LifecycleWait[*models.ShareTarget]{
+		Operation: WaitForCreateVolumeAccessPointOp,
+		ID:        AccessPointRequest.VolumeID + "/" + AccessPointRequest.AccessPointID,
+		Get: func() (*models.ShareTarget, error) {
+			return vpcs.findVolumeAccessPoint(AccessPointRequest)
+		},
+		Ready: InLifecycleState[*models.ShareTarget](StatusStable),
+		// findVolumeAccessPoint already retried, so its errors are final
+		Retryable: func(error) bool { return false },
 	})
 
-	// Success case, checks are required in case of timeout happened and volume is still not attached state
-	if err == nil && (currentVolAccessPoint != nil && currentVolAccessPoint.Status == StatusStable) {
+	if err == nil {
+		currentVolAccessPoint := volumeAccessPoint.ToVolumeAccessPointResponse()
+		currentVolAccessPoint.VolumeID = AccessPointRequest.VolumeID
 		return currentVolAccessPoint, nil
 	}
 
-	userErr := userError.GetUserError(string(userError.CreateVolumeAccessPointTimedOut), nil, AccessPointRequest.VolumeID, AccessPointRequest.AccessPointID)
+	userErr := userError.GetUserError(string(userError.CreateVolumeAccessPointTimedOut), err, AccessPointRequest.VolumeID, AccessPointRequest.AccessPointID)
 	vpcs.Logger.Info("Wait for AccessPoint creation timed out", zap.Error(userErr))
 
 	return nil, userErr
 }
+
+// WaitForCreateVolumeAccessPointWithContext is WaitForCreateVolumeAccessPoint which stops polling once ctx is done
+func (vpcs *VPCSession) WaitForCreateVolumeAccessPointWithContext(ctx context.Context, accessPointRequest provider.VolumeAccessPointRequest) (*provider.VolumeAccessPointResponse, error) {
+	return vpcs.withContext(ctx).WaitForCreateVolumeAccessPoint(accessPointRequest)
+}
@@ -18,9 +18,11 @@
 package provider
 
 import (
+	"context"
 	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
 	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
 	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	util "github.com/IBM/ibmcloud-volume-interface/lib/utils"
@@ -39,28 +41,32 @@ func (vpcs *VPCSession) WaitForDeleteVolumeAccessPoint(deleteAccessPointRequest
 		return err
 	}
 
-	err = vpcs.APIRetry.FlexyRetryWithConstGap(vpcs.Logger, func() (error, bool) {
-		_, err := vpcs.GetVolumeAccessPoint(deleteAccessPointRequest)
-		// In case of error we should not retry as there are two conditions for error
-		// 1- some issues at endpoint side --> Which is already covered in vpcs.GetVolumeAccessPoint
-		// 2- AccessPoint not found i.e err != nil --> in this case we should not re-try as it has been deleted
-		if err != nil {
-			return err, true
-		}
-		return err, false
+	_, err = pollLifecycle(vpcs, LifecycleWait[*models.ShareTarget]{
+		Operation: WaitForDeleteVolumeAccessPointOp,
+		ID:        deleteAccessPointRequest.VolumeID + "/" + deleteAccessPointRequest.AccessPointID,
+		Get: func() (*models.ShareTarget, error) {
+			return vpcs.findVolumeAccessPoint(deleteAccessPointRequest)
+		},
+		// The access point is gone once it cannot be found by its ID anymore
+		Gone: func(err error) bool {
+			errMsg, ok := err.(util.Message)
+			return ok && errMsg.Code == userError.AccessPointWithAPIDFindFailed
+		},
+		// findVolumeAccessPoint already retried, so its errors are final
+		Retryable: func(error) bool { return false },
 	})
 
-	// Could be a success case
-	if err != nil {
-		if errMsg, ok := err.(util.Message); ok {
-			if errMsg.Code == userError.AccessPointWithAPIDFindFailed {
-				vpcs.Logger.Info("Volume AccessPoint delete is complete")
-				return nil
-			}
-		}
+	if err == nil {
+		vpcs.Logger.Info("Volume AccessPoint delete is complete")
+		return nil
 	}
 
 	userErr := userError.GetUserError(string(userError.DeleteVolumeAccessPointTimedOut), err, deleteAccessPointRequest.VolumeID, deleteAccessPointRequest.AccessPointID)
 	vpcs.Logger.Info("Wait for delete AccessPoint timed out", zap.Error(userErr))
 	return userErr
 }
+
+// WaitForDeleteVolumeAccessPointWithContext is WaitForDeleteVolumeAccessPoint which stops polling once ctx is done
+func (vpcs *VPCSession) WaitForDeleteVolumeAccessPointWithContext(ctx context.Context, deleteAccessPointRequest provider.VolumeAccessPointRequest) error {
+	return vpcs.withContext(ctx).WaitForDeleteVolumeAccessPoint(deleteAccessPointRequest)
+}
@@ -0,0 +1,100 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/metrics"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"go.uber.org/zap"
+)
+
+// ReadySnapshot is a snapshot which reached the stable lifecycle state
+type ReadySnapshot struct {
+	Snapshot *provider.Snapshot
+	// CapturedAt is when the data of the share was captured, zero when the backend does not report it
+	CapturedAt time.Time
+	// MinimumSize is the minimum size in GiB of a share created from the snapshot
+	MinimumSize int64
+	// Elapsed is the time the wait took
+	Elapsed time.Duration
+}
+
+// WaitForSnapshotReady waits for the snapshot of the share to be stable. The wait times out after timeout, or as
+// per the poll settings of WaitForSnapshotReadyOp when timeout is 0. A snapshot which ends up failed stops the
+// wait right away with a SnapshotFailed error, any other failure of the wait is a SnapshotNotReady error
+func (vpcs *VPCSession) WaitForSnapshotReady(volumeID string, snapshotID string, timeout time.Duration) (*ReadySnapshot, error) {
+	vpcs.Logger.Debug("Entry of WaitForSnapshotReady method...")
+	defer vpcs.Logger.Debug("Exit from WaitForSnapshotReady method...")
+	defer metrics.UpdateDurationFromStart(vpcs.Logger, "WaitForSnapshotReady", time.Now())
+
+	if volumeID == "" || snapshotID == "" {
+		return nil, userError.GetUserError("ErrorRequiredFieldMissing", nil, "volumeID and snapshotID")
+	}
+
+	session := vpcs
+	if timeout > 0 {
+		scoped := *vpcs
+		scoped.Poller = vpcs.GetPoller().WithTimeout(WaitForSnapshotReadyOp, timeout)
+		session = &scoped
+	}
+
+	vpcs.Logger.Info("Getting snapshot details from VPC provider...", zap.Reflect("snapshotID", snapshotID), zap.Duration("timeout", timeout))
+	start := time.Now()
+	snapshot, err := pollLifecycle(session, LifecycleWait[*models.Snapshot]{
+		Operation: WaitForSnapshotReadyOp,
+		ID:        snapshotID,
+		Get: func() (*models.Snapshot, error) {
+			return vpcs.Apiclient.SnapshotService().GetSnapshot(volumeID, snapshotID, vpcs.Logger)
+		},
+		Ready: InLifecycleState[*models.Snapshot](snapshotReadyState),
+		// A snapshot being deleted never gets stable again
+		Terminal: []string{StatusDeleting, StatusDeleted},
+	})
+
+	if err != nil {
+		var stateErr *LifecycleStateError
+		if errors.As(err, &stateErr) && stateErr.State == StatusFailed {
+			vpcs.Logger.Error("Snapshot is in the failed state", zap.Reflect("SnapshotDetails", snapshot), zap.Error(err))
+			return nil, userError.GetUserError("SnapshotFailed", err, snapshotID, volumeID)
+		}
+		vpcs.Logger.Info("Snapshot could not get ready (stable) state", zap.Reflect("SnapshotDetails", snapshot), zap.Error(err))
+		return nil, userError.GetUserError("SnapshotNotReady", err, snapshotID, volumeID)
+	}
+
+	readySnapshot := &ReadySnapshot{
+		Snapshot:    FromProviderToLibSnapshot(volumeID, snapshot, vpcs.Logger),
+		MinimumSize: snapshot.MinimumSize,
+		Elapsed:     time.Since(start),
+	}
+	if snapshot.CapturedAt != nil {
+		readySnapshot.CapturedAt = *snapshot.CapturedAt
+	}
+	vpcs.Logger.Info("Snapshot got ready (stable) state", zap.Reflect("SnapshotDetails", snapshot), zap.Duration("elapsed", readySnapshot.Elapsed))
+	return readySnapshot, nil
+}
+
+// WaitForSnapshotReadyWithContext is WaitForSnapshotReady which stops polling once ctx is done
+func (vpcs *VPCSession) WaitForSnapshotReadyWithContext(ctx context.Context, volumeID string, snapshotID string, timeout time.Duration) (*ReadySnapshot, error) {
+	return vpcs.withContext(ctx).WaitForSnapshotReady(volumeID, snapshotID, timeout)
+}
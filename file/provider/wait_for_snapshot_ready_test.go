@@ -0,0 +1,114 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider ...
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForSnapshotReady(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		snapshot, err := vpcs.Apiclient.SnapshotService().CreateSnapshot(volume.VolumeID, &models.Snapshot{Name: "snap1"}, vpcs.Logger)
+		require.NoError(t, err)
+
+		readySnapshot, err := vpcs.WaitForSnapshotReadyWithContext(context.Background(), volume.VolumeID, snapshot.ID, 0)
+		require.NoError(t, err)
+		assert.True(t, readySnapshot.Snapshot.ReadyToUse)
+		assert.Equal(t, snapshot.ID, readySnapshot.Snapshot.SnapshotID)
+		assert.Equal(t, *snapshot.CapturedAt, readySnapshot.CapturedAt)
+		assert.Equal(t, int64(10), readySnapshot.MinimumSize)
+		assert.Equal(t, GiBToBytes(10), readySnapshot.Snapshot.SnapshotSize)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateSnapshot", Fail: true})
+		snapshot, err := vpcs.Apiclient.SnapshotService().CreateSnapshot(volume.VolumeID, &models.Snapshot{Name: "snap1"}, vpcs.Logger)
+		require.NoError(t, err)
+
+		_, err = vpcs.WaitForSnapshotReady(volume.VolumeID, snapshot.ID, 0)
+		assert.Equal(t, "SnapshotFailed", userErrorCode(err))
+		assert.Contains(t, err.Error(), fakevpc.ErrorCodeInternalError)
+		// The wait stopped as soon as the snapshot failed rather than polling on
+		assert.Less(t, fake.CallCount("GetSnapshot"), vpcs.RetryPolicy.MaxAttempts)
+	})
+
+	t.Run("timed out", func(t *testing.T) {
+		vpcs, fake := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+		fake.InjectFault(fakevpc.Fault{Operation: "CreateSnapshot", Stuck: true})
+		snapshot, err := vpcs.Apiclient.SnapshotService().CreateSnapshot(volume.VolumeID, &models.Snapshot{Name: "snap1"}, vpcs.Logger)
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = vpcs.WaitForSnapshotReady(volume.VolumeID, snapshot.ID, 30*time.Millisecond)
+		assert.Equal(t, "SnapshotNotReady", userErrorCode(err))
+		assert.Contains(t, err.Error(), "timed out after 30ms in the pending lifecycle state")
+		assert.Less(t, time.Since(start), time.Second)
+		// The session poller is left as is
+		assert.Nil(t, vpcs.Poller)
+	})
+
+	t.Run("missing snapshot", func(t *testing.T) {
+		vpcs, _ := fakeVPCSession(t)
+		volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+		require.NoError(t, err)
+
+		_, err = vpcs.WaitForSnapshotReady(volume.VolumeID, "missing", 0)
+		assert.Equal(t, "SnapshotNotReady", userErrorCode(err))
+		_, err = vpcs.WaitForSnapshotReady("", "missing", 0)
+		assert.Equal(t, "ErrorRequiredFieldMissing", userErrorCode(err))
+	})
+}
+
+func TestCreateSnapshotWithOptions(t *testing.T) {
+	vpcs, fake := fakeVPCSession(t)
+	volume, err := vpcs.CreateVolume(fakeVolumeRequest("fake-volume"))
+	require.NoError(t, err)
+
+	// CreateSnapshot returns right away
+	snapshot, err := vpcs.CreateSnapshot(volume.VolumeID, provider.SnapshotParameters{Name: "snap1"})
+	require.NoError(t, err)
+	assert.False(t, snapshot.ReadyToUse)
+
+	snapshot, err = vpcs.CreateSnapshotWithOptionsWithContext(context.Background(), volume.VolumeID, provider.SnapshotParameters{Name: "snap2"}, CreateSnapshotOptions{WaitForReady: true})
+	require.NoError(t, err)
+	assert.True(t, snapshot.ReadyToUse)
+
+	// The snapshot is returned along with the error when it does not get ready
+	fake.InjectFault(fakevpc.Fault{Operation: "CreateSnapshot", Stuck: true})
+	snapshot, err = vpcs.CreateSnapshotWithOptions(volume.VolumeID, provider.SnapshotParameters{Name: "snap3"}, CreateSnapshotOptions{WaitForReady: true, ReadyTimeout: 30 * time.Millisecond})
+	assert.Equal(t, "SnapshotNotReady", userErrorCode(err))
+	require.NotNil(t, snapshot)
+	assert.NotEmpty(t, snapshot.SnapshotID)
+	assert.False(t, snapshot.ReadyToUse)
+}
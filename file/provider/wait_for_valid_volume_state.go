@@ -18,6 +18,7 @@
 package provider
 
 import (
+	"context"
 	"time"
 
 	userError "github.com/IBM/ibmcloud-volume-file-vpc/common/messages"
@@ -27,31 +28,39 @@ import (
 )
 
 // WaitForValidVolumeState checks the file share for valid status
-func WaitForValidVolumeState(vpcs *VPCSession, volumeID string) (err error) {
+func WaitForValidVolumeState(vpcs *VPCSession, volumeID string) error {
 	vpcs.Logger.Debug("Entry of WaitForValidVolumeState file method...")
 	defer vpcs.Logger.Debug("Exit from WaitForValidVolumeState file method...")
 	defer metrics.UpdateDurationFromStart(vpcs.Logger, "WaitForValidVolumeState", time.Now())
 
 	vpcs.Logger.Info("Getting file share details from VPC file provider...", zap.Reflect("VolumeID", volumeID))
 
-	var volume *models.Share
-	err = retry(vpcs.Logger, func() error {
-		volume, err = vpcs.Apiclient.FileShareService().GetFileShare(volumeID, vpcs.Logger)
-		if err != nil {
-			return err
-		}
-		vpcs.Logger.Info("Getting file share details from VPC provider...", zap.Reflect("volume", volume))
-		if volume != nil && volume.Status == StatusStable {
-			vpcs.Logger.Info("Volume got valid (stable) state", zap.Reflect("VolumeDetails", volume))
-			return nil
-		}
-		return userError.GetUserError("VolumeNotInValidState", err, volumeID)
-	})
+	volume, err := pollLifecycle(vpcs, validVolumeStateWait(vpcs, volumeID))
 
 	if err != nil {
 		vpcs.Logger.Info("Volume could not get valid (stable) state", zap.Reflect("VolumeDetails", volume))
 		return userError.GetUserError("VolumeNotInValidState", err, volumeID)
 	}
 
+	vpcs.Logger.Info("Volume got valid (stable) state", zap.Reflect("VolumeDetails", volume))
 	return nil
 }
+
+// validVolumeStateWait is the wait for the file share to be stable
+func validVolumeStateWait(vpcs *VPCSession, volumeID string) LifecycleWait[*models.Share] {
+	return LifecycleWait[*models.Share]{
+		Operation: WaitForValidVolumeStateOp,
+		ID:        volumeID,
+		Get: func() (*models.Share, error) {
+			return vpcs.Apiclient.FileShareService().GetFileShare(volumeID, vpcs.Logger)
+		},
+		Ready: InLifecycleState[*models.Share](StatusStable),
+		// A share being deleted never gets stable again
+		Terminal: []string{StatusDeleting, StatusDeleted},
+	}
+}
+
+// WaitForValidVolumeStateWithContext is WaitForValidVolumeState which stops polling once ctx is done
+func WaitForValidVolumeStateWithContext(ctx context.Context, vpcs *VPCSession, volumeID string) error {
+	return WaitForValidVolumeState(vpcs.withContext(ctx), volumeID)
+}
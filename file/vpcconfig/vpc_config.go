@@ -18,6 +18,8 @@
 package utils
 
 import (
+	"time"
+
 	"github.com/IBM/ibmcloud-volume-interface/config"
 )
 
@@ -26,4 +28,16 @@ type VPCFileConfig struct {
 	VPCConfig    *config.VPCProviderConfig
 	IKSConfig    *config.IKSConfig
 	ServerConfig *config.ServerConfig
+
+	// WaitTimeouts overrides the timeout of the provider wait operations by name, e.g. "WaitForValidVolumeState".
+	// NewProvider reads it from WaitTimeoutsEnv when it is nil
+	WaitTimeouts map[string]time.Duration
+
+	// GlobalTaggingURL is the Global Tagging endpoint the tags of the shares are updated through.
+	// NewProvider reads it from GlobalTaggingEndpointEnv when it is empty
+	GlobalTaggingURL string
 }
+
+// GlobalTaggingEndpointEnv is the environment variable holding the Global Tagging endpoint,
+// e.g. "https://tags.global-search-tagging.cloud.ibm.com"
+const GlobalTaggingEndpointEnv = "IBMCLOUD_GT_API_ENDPOINT"
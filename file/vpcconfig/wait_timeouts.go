@@ -0,0 +1,59 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package utils ...
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// WaitTimeoutsEnv is the environment variable holding the wait timeouts, as comma separated operation=duration
+// pairs, e.g. "WaitForValidVolumeState=10m,WaitForCreateVolumeAccessPoint=15m"
+const WaitTimeoutsEnv = "VPC_WAIT_TIMEOUTS"
+
+// ParseWaitTimeouts parses comma separated operation=duration pairs into wait timeouts
+func ParseWaitTimeouts(value string) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		operation, duration, found := strings.Cut(pair, "=")
+		operation = strings.TrimSpace(operation)
+		if !found || operation == "" {
+			return nil, fmt.Errorf("invalid wait timeout %q, expected operation=duration", pair)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil {
+			return nil, fmt.Errorf("invalid wait timeout for %s: %v", operation, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("invalid wait timeout for %s: %s is not positive", operation, timeout)
+		}
+		timeouts[operation] = timeout
+	}
+	return timeouts, nil
+}
+
+// WaitTimeoutsFromEnv returns the wait timeouts set through WaitTimeoutsEnv
+func WaitTimeoutsFromEnv() (map[string]time.Duration, error) {
+	return ParseWaitTimeouts(os.Getenv(WaitTimeoutsEnv))
+}
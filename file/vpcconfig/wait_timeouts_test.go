@@ -0,0 +1,49 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWaitTimeouts(t *testing.T) {
+	timeouts, err := ParseWaitTimeouts(" WaitForValidVolumeState=10m, WaitForVolumeDeletion = 90s ,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"WaitForValidVolumeState": 10 * time.Minute,
+		"WaitForVolumeDeletion":   90 * time.Second,
+	}, timeouts)
+
+	timeouts, err = ParseWaitTimeouts("")
+	assert.NoError(t, err)
+	assert.Empty(t, timeouts)
+
+	for _, value := range []string{"WaitForValidVolumeState", "=10m", "WaitForValidVolumeState=ten", "WaitForValidVolumeState=0s"} {
+		_, err = ParseWaitTimeouts(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestWaitTimeoutsFromEnv(t *testing.T) {
+	t.Setenv(WaitTimeoutsEnv, "WaitForSnapshotDeletion=2m")
+	timeouts, err := WaitTimeoutsFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, timeouts["WaitForSnapshotDeletion"])
+}
@@ -52,7 +52,7 @@ func (vpcIks *IksVpcSession) UpdateVolume(volumeRequest provider.Volume) (err er
 	vpcIks.Logger.Info("Successfully validated inputs for UpdateVolume request... ")
 
 	vpcIks.Logger.Info("Calling  provider for volume update...")
-	err = vpcIks.APIRetry.FlexyRetry(vpcIks.Logger, func() (error, bool) {
+	err = vpcIks.GetRetryPolicy().FlexyRetry(vpcIks.RequestContext(), vpcIks.Logger, func() (error, bool) {
 		err = vpcIks.IksSession.Apiclient.FileShareService().UpdateVolume(&pvcTemplate, vpcIks.Logger)
 		return err, err == nil || vpc_provider.SkipRetryForIKS(err)
 	})
@@ -0,0 +1,220 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package watcher ...
+package watcher
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	// Registers the leader_election_master_status and leader_election_slowpath_total metrics of the lease
+	_ "k8s.io/component-base/metrics/prometheus/clientgo/leaderelection"
+)
+
+const (
+	// DefaultLeaseDuration is the default duration the followers wait before taking over a lease which is not renewed
+	DefaultLeaseDuration = 15 * time.Second
+	// DefaultRenewDeadline is the default duration the leader retries to renew its lease before giving up leadership
+	DefaultRenewDeadline = 10 * time.Second
+	// DefaultRetryPeriod is the default period the replicas try to acquire or renew the lease
+	DefaultRetryPeriod = 2 * time.Second
+)
+
+// ErrLeadershipLost is returned by Run when the replica lost the lease before its context is done. The replica
+// cannot process events anymore, it is expected to exit and be restarted
+var ErrLeadershipLost = errors.New("lost the watcher leadership")
+
+// errCacheNotSynced is returned by the run function of a watcher when it stopped before its informer caches synced
+var errCacheNotSynced = errors.New("failed to sync the cache")
+
+// LeaderElection configures the Lease based leader election of the watcher replicas, only the replica which
+// holds the lease processes the events. The lease is released when the leader stops, so that another replica
+// takes over within RetryPeriod
+type LeaderElection struct {
+	// LeaseName is the name of the Lease, it is required
+	LeaseName string
+	// LeaseNamespace is the namespace of the Lease, it is required
+	LeaseNamespace string
+	// Identity identifies the replica in the Lease, it defaults to the POD_NAME environment variable, then to the host name
+	Identity string
+	// LeaseDuration is the duration the followers wait before taking over a lease which is not renewed
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration the leader retries to renew its lease before giving up leadership
+	RenewDeadline time.Duration
+	// RetryPeriod is the period the replicas try to acquire or renew the lease
+	RetryPeriod time.Duration
+}
+
+// LeaderElectionStatus tells the leadership state of the watcher replica
+type LeaderElectionStatus struct {
+	// Enabled tells if leader election is configured, the replica always processes the events otherwise
+	Enabled bool `json:"enabled"`
+	// Identity identifies the replica in the Lease
+	Identity string `json:"identity,omitempty"`
+	// IsLeader tells if the replica holds the lease
+	IsLeader bool `json:"isLeader"`
+	// Leader is the identity of the last observed holder of the lease
+	Leader string `json:"leader,omitempty"`
+	// Transitions is the number of times the replica acquired or lost the lease
+	Transitions int `json:"transitions"`
+	// LastTransitionTime is the time the replica last acquired or lost the lease
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// leaderState records the leadership state of the replica
+type leaderState struct {
+	lock   sync.Mutex
+	status LeaderElectionStatus
+}
+
+// withDefaults returns the leader election with its zero fields set to the defaults
+func (le LeaderElection) withDefaults() LeaderElection {
+	if le.Identity == "" {
+		le.Identity = os.Getenv("POD_NAME")
+	}
+	if le.Identity == "" {
+		le.Identity, _ = os.Hostname()
+	}
+	if le.LeaseDuration <= 0 {
+		le.LeaseDuration = DefaultLeaseDuration
+	}
+	if le.RenewDeadline <= 0 {
+		le.RenewDeadline = DefaultRenewDeadline
+	}
+	if le.RetryPeriod <= 0 {
+		le.RetryPeriod = DefaultRetryPeriod
+	}
+	return le
+}
+
+// LeaderElectionStatus returns the leadership state of the replica
+func (pvw *PVWatcher) LeaderElectionStatus() LeaderElectionStatus {
+	return pvw.leader.get()
+}
+
+// get returns the leadership state of the replica
+func (state *leaderState) get() LeaderElectionStatus {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	return state.status
+}
+
+// setLeading records that the replica acquired or lost the lease
+func (state *leaderState) setLeading(isLeader bool) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	if state.status.IsLeader == isLeader {
+		return
+	}
+	state.status.IsLeader = isLeader
+	state.status.Transitions++
+	state.status.LastTransitionTime = time.Now()
+}
+
+// setLeader records the last observed holder of the lease
+func (state *leaderState) setLeader(identity string) {
+	state.lock.Lock()
+	defer state.lock.Unlock()
+	state.status.Leader = identity
+}
+
+// runWithLeaderElection runs the watcher named name with run while the replica holds the lease, until ctx is done.
+// The leadership state of the replica is recorded in state
+func runWithLeaderElection(ctx context.Context, logger *zap.Logger, kclient kubernetes.Interface, state *leaderState, name string, election LeaderElection, run func(ctx context.Context) error) error {
+	if election.LeaseName == "" || election.LeaseNamespace == "" {
+		return errors.New("the lease name and namespace are required for leader election")
+	}
+	election = election.withDefaults()
+
+	state.lock.Lock()
+	state.status.Enabled = true
+	state.status.Identity = election.Identity
+	state.lock.Unlock()
+
+	lock, err := resourcelock.New(resourcelock.LeasesResourceLock, election.LeaseNamespace, election.LeaseName,
+		kclient.CoreV1(), kclient.CoordinationV1(), resourcelock.ResourceLockConfig{Identity: election.Identity})
+	if err != nil {
+		return err
+	}
+
+	leading := make(chan context.Context, 1)
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   election.LeaseDuration,
+		RenewDeadline:   election.RenewDeadline,
+		RetryPeriod:     election.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            election.LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				logger.Info(name+" acquired the lease", zap.String("identity", election.Identity))
+				state.setLeading(true)
+				leading <- leaderCtx
+			},
+			OnStoppedLeading: func() {
+				logger.Info(name+" is not leading", zap.String("identity", election.Identity))
+				state.setLeading(false)
+			},
+			OnNewLeader: func(identity string) {
+				logger.Info(name+" observed a new leader", zap.String("leader", identity))
+				state.setLeader(identity)
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info(name+" waiting for the lease", zap.String("lease", election.LeaseNamespace+"/"+election.LeaseName), zap.String("identity", election.Identity))
+	electorDone := make(chan struct{})
+	go func() {
+		defer close(electorDone)
+		elector.Run(ctx)
+	}()
+
+	var leaderCtx context.Context
+	select {
+	case leaderCtx = <-leading:
+	case <-electorDone:
+		select {
+		case leaderCtx = <-leading:
+		default:
+			// ctx was done before the lease was acquired
+			return nil
+		}
+	}
+
+	// The watcher runs until the lease is lost or ctx is done
+	if err := run(leaderCtx); errors.Is(err, errCacheNotSynced) {
+		logger.Warn(name+" stopped before its cache synced", zap.Error(err))
+	} else if err != nil {
+		logger.Warn(name+" stopped", zap.Error(err))
+	}
+	<-electorDone
+	if ctx.Err() != nil {
+		return nil
+	}
+	return ErrLeadershipLost
+}
@@ -0,0 +1,132 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package watcher ...
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cloudprovider "github.com/IBM/ibmcloud-volume-file-vpc/pkg/ibmcloudprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRunWithLeaderElection(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
+	clientset := fake.NewSimpleClientset()
+	newReplica := func(identity string) *PVWatcher {
+		return newTestPVWatcher(t, clientset, fakeIBMCloudStorageProvider, Options{LeaderElection: &LeaderElection{
+			LeaseName:      "pvwatcher",
+			LeaseNamespace: "kube-system",
+			Identity:       identity,
+			LeaseDuration:  time.Second,
+			RenewDeadline:  500 * time.Millisecond,
+			RetryPeriod:    50 * time.Millisecond,
+		}})
+	}
+	run := func(pvw *PVWatcher) (context.CancelFunc, chan error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			done <- pvw.Run(ctx)
+		}()
+		return cancel, done
+	}
+
+	replica1 := newReplica("replica-1")
+	cancel1, done1 := run(replica1)
+	require.Eventually(t, func() bool { return replica1.LeaderElectionStatus().IsLeader }, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return replica1.pvSynced() }, 5*time.Second, 10*time.Millisecond)
+
+	replica2 := newReplica("replica-2")
+	cancel2, done2 := run(replica2)
+	require.Eventually(t, func() bool { return replica2.LeaderElectionStatus().Leader == "replica-1" }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, replica2.LeaderElectionStatus().IsLeader)
+	assert.False(t, replica2.pvSynced())
+
+	// The leader releases the lease when it stops and the other replica takes over
+	cancel1()
+	assert.NoError(t, <-done1)
+	status := replica1.LeaderElectionStatus()
+	assert.True(t, status.Enabled)
+	assert.Equal(t, "replica-1", status.Identity)
+	assert.False(t, status.IsLeader)
+	assert.Equal(t, 2, status.Transitions)
+	assert.True(t, replica1.queue.ShuttingDown())
+	// The new leader is observed asynchronously, after the replica starts leading
+	assert.Eventually(t, func() bool {
+		status := replica2.LeaderElectionStatus()
+		return status.IsLeader && status.Leader == "replica-2"
+	}, time.Second, 10*time.Millisecond)
+
+	cancel2()
+	assert.NoError(t, <-done2)
+}
+
+func TestRunWithLeaderElectionWithoutLease(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
+	pvw := newTestPVWatcher(t, fake.NewSimpleClientset(), fakeIBMCloudStorageProvider, Options{LeaderElection: &LeaderElection{LeaseName: "pvwatcher"}})
+
+	assert.Error(t, pvw.Run(context.Background()))
+	assert.False(t, pvw.LeaderElectionStatus().Enabled)
+	assert.Equal(t, LeaderElectionStatus{}, newTestPVWatcher(t, fake.NewSimpleClientset(), fakeIBMCloudStorageProvider, Options{}).LeaderElectionStatus())
+}
+
+func TestRunWithLeaderElectionLogsCacheSyncFailure(t *testing.T) {
+	election := LeaderElection{
+		LeaseName:      "pvwatcher",
+		LeaseNamespace: "kube-system",
+		Identity:       "replica-1",
+		LeaseDuration:  time.Second,
+		RenewDeadline:  500 * time.Millisecond,
+		RetryPeriod:    50 * time.Millisecond,
+	}
+	runUntilCancelled := func(runErr error) *observer.ObservedLogs {
+		core, logs := observer.New(zap.InfoLevel)
+		ctx, cancel := context.WithCancel(context.Background())
+		run := func(leaderCtx context.Context) error {
+			cancel()
+			<-leaderCtx.Done()
+			return runErr
+		}
+		assert.NoError(t, runWithLeaderElection(ctx, zap.New(core), fake.NewSimpleClientset(), &leaderState{}, "PVWatcher", election, run))
+		return logs
+	}
+
+	// A watcher which stops once its cache synced does not report a cache sync failure
+	logs := runUntilCancelled(nil)
+	assert.Zero(t, logs.FilterMessageSnippet("cache synced").Len())
+	assert.Zero(t, logs.FilterMessage("PVWatcher stopped").Len())
+
+	logs = runUntilCancelled(errors.New("watcher failure"))
+	assert.Zero(t, logs.FilterMessageSnippet("cache synced").Len())
+	assert.Equal(t, 1, logs.FilterMessage("PVWatcher stopped").Len())
+
+	logs = runUntilCancelled(fmt.Errorf("%w of the persistent volumes", errCacheNotSynced))
+	assert.Equal(t, 1, logs.FilterMessage("PVWatcher stopped before its cache synced").Len())
+}
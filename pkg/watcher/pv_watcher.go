@@ -18,9 +18,12 @@
 package watcher
 
 import (
+	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	uid "github.com/gofrs/uuid"
@@ -28,6 +31,8 @@ import (
 
 	"github.com/golang/glog"
 
+	vpc_provider "github.com/IBM/ibmcloud-volume-file-vpc/file/provider"
+	vpcconfig "github.com/IBM/ibmcloud-volume-file-vpc/file/vpcconfig"
 	iks_vpc_provider "github.com/IBM/ibmcloud-volume-file-vpc/iks/provider"
 	cloudprovider "github.com/IBM/ibmcloud-volume-file-vpc/pkg/ibmcloudprovider"
 	"github.com/IBM/ibmcloud-volume-interface/config"
@@ -36,15 +41,18 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/fields"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 )
 
 // PVWatcher to watch  pv creation and add taggs
@@ -55,11 +63,47 @@ type PVWatcher struct {
 	provisionerName string
 	recorder        record.EventRecorder
 	cloudProvider   cloudprovider.CloudProviderInterface
+	options         Options
+
+	informerFactory informers.SharedInformerFactory
+	pvLister        corelisters.PersistentVolumeLister
+	pvSynced        cache.InformerSynced
+	pvcLister       corelisters.PersistentVolumeClaimLister
+	namespaceLister corelisters.NamespaceLister
+	cacheSynced     []cache.InformerSynced
+	queue           workqueue.TypedRateLimitingInterface[string]
+
+	// tagUpdates holds the names of the queued PVs whose share tags must be updated through VPC IaaS, and
+	// metadataUpdates the ones whose metadata must be updated because the PV itself changed. A PV queued
+	// for a PVC or namespace change only is in tagUpdates
+	tagUpdatesLock  sync.Mutex
+	tagUpdates      map[string]bool
+	metadataUpdates map[string]bool
+
+	leader leaderState
+}
+
+// Options tunes the PV and snapshot watchers, a zero field takes its default
+type Options struct {
+	// Workers is the number of PVs processed concurrently
+	Workers int
+	// ResyncPeriod is how often the informer replays all the PVs, they are skipped unless they changed
+	ResyncPeriod time.Duration
+	// MaxRetries is the number of times a PV which failed to be processed is requeued before it is dropped
+	MaxRetries int
+	// BaseRetryDelay is the delay before the first requeue of a PV, it doubles on each failure
+	BaseRetryDelay time.Duration
+	// MaxRetryDelay caps the delay between the requeues of a PV
+	MaxRetryDelay time.Duration
+	// LeaderElection makes only the replica holding its lease process the PV events, all the replicas process them when nil
+	LeaderElection *LeaderElection
+	// TagMappings turn labels and annotations of the PVCs and of their namespaces into share tags
+	TagMappings []TagMapping
 }
 
 const (
-	//IbmCloudGtAPIEndpoint ...
-	IbmCloudGtAPIEndpoint = "IBMCLOUD_GT_API_ENDPOINT"
+	//IbmCloudGtAPIEndpoint is the environment variable of the Global Tagging endpoint the volume tags are reconciled through
+	IbmCloudGtAPIEndpoint = vpcconfig.GlobalTaggingEndpointEnv
 	//ReclaimPolicyTag ...
 	ReclaimPolicyTag = "reclaimpolicy:"
 	//NameSpaceTag ...
@@ -103,6 +147,20 @@ const (
 
 	// GiB in bytes
 	GiB = 1024 * 1024 * 1024
+
+	// DefaultWorkers is the default number of PVs processed concurrently
+	DefaultWorkers = 5
+	// DefaultResyncPeriod is the default period the informer replays all the PVs
+	DefaultResyncPeriod = 10 * time.Minute
+	// DefaultMaxRetries is the default number of requeues of a PV which failed to be processed
+	DefaultMaxRetries = 10
+	// DefaultBaseRetryDelay is the default delay before the first requeue of a PV
+	DefaultBaseRetryDelay = 5 * time.Second
+	// DefaultMaxRetryDelay is the default cap of the delay between the requeues of a PV
+	DefaultMaxRetryDelay = 5 * time.Minute
+
+	// queueName names the workqueue in its metrics
+	queueName = "pvwatcher"
 )
 
 // VolumeTypeMap ...
@@ -121,10 +179,13 @@ var kubeconfig = flag.String(
 
 // New creates the Watcher instance
 func New(logger *zap.Logger, provisionerName string, volumeType string, cloudProvider cloudprovider.CloudProviderInterface) *PVWatcher {
+	return NewWithOptions(logger, provisionerName, volumeType, cloudProvider, Options{})
+}
+
+// NewWithOptions creates the Watcher instance tuned by options
+func NewWithOptions(logger *zap.Logger, provisionerName string, volumeType string, cloudProvider cloudprovider.CloudProviderInterface, options Options) *PVWatcher {
 	var restConfig *rest.Config
 	var err error
-	// Register provider
-	VolumeTypeMap[provisionerName] = volumeType
 
 	restConfig, err = clientcmd.BuildConfigFromFlags(*master, *kubeconfig)
 	if err != nil {
@@ -141,101 +202,303 @@ func New(logger *zap.Logger, provisionerName string, volumeType string, cloudPro
 	broadcaster.StartLogging(glog.Infof)
 	eventInterface := clientset.CoreV1().Events("")
 	broadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: eventInterface})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: iksPodName})
+	return newPVWatcher(logger, clientset, recorder, provisionerName, volumeType, cloudProvider, options)
+}
+
+// newPVWatcher creates the Watcher instance on top of kclient, its informers are started by Run
+func newPVWatcher(logger *zap.Logger, kclient kubernetes.Interface, recorder record.EventRecorder, provisionerName string, volumeType string, cloudProvider cloudprovider.CloudProviderInterface, options Options) *PVWatcher {
+	// Register provider
+	VolumeTypeMap[provisionerName] = volumeType
+
+	options = options.withDefaults()
 	pvw := &PVWatcher{
 		logger:          logger,
 		config:          cloudProvider.GetConfig(),
 		provisionerName: provisionerName,
-		kclient:         clientset,
+		kclient:         kclient,
 		cloudProvider:   cloudProvider,
-		recorder:        broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: iksPodName}),
+		recorder:        recorder,
+		options:         options,
+		informerFactory: informers.NewSharedInformerFactory(kclient, options.ResyncPeriod),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[string](options.BaseRetryDelay, options.MaxRetryDelay),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: queueName},
+		),
+		tagUpdates:      map[string]bool{},
+		metadataUpdates: map[string]bool{},
 	}
+
+	pvInformer := pvw.informerFactory.Core().V1().PersistentVolumes()
+	_, _ = pvInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		Handler: cache.ResourceEventHandlerFuncs{
+			UpdateFunc: pvw.updateVolume,
+		},
+		FilterFunc: pvw.filter,
+	})
+	pvw.pvLister = pvInformer.Lister()
+	pvw.pvSynced = pvInformer.Informer().HasSynced
+	pvw.cacheSynced = []cache.InformerSynced{pvw.pvSynced}
+	pvw.watchTagSources()
 	return pvw
 }
 
-// Start start pv watcher
+// withDefaults returns the options with their zero fields set to the defaults
+func (options Options) withDefaults() Options {
+	if options.Workers <= 0 {
+		options.Workers = DefaultWorkers
+	}
+	if options.ResyncPeriod <= 0 {
+		options.ResyncPeriod = DefaultResyncPeriod
+	}
+	if options.MaxRetries <= 0 {
+		options.MaxRetries = DefaultMaxRetries
+	}
+	if options.BaseRetryDelay <= 0 {
+		options.BaseRetryDelay = DefaultBaseRetryDelay
+	}
+	if options.MaxRetryDelay <= 0 {
+		options.MaxRetryDelay = DefaultMaxRetryDelay
+	}
+	return options
+}
+
+// Start start pv watcher, it never returns
 func (pvw *PVWatcher) Start() {
-	watchlist := cache.NewListWatchFromClient(pvw.kclient.CoreV1().RESTClient(), "persistentvolumes", "", fields.Everything())
-	_, controller := cache.NewInformer(watchlist, &v1.PersistentVolume{}, time.Second*0,
-		cache.FilteringResourceEventHandler{
-			Handler: cache.ResourceEventHandlerFuncs{
-				UpdateFunc: pvw.updateVolume,
-			},
-			FilterFunc: pvw.filter,
-		},
-	)
-	pvw.logger.Info("PVWatcher starting")
-	stopch := wait.NeverStop
-	go controller.Run(stopch)
+	if err := pvw.Run(context.Background()); err != nil {
+		pvw.logger.Fatal("PVWatcher failed", zap.Error(err))
+	}
+}
+
+// Run runs the pv watcher until ctx is done. The PVs being processed are finished before it returns. With leader
+// election the watcher only runs while the replica holds the lease, ErrLeadershipLost is returned when it is lost
+func (pvw *PVWatcher) Run(ctx context.Context) error {
+	if err := pvw.validateTagMappings(); err != nil {
+		return err
+	}
+	if pvw.options.LeaderElection != nil {
+		return runWithLeaderElection(ctx, pvw.logger, pvw.kclient, &pvw.leader, "PVWatcher", *pvw.options.LeaderElection, pvw.run)
+	}
+	return pvw.run(ctx)
+}
+
+// run runs the informers and the workers of the pv watcher until ctx is done
+func (pvw *PVWatcher) run(ctx context.Context) error {
+	defer pvw.queue.ShutDown()
+
+	pvw.logger.Info("PVWatcher starting", zap.Int("workers", pvw.options.Workers))
+	pvw.informerFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), pvw.cacheSynced...) {
+		return fmt.Errorf("%w of the persistent volumes", errCacheNotSynced)
+	}
+
+	var workers sync.WaitGroup
+	for i := 0; i < pvw.options.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			wait.UntilWithContext(ctx, pvw.runWorker, time.Second)
+		}()
+	}
 	pvw.logger.Info("PVWatcher started")
-	<-stopch
+
+	<-ctx.Done()
+	pvw.logger.Info("PVWatcher stopping")
+	pvw.queue.ShutDownWithDrain()
+	workers.Wait()
+	pvw.informerFactory.Shutdown()
+	pvw.logger.Info("PVWatcher stopped")
+	return nil
 }
 
+// updateVolume queues the PV when its status, capacity or iops changed
 func (pvw *PVWatcher) updateVolume(oldobj, obj interface{}) {
-	// Run as non-blocking thread to allow parallel processing of volumes
-	go func() {
-		var oldStatus v1.PersistentVolumePhase
-		var newStatus v1.PersistentVolumePhase
-		ctxLogger, requestID := GetContextLogger(context.Background(), false)
-		// panic-recovery function that avoid watcher thread to stop because of unexexpected error
-		defer func() {
-			if r := recover(); r != nil {
-				ctxLogger.Error("Recovered from panic in pvwatcher", zap.Stack("stack"), zap.String("requestID", requestID))
-			}
-		}()
+	newpv, _ := obj.(*v1.PersistentVolume)
+	if newpv == nil {
+		return
+	}
+	//If there is no change to status , capacity or iops we can skip the updateVolume call.
+	if oldobj != nil {
+		oldpv, _ := oldobj.(*v1.PersistentVolume)
+		oldCapacity := oldpv.Spec.Capacity[v1.ResourceStorage]
+		capacity := newpv.Spec.Capacity[v1.ResourceStorage]
+		iops := newpv.Spec.CSI.VolumeAttributes[IOPSLabel]
+		oldiops := oldpv.Spec.CSI.VolumeAttributes[IOPSLabel]
+		newStatus := newpv.Status.Phase
+		oldStatus := oldpv.Status.Phase
+		if (newStatus == oldStatus) && (oldCapacity.Value() == capacity.Value()) && (oldiops == iops) {
+			pvw.logger.Debug("Skipping update Volume as there is no change in status , capacity and iops", zap.String("pv", newpv.Name))
+			return
+		}
 
-		ctxLogger.Info("Entry updateVolume()", zap.Reflect("obj", obj), zap.Reflect("oldobj", oldobj))
-		newpv, _ := obj.(*v1.PersistentVolume)
-		//If there is no change to status , capacity or iops we can skip the updateVolume call.
-		if oldobj != nil {
-			oldpv, _ := oldobj.(*v1.PersistentVolume)
-			oldCapacity := oldpv.Spec.Capacity[v1.ResourceStorage]
-			capacity := newpv.Spec.Capacity[v1.ResourceStorage]
-			iops := newpv.Spec.CSI.VolumeAttributes[IOPSLabel]
-			oldiops := oldpv.Spec.CSI.VolumeAttributes[IOPSLabel]
-			newStatus = newpv.Status.Phase
-			oldStatus = oldpv.Status.Phase
-			if (newStatus == oldStatus) && (oldCapacity.Value() == capacity.Value()) && (oldiops == iops) {
-				ctxLogger.Info("Skipping update Volume as there is no change in status , capacity and iops")
-				return
-			}
+		//Lets invoke the VPC IaaS update Volume only if there is status change and new status is bound state.
+		//This will be true only when PVC is first time created
+		if newStatus != oldStatus && newStatus == v1.VolumeBound {
+			pvw.setTagUpdate(newpv.Name, true)
 		}
+	}
+	pvw.setMetadataUpdate(newpv.Name, true)
+	pvw.queue.Add(newpv.Name)
+}
 
-		session, err := pvw.cloudProvider.GetProviderSession(context.Background(), ctxLogger)
-		if session != nil {
-			iksVpc, ok := session.(*iks_vpc_provider.IksVpcSession)
-
-			if !ok {
-				ctxLogger.Error("Failed to get the IKS-VPC session, Try to restart the CSI driver controller POD")
-				return
-			}
-
-			volume := pvw.getVolumeFromPV(newpv, ctxLogger)
-			// Updating metadata for the volume
-			ctxLogger.Info("Updating metadata for the volume", zap.Reflect("volume", volume))
-			err := iksVpc.UpdateVolume(volume)
-			if err != nil {
-				ctxLogger.Warn("Failed to update volume metadata", zap.Error(err))
-				pvw.recorder.Event(newpv, v1.EventTypeWarning, VolumeUpdateEventReason, err.Error())
-			}
-
-			//Lets invoke the VPC IaaS update Volume only if there is status change and new status is bound state.
-			//This will be true only when PVC is first time created
-			if newStatus != oldStatus && newStatus == v1.VolumeBound {
-				ctxLogger.Info("Updating tags from VPC IaaS")
-				err = iksVpc.VPCSession.UpdateVolume(volume)
-				if err != nil {
-					ctxLogger.Warn("Failed to update volume with tags from VPC IaaS", zap.Error(err))
-					pvw.recorder.Event(newpv, v1.EventTypeWarning, VolumeUpdateEventReason, err.Error())
-				} else {
-					pvw.recorder.Event(newpv, v1.EventTypeNormal, VolumeUpdateEventReason, VolumeUpdateEventSuccess)
-					ctxLogger.Warn("Volume Metadata saved successfully")
-				}
-			} else {
-				ctxLogger.Info("Skipping Updating tags from VPC IaaS as there is no change in tags")
-			}
+// runWorker processes the queued PVs until the queue is shut down
+func (pvw *PVWatcher) runWorker(ctx context.Context) {
+	for pvw.processNextItem(ctx) {
+	}
+}
+
+// processNextItem processes the next queued PV, requeueing it with backoff when it fails. It returns false once
+// the queue is shut down
+func (pvw *PVWatcher) processNextItem(ctx context.Context) bool {
+	name, shutdown := pvw.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer pvw.queue.Done(name)
+
+	err := pvw.syncVolume(ctx, name)
+	switch {
+	case err == nil:
+		pvw.queue.Forget(name)
+	case pvw.queue.NumRequeues(name) < pvw.options.MaxRetries:
+		pvw.logger.Warn("Requeueing the persistent volume", zap.String("pv", name), zap.Int("requeues", pvw.queue.NumRequeues(name)), zap.Error(err))
+		pvw.queue.AddRateLimited(name)
+	default:
+		pvw.logger.Error("Dropping the persistent volume after too many failures", zap.String("pv", name), zap.Error(err))
+		pvw.queue.Forget(name)
+		pvw.setTagUpdate(name, false)
+		pvw.setMetadataUpdate(name, false)
+	}
+	return true
+}
+
+// syncVolume updates the metadata of the volume of the PV when the PV changed, and its tags through VPC IaaS when
+// the PV got bound or the mapped labels and annotations of its PVC or namespace changed
+func (pvw *PVWatcher) syncVolume(ctx context.Context, name string) (err error) {
+	ctxLogger, requestID := GetContextLogger(ctx, false)
+	// panic-recovery function that avoid watcher thread to stop because of unexexpected error
+	defer func() {
+		if r := recover(); r != nil {
+			ctxLogger.Error("Recovered from panic in pvwatcher", zap.Stack("stack"), zap.String("requestID", requestID))
+			err = fmt.Errorf("panic while processing the persistent volume: %v", r)
 		}
-		ctxLogger.Info("Exit updateVolume()", zap.Error(err))
 	}()
+
+	newpv, err := pvw.pvLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		ctxLogger.Info("Skipping update Volume as the persistent volume is gone", zap.String("pv", name))
+		pvw.setTagUpdate(name, false)
+		pvw.setMetadataUpdate(name, false)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ctxLogger.Info("Entry updateVolume()", zap.Reflect("obj", newpv))
+
+	session, err := pvw.cloudProvider.GetProviderSession(ctx, ctxLogger)
+	if err != nil {
+		ctxLogger.Warn("Failed to get the provider session", zap.Error(err))
+		return err
+	}
+	iksVpc, ok := session.(*iks_vpc_provider.IksVpcSession)
+	if !ok {
+		ctxLogger.Error("Failed to get the IKS-VPC session, Try to restart the CSI driver controller POD")
+		pvw.setTagUpdate(name, false)
+		pvw.setMetadataUpdate(name, false)
+		return nil
+	}
+
+	volume := pvw.getVolumeFromPV(newpv, ctxLogger)
+	var metadataErr error
+	// The metadata only changes with the PV, it is not updated again for a PVC or namespace change
+	if pvw.takeMetadataUpdate(name) {
+		ctxLogger.Info("Updating metadata for the volume", zap.Reflect("volume", volume))
+		metadataErr = iksVpc.UpdateVolume(volume)
+		if metadataErr != nil {
+			ctxLogger.Warn("Failed to update volume metadata", zap.Error(metadataErr))
+			pvw.recorder.Event(newpv, v1.EventTypeWarning, VolumeUpdateEventReason, metadataErr.Error())
+			pvw.setMetadataUpdate(name, true)
+		}
+	} else {
+		ctxLogger.Info("Skipping Updating metadata for the volume as the persistent volume did not change")
+	}
+
+	var tagsErr error
+	// The flag is taken before the update, so that a tag update requested meanwhile is not lost
+	if pvw.takeTagUpdate(name) && len(volume.Tags) > 0 {
+		ctxLogger.Info("Updating tags from VPC IaaS")
+		var tagDiff *vpc_provider.TagDiff
+		tagDiff, tagsErr = iksVpc.VPCSession.UpdateVolumeTagsWithContext(ctx, volume, pvw.ownedTagKeys()...)
+		if tagsErr != nil {
+			ctxLogger.Warn("Failed to update volume with tags from VPC IaaS", zap.Error(tagsErr))
+			pvw.recorder.Event(newpv, v1.EventTypeWarning, VolumeUpdateEventReason, tagsErr.Error())
+			pvw.setTagUpdate(name, true)
+		} else {
+			pvw.recorder.Event(newpv, v1.EventTypeNormal, VolumeUpdateEventReason, VolumeUpdateEventSuccess)
+			ctxLogger.Info("Volume Metadata saved successfully", zap.Reflect("tagDiff", tagDiff))
+		}
+	} else {
+		ctxLogger.Info("Skipping Updating tags from VPC IaaS as there is no change in tags")
+	}
+
+	err = errors.Join(metadataErr, tagsErr)
+	ctxLogger.Info("Exit updateVolume()", zap.Error(err))
+	return err
+}
+
+// tagUpdate tells if the tags of the share of the PV must be updated through VPC IaaS
+func (pvw *PVWatcher) tagUpdate(name string) bool {
+	pvw.tagUpdatesLock.Lock()
+	defer pvw.tagUpdatesLock.Unlock()
+	return pvw.tagUpdates[name]
+}
+
+// takeTagUpdate tells if the tags of the share of the PV must be updated through VPC IaaS, and clears it
+func (pvw *PVWatcher) takeTagUpdate(name string) bool {
+	return pvw.takeUpdate(pvw.tagUpdates, name)
+}
+
+// setTagUpdate records if the tags of the share of the PV must be updated through VPC IaaS
+func (pvw *PVWatcher) setTagUpdate(name string, update bool) {
+	pvw.setUpdate(pvw.tagUpdates, name, update)
+}
+
+// metadataUpdate tells if the metadata of the volume of the PV must be updated
+func (pvw *PVWatcher) metadataUpdate(name string) bool {
+	pvw.tagUpdatesLock.Lock()
+	defer pvw.tagUpdatesLock.Unlock()
+	return pvw.metadataUpdates[name]
+}
+
+// takeMetadataUpdate tells if the metadata of the volume of the PV must be updated, and clears it
+func (pvw *PVWatcher) takeMetadataUpdate(name string) bool {
+	return pvw.takeUpdate(pvw.metadataUpdates, name)
+}
+
+// setMetadataUpdate records if the metadata of the volume of the PV must be updated
+func (pvw *PVWatcher) setMetadataUpdate(name string, update bool) {
+	pvw.setUpdate(pvw.metadataUpdates, name, update)
+}
+
+// takeUpdate tells if the PV is in updates, and removes it
+func (pvw *PVWatcher) takeUpdate(updates map[string]bool, name string) bool {
+	pvw.tagUpdatesLock.Lock()
+	defer pvw.tagUpdatesLock.Unlock()
+	update := updates[name]
+	delete(updates, name)
+	return update
+}
+
+// setUpdate adds the PV to updates or removes it
+func (pvw *PVWatcher) setUpdate(updates map[string]bool, name string, update bool) {
+	pvw.tagUpdatesLock.Lock()
+	defer pvw.tagUpdatesLock.Unlock()
+	if update {
+		updates[name] = true
+	} else {
+		delete(updates, name)
+	}
 }
 
 func (pvw *PVWatcher) getTags(pv *v1.PersistentVolume, ctxLogger *zap.Logger) (string, []string) {
@@ -255,6 +518,7 @@ func (pvw *PVWatcher) getTags(pv *v1.PersistentVolume, ctxLogger *zap.Logger) (s
 	tags = append(tags, PVCNameTag+pv.Spec.ClaimRef.Name)
 	tags = append(tags, PVNameTag+pv.ObjectMeta.Name)
 	tags = append(tags, ProvisionerTag+pvw.provisionerName)
+	tags = append(tags, pvw.mappedTags(pv, ctxLogger)...)
 	ctxLogger.Debug("Exit getTags()", zap.String("VolumeCRN", volAttributes[VolumeCRN]), zap.Reflect("tags", tags))
 	return volAttributes[VolumeCRN], tags
 }
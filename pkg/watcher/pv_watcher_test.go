@@ -19,16 +19,22 @@ package watcher
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"net/http"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	cloudprovider "github.com/IBM/ibmcloud-volume-file-vpc/pkg/ibmcloudprovider"
 	"github.com/IBM/ibmcloud-volume-interface/config"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
 	"github.com/golang/glog"
 	"github.com/onsi/gomega/ghttp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	v1 "k8s.io/api/core/v1"
@@ -59,44 +65,9 @@ func TestAddTags(t *testing.T) {
 	logger, _ := GetTestLogger(t)
 	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
 
-	broadcaster := record.NewBroadcaster()
-	broadcaster.StartLogging(glog.Infof)
-	clientset := fake.NewSimpleClientset()
-	eventInterface := clientset.CoreV1().Events("")
-	broadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: eventInterface})
-
-	pvw := &PVWatcher{
-		provisionerName: "ibm-csi-driver",
-		logger:          logger,
-		config:          conf,
-		cloudProvider:   fakeIBMCloudStorageProvider,
-		recorder:        broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "pod-name"}),
-	}
-	pv := &v1.PersistentVolume{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "test-pv",
-		},
-		Spec: v1.PersistentVolumeSpec{
-			StorageClassName:              "test-storage-class",
-			PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete,
-			ClaimRef: &v1.ObjectReference{
-				Namespace: "test-namespace",
-				Name:      "test-pvc",
-			},
-			Capacity: v1.ResourceList(map[v1.ResourceName]resource.Quantity{
-				v1.ResourceStorage: resource.MustParse("1Gi"),
-			}),
-
-			PersistentVolumeSource: v1.PersistentVolumeSource{
-				CSI: &v1.CSIPersistentVolumeSource{
-					Driver:       "vpc-csi-driver",
-					VolumeHandle: "test-volumeid",
-
-					VolumeAttributes: map[string]string{"tags": "mytag1:1,mytag2:2", ClusterIDLabel: "12345", "volumeCRN": "test-volcrn", "iops": "3000"},
-				},
-			},
-		},
-	}
+	pvw := newTestPVWatcher(t, fake.NewSimpleClientset(), fakeIBMCloudStorageProvider, Options{})
+	pvw.config = conf
+	pv := newTestPV("test-pv")
 	pvNoTags := pv.DeepCopy()
 	pvNoTags.Spec.CSI.VolumeAttributes["tags"] = ""
 	testCases := []struct {
@@ -146,10 +117,170 @@ func TestAddTags(t *testing.T) {
 			assert.Equal(t, "12345", vol.Attributes[strings.ToLower(ClusterIDLabel)])
 
 			pvw.updateVolume(testcase.pv, testcase.pv)
+			assert.Equal(t, 0, pvw.queue.Len())
 		})
 	}
 }
 
+func TestUpdateVolumeQueuesChangedPVs(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
+	pvw := newTestPVWatcher(t, fake.NewSimpleClientset(), fakeIBMCloudStorageProvider, Options{})
+
+	oldpv := newTestPV("test-pv")
+	oldpv.Status.Phase = v1.VolumePending
+	newpv := oldpv.DeepCopy()
+	newpv.Status.Phase = v1.VolumeBound
+
+	// Status change to bound queues the PV for a tag update
+	pvw.updateVolume(oldpv, newpv)
+	assert.Equal(t, 1, pvw.queue.Len())
+	assert.True(t, pvw.tagUpdate("test-pv"))
+	assert.True(t, pvw.metadataUpdate("test-pv"))
+
+	// Capacity change of the same PV is deduplicated
+	resized := newpv.DeepCopy()
+	resized.Spec.Capacity[v1.ResourceStorage] = resource.MustParse("2Gi")
+	pvw.updateVolume(newpv, resized)
+	assert.Equal(t, 1, pvw.queue.Len())
+
+	// Unchanged PV is skipped
+	pvw.updateVolume(newTestPV("other-pv"), newTestPV("other-pv"))
+	assert.Equal(t, 1, pvw.queue.Len())
+	assert.False(t, pvw.tagUpdate("other-pv"))
+	assert.False(t, pvw.metadataUpdate("other-pv"))
+}
+
+func TestProcessNextItem(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
+	failingProvider := &sessionErrorProvider{FakeIBMCloudStorageProvider: fakeIBMCloudStorageProvider, err: errors.New("session failure")}
+	pvw := newTestPVWatcher(t, fake.NewSimpleClientset(), failingProvider, Options{MaxRetries: 2, BaseRetryDelay: time.Millisecond, MaxRetryDelay: time.Millisecond})
+
+	pv := newTestPV("test-pv")
+	require.NoError(t, pvw.informerFactory.Core().V1().PersistentVolumes().Informer().GetStore().Add(pv))
+	pvw.setTagUpdate("test-pv", true)
+	pvw.setMetadataUpdate("test-pv", true)
+	pvw.queue.Add("test-pv")
+
+	// A failure requeues the PV with backoff until it is dropped
+	for requeues := 1; requeues <= 2; requeues++ {
+		assert.True(t, pvw.processNextItem(context.Background()))
+		assert.Equal(t, requeues, pvw.queue.NumRequeues("test-pv"))
+		assert.True(t, pvw.tagUpdate("test-pv"))
+		assert.True(t, pvw.metadataUpdate("test-pv"))
+		assert.Eventually(t, func() bool { return pvw.queue.Len() == 1 }, time.Second, time.Millisecond)
+	}
+	assert.True(t, pvw.processNextItem(context.Background()))
+	assert.Equal(t, 0, pvw.queue.NumRequeues("test-pv"))
+	assert.False(t, pvw.tagUpdate("test-pv"))
+	assert.False(t, pvw.metadataUpdate("test-pv"))
+
+	// A PV which is gone is forgotten
+	failingProvider.err = nil
+	pvw.queue.Add("deleted-pv")
+	assert.True(t, pvw.processNextItem(context.Background()))
+	assert.Equal(t, 0, pvw.queue.NumRequeues("deleted-pv"))
+
+	pvw.queue.ShutDown()
+	assert.False(t, pvw.processNextItem(context.Background()))
+}
+
+func TestRun(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
+	pv := newTestPV("test-pv")
+	pv.Status.Phase = v1.VolumePending
+	clientset := fake.NewSimpleClientset(pv)
+	countingProvider := &sessionErrorProvider{FakeIBMCloudStorageProvider: fakeIBMCloudStorageProvider}
+	pvw := newTestPVWatcher(t, clientset, countingProvider, Options{Workers: 2})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- pvw.Run(ctx)
+	}()
+	require.Eventually(t, func() bool { return pvw.pvSynced() }, 5*time.Second, 10*time.Millisecond)
+
+	bound := pv.DeepCopy()
+	bound.Status.Phase = v1.VolumeBound
+	_, err := clientset.CoreV1().PersistentVolumes().UpdateStatus(ctx, bound, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	// The fake session is not an IKS-VPC one, the PV is processed without being requeued
+	require.Eventually(t, func() bool { return countingProvider.sessions.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return !pvw.tagUpdate("test-pv") && !pvw.metadataUpdate("test-pv") && pvw.queue.Len() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("PVWatcher did not stop")
+	}
+	assert.True(t, pvw.queue.ShuttingDown())
+}
+
+// sessionErrorProvider counts the provider sessions and fails to get them with err when it is set
+type sessionErrorProvider struct {
+	*cloudprovider.FakeIBMCloudStorageProvider
+	err      error
+	sessions atomic.Int32
+}
+
+// GetProviderSession ...
+func (sep *sessionErrorProvider) GetProviderSession(ctx context.Context, logger *zap.Logger) (provider.Session, error) {
+	sep.sessions.Add(1)
+	if sep.err != nil {
+		return nil, sep.err
+	}
+	return sep.FakeIBMCloudStorageProvider.GetProviderSession(ctx, logger)
+}
+
+// newTestPVWatcher creates a watcher of the vpc-csi-driver PVs on top of clientset
+func newTestPVWatcher(t *testing.T, clientset *fake.Clientset, cloudProvider cloudprovider.CloudProviderInterface, options Options) *PVWatcher {
+	logger, _ := GetTestLogger(t)
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartLogging(glog.Infof)
+	eventInterface := clientset.CoreV1().Events("")
+	broadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: eventInterface})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "pod-name"})
+	return newPVWatcher(logger, clientset, recorder, "vpc-csi-driver", "vpc-share", cloudProvider, options)
+}
+
+// newTestPV returns a vpc-csi-driver PV
+func newTestPV(name string) *v1.PersistentVolume {
+	return &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1.PersistentVolumeSpec{
+			StorageClassName:              "test-storage-class",
+			PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete,
+			ClaimRef: &v1.ObjectReference{
+				Namespace: "test-namespace",
+				Name:      "test-pvc",
+			},
+			Capacity: v1.ResourceList(map[v1.ResourceName]resource.Quantity{
+				v1.ResourceStorage: resource.MustParse("1Gi"),
+			}),
+
+			PersistentVolumeSource: v1.PersistentVolumeSource{
+				CSI: &v1.CSIPersistentVolumeSource{
+					Driver:       "vpc-csi-driver",
+					VolumeHandle: "test-volumeid",
+
+					VolumeAttributes: map[string]string{"tags": "mytag1:1,mytag2:2", ClusterIDLabel: "12345", "volumeCRN": "test-volcrn", "iops": "3000"},
+				},
+			},
+		},
+	}
+}
+
 // GetTestLogger ...
 func GetTestLogger(t *testing.T) (logger *zap.Logger, teardown func()) {
 	atom := zap.NewAtomicLevel()
@@ -164,7 +295,7 @@ func GetTestLogger(t *testing.T) (logger *zap.Logger, teardown func()) {
 	logger = zap.New(
 		zapcore.NewCore(
 			zapcore.NewJSONEncoder(encoderCfg),
-			zapcore.AddSync(buf),
+			zapcore.Lock(zapcore.AddSync(buf)),
 			atom,
 		),
 		zap.AddCaller(),
@@ -0,0 +1,336 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package watcher ...
+package watcher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	iks_vpc_provider "github.com/IBM/ibmcloud-volume-file-vpc/iks/provider"
+	cloudprovider "github.com/IBM/ibmcloud-volume-file-vpc/pkg/ibmcloudprovider"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	snapshotclientset "github.com/kubernetes-csi/external-snapshotter/client/v4/clientset/versioned"
+	snapshotinformers "github.com/kubernetes-csi/external-snapshotter/client/v4/informers/externalversions"
+	snapshotlisters "github.com/kubernetes-csi/external-snapshotter/client/v4/listers/volumesnapshot/v1"
+	"go.uber.org/zap"
+	"golang.org/x/net/context"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	//VolumeSnapshotTag ...
+	VolumeSnapshotTag = "volumesnapshot:"
+
+	// snapshotQueueName names the workqueue of the snapshot watcher in its metrics
+	snapshotQueueName = "snapshotwatcher"
+)
+
+// SnapshotWatcher watches the VolumeSnapshotContents of the driver and tags their share snapshots with the cluster,
+// the namespace and the name of their VolumeSnapshot. Watching them needs the list and watch permissions on the
+// VolumeSnapshotContents
+type SnapshotWatcher struct {
+	logger          *zap.Logger
+	kclient         kubernetes.Interface
+	snapshotClient  snapshotclientset.Interface
+	provisionerName string
+	cloudProvider   cloudprovider.CloudProviderInterface
+	options         Options
+
+	informerFactory snapshotinformers.SharedInformerFactory
+	contentLister   snapshotlisters.VolumeSnapshotContentLister
+	contentSynced   cache.InformerSynced
+	queue           workqueue.TypedRateLimitingInterface[string]
+
+	// appliedTags holds the tags last applied to the share snapshot of each VolumeSnapshotContent, so that the
+	// resyncs of the informer do not call VPC IaaS again
+	appliedTagsLock sync.Mutex
+	appliedTags     map[string]string
+
+	leader leaderState
+}
+
+// NewSnapshotWatcher creates the snapshot watcher instance tuned by options, its TagMappings are not used
+func NewSnapshotWatcher(logger *zap.Logger, provisionerName string, cloudProvider cloudprovider.CloudProviderInterface, options Options) *SnapshotWatcher {
+	restConfig, err := clientcmd.BuildConfigFromFlags(*master, *kubeconfig)
+	if err != nil {
+		logger.Fatal("Failed to create config:", zap.Error(err))
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		logger.Fatal("Failed to create client:", zap.Error(err))
+	}
+	snapshotClient, err := snapshotclientset.NewForConfig(restConfig)
+	if err != nil {
+		logger.Fatal("Failed to create snapshot client:", zap.Error(err))
+	}
+	return newSnapshotWatcher(logger, clientset, snapshotClient, provisionerName, cloudProvider, options)
+}
+
+// newSnapshotWatcher creates the snapshot watcher instance on top of kclient and snapshotClient, its informer is
+// started by Run
+func newSnapshotWatcher(logger *zap.Logger, kclient kubernetes.Interface, snapshotClient snapshotclientset.Interface, provisionerName string, cloudProvider cloudprovider.CloudProviderInterface, options Options) *SnapshotWatcher {
+	options = options.withDefaults()
+	sw := &SnapshotWatcher{
+		logger:          logger,
+		kclient:         kclient,
+		snapshotClient:  snapshotClient,
+		provisionerName: provisionerName,
+		cloudProvider:   cloudProvider,
+		options:         options,
+		informerFactory: snapshotinformers.NewSharedInformerFactory(snapshotClient, options.ResyncPeriod),
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[string](options.BaseRetryDelay, options.MaxRetryDelay),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: snapshotQueueName},
+		),
+		appliedTags: map[string]string{},
+	}
+
+	contentInformer := sw.informerFactory.Snapshot().V1().VolumeSnapshotContents()
+	_, _ = contentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    sw.addContent,
+			UpdateFunc: func(_, obj interface{}) { sw.addContent(obj) },
+			DeleteFunc: sw.deleteContent,
+		},
+		FilterFunc: sw.filter,
+	})
+	sw.contentLister = contentInformer.Lister()
+	sw.contentSynced = contentInformer.Informer().HasSynced
+	return sw
+}
+
+// Run runs the snapshot watcher until ctx is done. The VolumeSnapshotContents being processed are finished before
+// it returns. With leader election the watcher only runs while the replica holds the lease, ErrLeadershipLost is
+// returned when it is lost
+func (sw *SnapshotWatcher) Run(ctx context.Context) error {
+	if sw.options.LeaderElection != nil {
+		return runWithLeaderElection(ctx, sw.logger, sw.kclient, &sw.leader, "SnapshotWatcher", *sw.options.LeaderElection, sw.run)
+	}
+	return sw.run(ctx)
+}
+
+// LeaderElectionStatus returns the leadership state of the replica
+func (sw *SnapshotWatcher) LeaderElectionStatus() LeaderElectionStatus {
+	return sw.leader.get()
+}
+
+// run runs the informer and the workers of the snapshot watcher until ctx is done
+func (sw *SnapshotWatcher) run(ctx context.Context) error {
+	defer sw.queue.ShutDown()
+
+	sw.logger.Info("SnapshotWatcher starting", zap.Int("workers", sw.options.Workers))
+	sw.informerFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), sw.contentSynced) {
+		return fmt.Errorf("%w of the volume snapshot contents", errCacheNotSynced)
+	}
+
+	var workers sync.WaitGroup
+	for i := 0; i < sw.options.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			wait.UntilWithContext(ctx, sw.runWorker, time.Second)
+		}()
+	}
+	sw.logger.Info("SnapshotWatcher started")
+
+	<-ctx.Done()
+	sw.logger.Info("SnapshotWatcher stopping")
+	sw.queue.ShutDownWithDrain()
+	workers.Wait()
+	sw.logger.Info("SnapshotWatcher stopped")
+	return nil
+}
+
+// filter tells if the object is a VolumeSnapshotContent of the driver which has a share snapshot
+func (sw *SnapshotWatcher) filter(obj interface{}) bool {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	content, _ := obj.(*snapshotv1.VolumeSnapshotContent)
+	return content != nil && content.Spec.Driver == sw.provisionerName &&
+		content.Status != nil && content.Status.SnapshotHandle != nil && *content.Status.SnapshotHandle != ""
+}
+
+// addContent queues the VolumeSnapshotContent when its share snapshot is ready and its tags were not applied yet
+func (sw *SnapshotWatcher) addContent(obj interface{}) {
+	content, _ := obj.(*snapshotv1.VolumeSnapshotContent)
+	if content == nil || content.DeletionTimestamp != nil || !readyToUse(content) {
+		return
+	}
+	if sw.applied(content.Name) == strings.Join(sw.getTags(content), ",") {
+		sw.logger.Debug("Skipping the volume snapshot content as its tags are applied", zap.String("content", content.Name))
+		return
+	}
+	sw.queue.Add(content.Name)
+}
+
+// deleteContent forgets the tags applied to the share snapshot of the VolumeSnapshotContent
+func (sw *SnapshotWatcher) deleteContent(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if content, _ := obj.(*snapshotv1.VolumeSnapshotContent); content != nil {
+		sw.setApplied(content.Name, "")
+	}
+}
+
+// runWorker processes the queued VolumeSnapshotContents until the queue is shut down
+func (sw *SnapshotWatcher) runWorker(ctx context.Context) {
+	for sw.processNextItem(ctx) {
+	}
+}
+
+// processNextItem processes the next queued VolumeSnapshotContent, requeueing it with backoff when it fails. It
+// returns false once the queue is shut down
+func (sw *SnapshotWatcher) processNextItem(ctx context.Context) bool {
+	name, shutdown := sw.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer sw.queue.Done(name)
+
+	err := sw.syncContent(ctx, name)
+	switch {
+	case err == nil:
+		sw.queue.Forget(name)
+	case sw.queue.NumRequeues(name) < sw.options.MaxRetries:
+		sw.logger.Warn("Requeueing the volume snapshot content", zap.String("content", name), zap.Int("requeues", sw.queue.NumRequeues(name)), zap.Error(err))
+		sw.queue.AddRateLimited(name)
+	default:
+		sw.logger.Error("Dropping the volume snapshot content after too many failures", zap.String("content", name), zap.Error(err))
+		sw.queue.Forget(name)
+	}
+	return true
+}
+
+// syncContent updates the tags of the share snapshot of the VolumeSnapshotContent through VPC IaaS
+func (sw *SnapshotWatcher) syncContent(ctx context.Context, name string) (err error) {
+	ctxLogger, requestID := GetContextLogger(ctx, false)
+	// panic-recovery function that avoid watcher thread to stop because of unexexpected error
+	defer func() {
+		if r := recover(); r != nil {
+			ctxLogger.Error("Recovered from panic in snapshotwatcher", zap.Stack("stack"), zap.String("requestID", requestID))
+			err = fmt.Errorf("panic while processing the volume snapshot content: %v", r)
+		}
+	}()
+
+	content, err := sw.contentLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		ctxLogger.Info("Skipping the volume snapshot content as it is gone", zap.String("content", name))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if content.DeletionTimestamp != nil || !sw.filter(content) {
+		return nil
+	}
+
+	shareID, snapshotID := parseSnapshotHandle(content)
+	if shareID == "" || snapshotID == "" {
+		ctxLogger.Warn("Skipping the volume snapshot content as its share snapshot is unknown", zap.String("content", name), zap.String("snapshotHandle", *content.Status.SnapshotHandle))
+		return nil
+	}
+	tags := sw.getTags(content)
+
+	session, err := sw.cloudProvider.GetProviderSession(ctx, ctxLogger)
+	if err != nil {
+		ctxLogger.Warn("Failed to get the provider session", zap.Error(err))
+		return err
+	}
+	iksVpc, ok := session.(*iks_vpc_provider.IksVpcSession)
+	if !ok {
+		ctxLogger.Error("Failed to get the IKS-VPC session, Try to restart the CSI driver controller POD")
+		return nil
+	}
+
+	ctxLogger.Info("Updating snapshot tags from VPC IaaS", zap.String("content", name), zap.String("shareID", shareID), zap.String("snapshotID", snapshotID), zap.Reflect("tags", tags))
+	tagDiff, err := iksVpc.VPCSession.UpdateSnapshotTagsWithContext(ctx, shareID, snapshotID, tags)
+	if err != nil {
+		ctxLogger.Warn("Failed to update snapshot with tags from VPC IaaS", zap.String("content", name), zap.Error(err))
+		return err
+	}
+	sw.setApplied(name, strings.Join(tags, ","))
+	ctxLogger.Info("Snapshot tags saved successfully", zap.String("content", name), zap.Reflect("tagDiff", tagDiff))
+	return nil
+}
+
+// getTags returns the tags of the share snapshot of the VolumeSnapshotContent
+func (sw *SnapshotWatcher) getTags(content *snapshotv1.VolumeSnapshotContent) []string {
+	tags := []string{
+		ClusterIDLabel + ":" + sw.cloudProvider.GetClusterID(),
+		NameSpaceTag + content.Spec.VolumeSnapshotRef.Namespace,
+		VolumeSnapshotTag + content.Spec.VolumeSnapshotRef.Name,
+		ProvisionerTag + sw.provisionerName,
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// applied returns the tags last applied to the share snapshot of the VolumeSnapshotContent
+func (sw *SnapshotWatcher) applied(name string) string {
+	sw.appliedTagsLock.Lock()
+	defer sw.appliedTagsLock.Unlock()
+	return sw.appliedTags[name]
+}
+
+// setApplied records the tags applied to the share snapshot of the VolumeSnapshotContent, empty tags forget it
+func (sw *SnapshotWatcher) setApplied(name string, tags string) {
+	sw.appliedTagsLock.Lock()
+	defer sw.appliedTagsLock.Unlock()
+	if tags == "" {
+		delete(sw.appliedTags, name)
+	} else {
+		sw.appliedTags[name] = tags
+	}
+}
+
+// readyToUse tells if the share snapshot of the VolumeSnapshotContent is ready to use
+func readyToUse(content *snapshotv1.VolumeSnapshotContent) bool {
+	return content.Status != nil && content.Status.ReadyToUse != nil && *content.Status.ReadyToUse
+}
+
+// parseSnapshotHandle returns the IDs of the share and of the share snapshot of the VolumeSnapshotContent. The
+// snapshot handle is either the CRN of the share snapshot, shareID/snapshotID, or the snapshot ID alone whose
+// share is the source volume of the VolumeSnapshotContent
+func parseSnapshotHandle(content *snapshotv1.VolumeSnapshotContent) (string, string) {
+	handle := strings.TrimSpace(*content.Status.SnapshotHandle)
+	if strings.HasPrefix(handle, "crn:") {
+		handle = handle[strings.LastIndex(handle, ":")+1:]
+	}
+	if shareID, snapshotID, found := strings.Cut(handle, "/"); found {
+		return shareID, snapshotID
+	}
+
+	var shareID string
+	if content.Spec.Source.VolumeHandle != nil {
+		// The volume handle of the shares with a mount target is shareID#targetID
+		shareID, _, _ = strings.Cut(*content.Spec.Source.VolumeHandle, "#")
+	}
+	return shareID, handle
+}
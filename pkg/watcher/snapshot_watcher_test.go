@@ -0,0 +1,242 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package watcher ...
+package watcher
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/riaas"
+	vpc_provider "github.com/IBM/ibmcloud-volume-file-vpc/file/provider"
+	iks_vpc_provider "github.com/IBM/ibmcloud-volume-file-vpc/iks/provider"
+	cloudprovider "github.com/IBM/ibmcloud-volume-file-vpc/pkg/ibmcloudprovider"
+	"github.com/IBM/ibmcloud-volume-interface/lib/provider"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	snapshotfake "github.com/kubernetes-csi/external-snapshotter/client/v4/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientfeatures "k8s.io/client-go/features"
+	clientfeaturestesting "k8s.io/client-go/features/testing"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseSnapshotHandle(t *testing.T) {
+	testCases := []struct {
+		name         string
+		handle       string
+		volumeHandle *string
+		shareID      string
+		snapshotID   string
+	}{
+		{
+			name:       "snapshot CRN",
+			handle:     "crn:v1:bluemix:public:is:us-south-1:a/account1::share-snapshot:share1/snapshot1",
+			shareID:    "share1",
+			snapshotID: "snapshot1",
+		}, {
+			name:       "share and snapshot IDs",
+			handle:     "share1/snapshot1",
+			shareID:    "share1",
+			snapshotID: "snapshot1",
+		}, {
+			name:         "snapshot ID of the source volume",
+			handle:       "snapshot1",
+			volumeHandle: stringPtr("share1#target1"),
+			shareID:      "share1",
+			snapshotID:   "snapshot1",
+		}, {
+			name:       "snapshot ID without source volume",
+			handle:     "snapshot1",
+			snapshotID: "snapshot1",
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			content := newTestContent("content1", testcase.handle)
+			content.Spec.Source.VolumeHandle = testcase.volumeHandle
+			shareID, snapshotID := parseSnapshotHandle(content)
+			assert.Equal(t, testcase.shareID, shareID)
+			assert.Equal(t, testcase.snapshotID, snapshotID)
+		})
+	}
+}
+
+func TestAddContent(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
+	sw := newTestSnapshotWatcher(t, snapshotfake.NewSimpleClientset(), fakeIBMCloudStorageProvider, Options{})
+
+	content := newTestContent("content1", "share1/snapshot1")
+	assert.True(t, sw.filter(content))
+	otherDriver := content.DeepCopy()
+	otherDriver.Spec.Driver = "other-driver"
+	assert.False(t, sw.filter(otherDriver))
+	assert.False(t, sw.filter(newTestContent("content1", "")))
+
+	// Snapshots which are not ready yet are skipped
+	pending := content.DeepCopy()
+	pending.Status.ReadyToUse = boolPtr(false)
+	sw.addContent(pending)
+	assert.Equal(t, 0, sw.queue.Len())
+
+	sw.addContent(content)
+	assert.Equal(t, 1, sw.queue.Len())
+	assert.Equal(t, []string{"clusterID:fake-cluster-id", "namespace:test-namespace", "provisioner:vpc-csi-driver", "volumesnapshot:test-snapshot"}, sw.getTags(content))
+
+	// Applied tags are skipped until they change
+	sw.queue.Forget("content1")
+	name, _ := sw.queue.Get()
+	sw.queue.Done(name)
+	sw.setApplied("content1", "clusterID:fake-cluster-id,namespace:test-namespace,provisioner:vpc-csi-driver,volumesnapshot:test-snapshot")
+	sw.addContent(content)
+	assert.Equal(t, 0, sw.queue.Len())
+	renamed := content.DeepCopy()
+	renamed.Spec.VolumeSnapshotRef.Name = "other-snapshot"
+	sw.addContent(renamed)
+	assert.Equal(t, 1, sw.queue.Len())
+
+	sw.deleteContent(content)
+	assert.Equal(t, "", sw.applied("content1"))
+}
+
+func TestSnapshotWatcherRun(t *testing.T) {
+	// The fake snapshot clientset does not send the bookmark of the watch list streams
+	clientfeaturestesting.SetFeatureDuringTest(t, clientfeatures.WatchListClient, false)
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
+	vpcs, fakeVPC := newFakeVPCSession(t, logger)
+	sessionProvider := &fakeVPCProvider{FakeIBMCloudStorageProvider: fakeIBMCloudStorageProvider, session: &iks_vpc_provider.IksVpcSession{VPCSession: *vpcs}}
+
+	share, err := vpcs.Apiclient.FileShareService().CreateFileShare(&models.Share{Name: "share1", Size: 10, Profile: &models.Profile{Name: "dp2"}, ResourceGroup: &models.ResourceGroup{ID: "rg1"}}, logger)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		share, err = vpcs.Apiclient.FileShareService().GetFileShare(share.ID, logger)
+		return err == nil && share.Status == fakevpc.StateStable
+	}, 5*time.Second, 10*time.Millisecond)
+	snapshot, err := vpcs.Apiclient.SnapshotService().CreateSnapshot(share.ID, &models.Snapshot{Name: "snap1", UserTags: []string{"team:storage"}}, logger)
+	require.NoError(t, err)
+
+	snapshotClient := snapshotfake.NewSimpleClientset(newTestContent("content1", snapshot.CRN))
+	sw := newTestSnapshotWatcher(t, snapshotClient, sessionProvider, Options{Workers: 2})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- sw.Run(ctx)
+	}()
+
+	expected := []string{"team:storage", "clusterID:fake-cluster-id", "namespace:test-namespace", "provisioner:vpc-csi-driver", "volumesnapshot:test-snapshot"}
+	assert.Eventually(t, func() bool {
+		snapshot, err = vpcs.Apiclient.SnapshotService().GetSnapshot(share.ID, snapshot.ID, logger)
+		return err == nil && assert.ObjectsAreEqual(expected, snapshot.UserTags)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return sw.applied("content1") != "" }, 5*time.Second, 10*time.Millisecond)
+
+	// A resync of the same content does not update the snapshot again
+	calls := fakeVPC.CallCount("GetSnapshot")
+	content, err := snapshotClient.SnapshotV1().VolumeSnapshotContents().Get(ctx, "content1", metav1.GetOptions{})
+	require.NoError(t, err)
+	content.Labels = map[string]string{"app": "web"}
+	_, err = snapshotClient.SnapshotV1().VolumeSnapshotContents().Update(ctx, content, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Never(t, func() bool { return fakeVPC.CallCount("GetSnapshot") > calls }, 100*time.Millisecond, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("SnapshotWatcher did not stop")
+	}
+	assert.True(t, sw.queue.ShuttingDown())
+}
+
+// fakeVPCProvider returns session as the provider session
+type fakeVPCProvider struct {
+	*cloudprovider.FakeIBMCloudStorageProvider
+	session provider.Session
+}
+
+// GetProviderSession ...
+func (fp *fakeVPCProvider) GetProviderSession(ctx context.Context, logger *zap.Logger) (provider.Session, error) {
+	return fp.session, nil
+}
+
+// newFakeVPCSession returns a session talking to a fake VPC File API, whose resources settle after 10ms
+func newFakeVPCSession(t *testing.T, logger *zap.Logger) (*vpc_provider.VPCSession, *fakevpc.Server) {
+	fakeVPC := fakevpc.NewServer().WithTransitionDelay(10 * time.Millisecond)
+	t.Cleanup(fakeVPC.Close)
+
+	client, err := riaas.New(riaas.Config{BaseURL: fakeVPC.URL(), HTTPClient: http.DefaultClient})
+	require.NoError(t, err)
+	require.NoError(t, client.Login("token"))
+	return &vpc_provider.VPCSession{
+		Apiclient: client,
+		Logger:    logger,
+		RetryPolicy: &vpc_provider.RetryPolicy{
+			MaxAttempts: 20,
+			InitialGap:  10 * time.Millisecond,
+			MaxGap:      20 * time.Millisecond,
+			ConstantGap: 5 * time.Millisecond,
+		},
+	}, fakeVPC
+}
+
+// newTestSnapshotWatcher creates a watcher of the vpc-csi-driver VolumeSnapshotContents on top of snapshotClient
+func newTestSnapshotWatcher(t *testing.T, snapshotClient *snapshotfake.Clientset, cloudProvider cloudprovider.CloudProviderInterface, options Options) *SnapshotWatcher {
+	logger, _ := GetTestLogger(t)
+	return newSnapshotWatcher(logger, fake.NewSimpleClientset(), snapshotClient, "vpc-csi-driver", cloudProvider, options)
+}
+
+// newTestContent returns a ready vpc-csi-driver VolumeSnapshotContent of the share snapshot of handle
+func newTestContent(name string, handle string) *snapshotv1.VolumeSnapshotContent {
+	return &snapshotv1.VolumeSnapshotContent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: snapshotv1.VolumeSnapshotContentSpec{
+			Driver: "vpc-csi-driver",
+			VolumeSnapshotRef: v1.ObjectReference{
+				Namespace: "test-namespace",
+				Name:      "test-snapshot",
+			},
+		},
+		Status: &snapshotv1.VolumeSnapshotContentStatus{
+			SnapshotHandle: stringPtr(handle),
+			ReadyToUse:     boolPtr(true),
+		},
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,260 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package watcher ...
+package watcher
+
+import (
+	"fmt"
+	"strings"
+
+	vpc_provider "github.com/IBM/ibmcloud-volume-file-vpc/file/provider"
+	"go.uber.org/zap"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TagSource tells which metadata of the PVCs a mapped tag is read from
+type TagSource string
+
+const (
+	// PVCLabel reads the tag value from a label of the PVC
+	PVCLabel TagSource = "pvc-label"
+	// PVCAnnotation reads the tag value from an annotation of the PVC
+	PVCAnnotation TagSource = "pvc-annotation"
+	// NamespaceLabel reads the tag value from a label of the namespace of the PVC
+	NamespaceLabel TagSource = "namespace-label"
+	// NamespaceAnnotation reads the tag value from an annotation of the namespace of the PVC
+	NamespaceAnnotation TagSource = "namespace-annotation"
+
+	// maxTagLength is the maximum length of a tag
+	maxTagLength = 128
+)
+
+// TagMapping turns a label or an annotation of the PVCs, or of their namespace, into a TagKey:value tag of the
+// share of their PV. The tags of TagKey are owned by the watcher: they follow the label or annotation and are
+// removed along with it. Watching the PVCs and the namespaces needs the list and watch permissions on them
+type TagMapping struct {
+	// Source tells which metadata the value is read from
+	Source TagSource
+	// Key is the key of the label or annotation
+	Key string
+	// TagKey is the key of the share tag, it defaults to the name of Key without its prefix
+	TagKey string
+}
+
+// tagKey returns the key of the share tag
+func (mapping TagMapping) tagKey() string {
+	tagKey := mapping.TagKey
+	if tagKey == "" {
+		tagKey = mapping.Key[strings.LastIndex(mapping.Key, "/")+1:]
+	}
+	return strings.ToLower(sanitizeTag(tagKey))
+}
+
+// validate checks the mapping is usable
+func (mapping TagMapping) validate() error {
+	switch mapping.Source {
+	case PVCLabel, PVCAnnotation, NamespaceLabel, NamespaceAnnotation:
+	default:
+		return fmt.Errorf("unknown source %q of the tag mapping of %q", mapping.Source, mapping.Key)
+	}
+	if mapping.Key == "" {
+		return fmt.Errorf("the key of the %s tag mapping is required", mapping.Source)
+	}
+	tagKey := mapping.tagKey()
+	if tagKey == "" || strings.Contains(tagKey, ":") {
+		return fmt.Errorf("invalid tag key %q of the tag mapping of %q", tagKey, mapping.Key)
+	}
+	for _, managed := range vpc_provider.ManagedTagKeys {
+		if tagKey == managed {
+			return fmt.Errorf("the tag key %q of the tag mapping of %q is managed by the watcher", tagKey, mapping.Key)
+		}
+	}
+	return nil
+}
+
+// value returns the value of the label or annotation of the mapping, an empty string when there is none
+func (mapping TagMapping) value(pvc *v1.PersistentVolumeClaim, namespace *v1.Namespace) string {
+	var value string
+	switch {
+	case mapping.Source == PVCLabel && pvc != nil:
+		value = pvc.Labels[mapping.Key]
+	case mapping.Source == PVCAnnotation && pvc != nil:
+		value = pvc.Annotations[mapping.Key]
+	case mapping.Source == NamespaceLabel && namespace != nil:
+		value = namespace.Labels[mapping.Key]
+	case mapping.Source == NamespaceAnnotation && namespace != nil:
+		value = namespace.Annotations[mapping.Key]
+	}
+	return strings.TrimSpace(value)
+}
+
+// fromNamespace tells if the mapping reads the namespace of the PVC
+func (mapping TagMapping) fromNamespace() bool {
+	return mapping.Source == NamespaceLabel || mapping.Source == NamespaceAnnotation
+}
+
+// sanitizeTag replaces the characters a tag cannot hold with underscores
+func sanitizeTag(tag string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case strings.ContainsRune(" _-.:", r):
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(tag))
+}
+
+// validateTagMappings checks all the tag mappings of the watcher are usable
+func (pvw *PVWatcher) validateTagMappings() error {
+	for _, mapping := range pvw.options.TagMappings {
+		if err := mapping.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watchTagSources watches the PVCs, and the namespaces when some tag mappings read them, so that the tags of
+// their shares are updated when the mapped labels and annotations change
+func (pvw *PVWatcher) watchTagSources() {
+	if len(pvw.options.TagMappings) == 0 {
+		return
+	}
+
+	pvcInformer := pvw.informerFactory.Core().V1().PersistentVolumeClaims()
+	_, _ = pvcInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: pvw.updateClaim,
+	})
+	pvw.pvcLister = pvcInformer.Lister()
+	pvw.cacheSynced = append(pvw.cacheSynced, pvcInformer.Informer().HasSynced)
+
+	for _, mapping := range pvw.options.TagMappings {
+		if mapping.fromNamespace() {
+			namespaceInformer := pvw.informerFactory.Core().V1().Namespaces()
+			_, _ = namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+				UpdateFunc: pvw.updateNamespace,
+			})
+			pvw.namespaceLister = namespaceInformer.Lister()
+			pvw.cacheSynced = append(pvw.cacheSynced, namespaceInformer.Informer().HasSynced)
+			return
+		}
+	}
+}
+
+// updateClaim queues the bound PV of the PVC for a tag update when its mapped labels or annotations changed
+func (pvw *PVWatcher) updateClaim(oldobj, obj interface{}) {
+	oldpvc, _ := oldobj.(*v1.PersistentVolumeClaim)
+	pvc, _ := obj.(*v1.PersistentVolumeClaim)
+	if oldpvc == nil || pvc == nil || pvc.Spec.VolumeName == "" {
+		return
+	}
+	if !pvw.mappedValuesChanged(oldpvc, pvc, nil, nil) {
+		return
+	}
+	pvw.logger.Info("Mapped metadata of the PVC changed", zap.String("namespace", pvc.Namespace), zap.String("pvc", pvc.Name))
+	pvw.queueTagUpdate(pvc.Spec.VolumeName)
+}
+
+// updateNamespace queues the bound PVs of the PVCs of the namespace for a tag update when its mapped labels or
+// annotations changed
+func (pvw *PVWatcher) updateNamespace(oldobj, obj interface{}) {
+	oldNamespace, _ := oldobj.(*v1.Namespace)
+	namespace, _ := obj.(*v1.Namespace)
+	if oldNamespace == nil || namespace == nil {
+		return
+	}
+	if !pvw.mappedValuesChanged(nil, nil, oldNamespace, namespace) {
+		return
+	}
+	pvcs, err := pvw.pvcLister.PersistentVolumeClaims(namespace.Name).List(labels.Everything())
+	if err != nil {
+		pvw.logger.Warn("Failed to list the PVCs of the namespace", zap.String("namespace", namespace.Name), zap.Error(err))
+		return
+	}
+	pvw.logger.Info("Mapped metadata of the namespace changed", zap.String("namespace", namespace.Name), zap.Int("pvcs", len(pvcs)))
+	for _, pvc := range pvcs {
+		if pvc.Spec.VolumeName != "" {
+			pvw.queueTagUpdate(pvc.Spec.VolumeName)
+		}
+	}
+}
+
+// mappedValuesChanged tells if any mapped label or annotation differs between the old and new PVC or namespace
+func (pvw *PVWatcher) mappedValuesChanged(oldpvc, pvc *v1.PersistentVolumeClaim, oldNamespace, namespace *v1.Namespace) bool {
+	for _, mapping := range pvw.options.TagMappings {
+		if mapping.value(oldpvc, oldNamespace) != mapping.value(pvc, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// queueTagUpdate queues the PV for a tag update when it is one of the watched ones
+func (pvw *PVWatcher) queueTagUpdate(name string) {
+	pv, err := pvw.pvLister.Get(name)
+	if err != nil || !pvw.filter(pv) {
+		return
+	}
+	pvw.setTagUpdate(name, true)
+	pvw.queue.Add(name)
+}
+
+// mappedTags returns the tags mapped from the labels and annotations of the PVC of the PV and of its namespace
+func (pvw *PVWatcher) mappedTags(pv *v1.PersistentVolume, ctxLogger *zap.Logger) []string {
+	if len(pvw.options.TagMappings) == 0 || pv.Spec.ClaimRef == nil || pvw.pvcLister == nil {
+		return nil
+	}
+
+	pvc, err := pvw.pvcLister.PersistentVolumeClaims(pv.Spec.ClaimRef.Namespace).Get(pv.Spec.ClaimRef.Name)
+	if err != nil {
+		ctxLogger.Debug("No PVC to map tags from", zap.Error(err))
+		pvc = nil
+	}
+	var namespace *v1.Namespace
+	if pvw.namespaceLister != nil {
+		namespace, err = pvw.namespaceLister.Get(pv.Spec.ClaimRef.Namespace)
+		if err != nil {
+			ctxLogger.Debug("No namespace to map tags from", zap.Error(err))
+			namespace = nil
+		}
+	}
+
+	var tags []string
+	for _, mapping := range pvw.options.TagMappings {
+		if value := sanitizeTag(mapping.value(pvc, namespace)); value != "" {
+			tag := mapping.tagKey() + ":" + value
+			if len(tag) > maxTagLength {
+				tag = tag[:maxTagLength]
+			}
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ownedTagKeys returns the keys of the share tags the tag mappings own
+func (pvw *PVWatcher) ownedTagKeys() []string {
+	var keys []string
+	for _, mapping := range pvw.options.TagMappings {
+		keys = append(keys, mapping.tagKey())
+	}
+	return keys
+}
@@ -0,0 +1,189 @@
+/**
+ * Copyright 2026 IBM Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package watcher ...
+package watcher
+
+import (
+	"context"
+	"testing"
+
+	cloudprovider "github.com/IBM/ibmcloud-volume-file-vpc/pkg/ibmcloudprovider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTagMappingValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mapping     TagMapping
+		tagKey      string
+		expectedErr bool
+	}{
+		{
+			name:    "prefix of the label is dropped",
+			mapping: TagMapping{Source: PVCLabel, Key: "example.com/Cost-Center"},
+			tagKey:  "cost-center",
+		}, {
+			name:    "tag key is sanitized",
+			mapping: TagMapping{Source: NamespaceAnnotation, Key: "team", TagKey: "owner/team"},
+			tagKey:  "owner_team",
+		}, {
+			name:        "unknown source",
+			mapping:     TagMapping{Source: "pv-label", Key: "team"},
+			tagKey:      "team",
+			expectedErr: true,
+		}, {
+			name:        "no key",
+			mapping:     TagMapping{Source: PVCLabel},
+			expectedErr: true,
+		}, {
+			name:        "tag key with a colon",
+			mapping:     TagMapping{Source: PVCLabel, Key: "team", TagKey: "a:b"},
+			tagKey:      "a:b",
+			expectedErr: true,
+		}, {
+			name:        "tag key managed by the watcher",
+			mapping:     TagMapping{Source: PVCLabel, Key: "example.com/namespace"},
+			tagKey:      "namespace",
+			expectedErr: true,
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.name, func(t *testing.T) {
+			assert.Equal(t, testcase.tagKey, testcase.mapping.tagKey())
+			err := testcase.mapping.validate()
+			if testcase.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMappedTags(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
+	pvw := newTestPVWatcher(t, fake.NewSimpleClientset(), fakeIBMCloudStorageProvider, Options{TagMappings: []TagMapping{
+		{Source: PVCLabel, Key: "example.com/cost-center"},
+		{Source: PVCAnnotation, Key: "owner", TagKey: "pvc-owner"},
+		{Source: NamespaceLabel, Key: "team"},
+		{Source: NamespaceAnnotation, Key: "missing"},
+	}})
+
+	pv := newTestPV("test-pv")
+	pvc := newTestPVC("test-pv", map[string]string{"example.com/cost-center": "cc/42"})
+	pvc.Annotations = map[string]string{"owner": " alice "}
+	require.NoError(t, pvw.informerFactory.Core().V1().PersistentVolumeClaims().Informer().GetStore().Add(pvc))
+	require.NoError(t, pvw.informerFactory.Core().V1().Namespaces().Informer().GetStore().Add(newTestNamespace(map[string]string{"team": "storage"})))
+
+	_, tags := pvw.getTags(pv, logger)
+	assert.Equal(t, []string{"cost-center:cc_42", "pvc-owner:alice", "team:storage"}, tags[len(tags)-3:])
+	assert.Equal(t, []string{"cost-center", "pvc-owner", "team", "missing"}, pvw.ownedTagKeys())
+	assert.Len(t, pvw.cacheSynced, 3)
+
+	// A PV without PVC gets no mapped tags
+	pv.Spec.ClaimRef.Name = "other-pvc"
+	_, tags = pvw.getTags(pv, logger)
+	assert.Equal(t, "team:storage", tags[len(tags)-1])
+	assert.Equal(t, "provisioner:vpc-csi-driver", tags[len(tags)-2])
+
+	// Without tag mappings neither the PVCs nor the namespaces are watched
+	pvw = newTestPVWatcher(t, fake.NewSimpleClientset(), fakeIBMCloudStorageProvider, Options{})
+	assert.Nil(t, pvw.pvcLister)
+	assert.Nil(t, pvw.namespaceLister)
+	assert.Nil(t, pvw.mappedTags(pv, logger))
+	assert.Nil(t, pvw.ownedTagKeys())
+
+	pvw = newTestPVWatcher(t, fake.NewSimpleClientset(), fakeIBMCloudStorageProvider, Options{TagMappings: []TagMapping{{Source: "pv-label", Key: "team"}}})
+	assert.Error(t, pvw.Run(context.Background()))
+}
+
+func TestUpdateClaimAndNamespace(t *testing.T) {
+	logger, teardown := GetTestLogger(t)
+	defer teardown()
+	fakeIBMCloudStorageProvider, _ := cloudprovider.NewFakeIBMCloudStorageProvider("configPath", logger)
+	pvw := newTestPVWatcher(t, fake.NewSimpleClientset(), fakeIBMCloudStorageProvider, Options{TagMappings: []TagMapping{
+		{Source: PVCLabel, Key: "cost-center"},
+		{Source: NamespaceLabel, Key: "team"},
+	}})
+
+	require.NoError(t, pvw.informerFactory.Core().V1().PersistentVolumes().Informer().GetStore().Add(newTestPV("test-pv")))
+	otherDriverPV := newTestPV("other-pv")
+	otherDriverPV.Spec.CSI.Driver = "other-driver"
+	require.NoError(t, pvw.informerFactory.Core().V1().PersistentVolumes().Informer().GetStore().Add(otherDriverPV))
+	pvc := newTestPVC("test-pv", map[string]string{"cost-center": "a1", "app": "web"})
+	require.NoError(t, pvw.informerFactory.Core().V1().PersistentVolumeClaims().Informer().GetStore().Add(pvc))
+	otherPVC := newTestPVC("other-pv", nil)
+	otherPVC.Name = "other-pvc"
+	require.NoError(t, pvw.informerFactory.Core().V1().PersistentVolumeClaims().Informer().GetStore().Add(otherPVC))
+
+	// Changes of labels which are not mapped are skipped
+	relabeled := pvc.DeepCopy()
+	relabeled.Labels["app"] = "db"
+	pvw.updateClaim(pvc, relabeled)
+	assert.Equal(t, 0, pvw.queue.Len())
+
+	// Changes of mapped labels queue the PV for a tag update, the PV did not change so its metadata is not updated
+	relabeled.Labels["cost-center"] = "b2"
+	pvw.updateClaim(pvc, relabeled)
+	assert.Equal(t, 1, pvw.queue.Len())
+	assert.True(t, pvw.tagUpdate("test-pv"))
+	assert.False(t, pvw.metadataUpdate("test-pv"))
+	assert.True(t, pvw.takeTagUpdate("test-pv"))
+	assert.False(t, pvw.tagUpdate("test-pv"))
+
+	// Namespace changes queue the PVs of its PVCs which are watched
+	namespace := newTestNamespace(map[string]string{"team": "storage"})
+	renamed := newTestNamespace(map[string]string{"team": "platform"})
+	pvw.updateNamespace(namespace, namespace)
+	assert.False(t, pvw.tagUpdate("test-pv"))
+	pvw.updateNamespace(namespace, renamed)
+	assert.True(t, pvw.tagUpdate("test-pv"))
+	assert.False(t, pvw.metadataUpdate("test-pv"))
+	assert.False(t, pvw.tagUpdate("other-pv"))
+	assert.Equal(t, 1, pvw.queue.Len())
+}
+
+// newTestPVC returns the PVC with labels of the PV returned by newTestPV
+func newTestPVC(volumeName string, labels map[string]string) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pvc",
+			Namespace: "test-namespace",
+			Labels:    labels,
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			VolumeName: volumeName,
+		},
+	}
+}
+
+// newTestNamespace returns the namespace with labels of the PVC returned by newTestPVC
+func newTestNamespace(labels map[string]string) *v1.Namespace {
+	return &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-namespace",
+			Labels: labels,
+		},
+	}
+}
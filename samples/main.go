@@ -26,6 +26,8 @@ import (
 	"go.uber.org/zap/zapcore"
 	"golang.org/x/net/context"
 
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/fakevpc"
+	"github.com/IBM/ibmcloud-volume-file-vpc/common/vpcclient/models"
 	provider_file_util "github.com/IBM/ibmcloud-volume-file-vpc/file/utils"
 	vpcfileconfig "github.com/IBM/ibmcloud-volume-file-vpc/file/vpcconfig"
 	"github.com/IBM/ibmcloud-volume-interface/config"
@@ -39,6 +41,7 @@ import (
 
 var (
 	defaultChoice = flag.Int("choice", 0, "Choice")
+	fakeVPC       = flag.Bool("fake-vpc", false, "Run against an in-memory fake VPC File API instead of IBM Cloud")
 )
 
 func getContextLogger() (*zap.Logger, zap.AtomicLevel) {
@@ -73,6 +76,15 @@ func updateRequestID(err error, requestID string) error {
 	return usrError
 }
 
+// openSession opens a provider session, with a fixed token when running against the fake VPC File API as IAM is not reachable then
+func openSession(ctx context.Context, prov local.Provider, vpcFileConfig *vpcfileconfig.VPCFileConfig, providerName string, ctxLogger *zap.Logger) (provider.Session, error) {
+	if *fakeVPC {
+		return prov.OpenSession(ctx, provider.ContextCredentials{AuthType: provider.IAMAccessToken, Credential: "fake-vpc-token"}, ctxLogger)
+	}
+	sess, _, err := provider_file_util.OpenProviderSessionWithContext(ctx, prov, vpcFileConfig, providerName, ctxLogger)
+	return sess, err
+}
+
 func main() {
 	snapshotID := ""
 	SourceVolumeID := ""
@@ -91,6 +103,15 @@ func main() {
 
 	logger.Info("Global Configuration is================", zap.Reflect("Config", conf))
 
+	if *fakeVPC {
+		fake := fakevpc.NewServer()
+		defer fake.Close()
+		subnet := fake.AddSubnet(models.Subnet{Name: "fake-subnet", VPC: &provider.VPC{ID: "fake-vpc"}, Zone: &models.Zone{Name: fakevpc.DefaultZone}})
+		logger.Info("Using the fake VPC File API", zap.String("URL", fake.URL()), zap.String("SubnetID", subnet.ID))
+		conf.VPC.G2EndpointURL = fake.URL()
+		conf.VPC.G2EndpointPrivateURL = ""
+	}
+
 	// Check if debug log level enabled or not
 	if conf.Server != nil && conf.Server.DebugTrace {
 		traceLevel.SetLevel(zap.DebugLevel)
@@ -149,7 +170,7 @@ func main() {
 			ctxLogger.Error("Not able to get the said provider, might be its not registered", local.ZapError(err))
 			continue
 		}
-		sess, _, err := provider_file_util.OpenProviderSessionWithContext(ctx, prov, vpcFileConfig, providerName, ctxLogger)
+		sess, err := openSession(ctx, prov, vpcFileConfig, providerName, ctxLogger)
 		if err != nil {
 			ctxLogger.Error("Failed to get session", zap.Reflect("Error", err))
 			continue